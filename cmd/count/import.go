@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/muhlemmer/count/internal/accesslog"
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+)
+
+// DefaultImportBatchSize is the amount of daily totals
+// imported per transaction by the import command.
+const DefaultImportBatchSize = 1000
+
+const importUsage = `Usage: count import [flags] [FILE...]
+
+Import access logs into the daily totals, adding to existing totals unless -replace is set.
+Logs are read from stdin when no FILE or - is given.
+
+Flags:
+`
+
+// storeOpener opens the storage backend, which is closed by the returned function.
+type storeOpener func(ctx context.Context) (store.Store, func(), error)
+
+type importOptions struct {
+	format    accesslog.Format
+	dryRun    bool
+	mode      store.ImportMode
+	service   string
+	batchSize int
+	loc       *time.Location
+	files     []string
+}
+
+func parseImportArgs(args []string, output io.Writer, loc *time.Location) (*importOptions, error) {
+	opts := &importOptions{loc: loc}
+
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), importUsage)
+		flags.PrintDefaults()
+	}
+	format := flags.String("format", string(accesslog.Combined), "access log format: clf, combined or json")
+	tz := flags.String("tz", "", "IANA time zone to count dates in, "+TimeZoneEnvKey+" when empty")
+	flags.BoolVar(&opts.dryRun, "dry-run", false, "print the summary without writing to the database")
+	replace := flags.Bool("replace", false, "replace existing daily totals instead of adding to them")
+	flags.StringVar(&opts.service, "service", store.DefaultService, "service to import the requests for")
+	flags.IntVar(&opts.batchSize, "batch-size", DefaultImportBatchSize, "daily totals imported per transaction")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if *replace {
+		opts.mode = store.Replace
+	}
+
+	var err error
+	if opts.format, err = accesslog.ParseFormat(*format); err != nil {
+		return nil, err
+	}
+	if *tz != "" {
+		if opts.loc, err = loadLocation(*tz); err != nil {
+			return nil, err
+		}
+	}
+	if opts.batchSize < 1 {
+		return nil, fmt.Errorf("batch-size must be positive")
+	}
+	if opts.service == "" {
+		return nil, fmt.Errorf("service must not be empty")
+	}
+
+	opts.files = flags.Args()
+	if len(opts.files) == 0 {
+		opts.files = []string{"-"}
+	}
+	return opts, nil
+}
+
+// readAccessLog parses file, or stdin for -, into agg.
+func readAccessLog(agg *accesslog.Aggregator, format accesslog.Format, file string, stdin io.Reader) error {
+	if file == "-" {
+		return accesslog.Parse(stdin, format, agg.Add)
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err = accesslog.Parse(f, format, agg.Add); err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+	return nil
+}
+
+// importBatches imports totals of service in batches of size.
+// The amount of imported totals is returned, also on error.
+func importBatches(ctx context.Context, importer store.Importer, service string, totals []*countv1.MethodCount, mode store.ImportMode, size int) (imported int, err error) {
+	for imported < len(totals) {
+		end := imported + size
+		if end > len(totals) {
+			end = len(totals)
+		}
+		if err = importer.ImportDailyTotals(ctx, service, totals[imported:end], mode); err != nil {
+			return imported, err
+		}
+		imported = end
+	}
+	return imported, nil
+}
+
+func writeImportSummary(w io.Writer, sum *accesslog.Summary, totals []*countv1.MethodCount, imported int, dryRun bool) error {
+	days := make(map[string]struct{})
+	for _, mc := range totals {
+		days[datepb.Time(mc.GetDate()).Format("2006-01-02")] = struct{}{}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Requests:\t%d\n", sum.Requests)
+	fmt.Fprintf(tw, "Unknown methods:\t%d\n", sum.UnknownMethod)
+	fmt.Fprintf(tw, "Malformed lines:\t%d\n", sum.Malformed)
+	if sum.Requests > 0 {
+		fmt.Fprintf(tw, "First request:\t%s\n", sum.First.Format(time.RFC3339))
+		fmt.Fprintf(tw, "Last request:\t%s\n", sum.Last.Format(time.RFC3339))
+	}
+	fmt.Fprintf(tw, "Days:\t%d\n", len(days))
+	fmt.Fprintf(tw, "Daily totals:\t%d\n", len(totals))
+	if dryRun {
+		fmt.Fprintf(tw, "Imported:\t0 (dry run)\n")
+	} else {
+		fmt.Fprintf(tw, "Imported:\t%d\n", imported)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(sum.Errors) > 0 {
+		fmt.Fprintln(w, "\nMalformed lines:")
+		for _, err := range sum.Errors {
+			fmt.Fprintln(w, " ", err)
+		}
+		if sum.Malformed > len(sum.Errors) {
+			fmt.Fprintf(w, "  and %d more\n", sum.Malformed-len(sum.Errors))
+		}
+	}
+	return nil
+}
+
+// runImport parses access logs into daily totals,
+// imports them into the store and writes a summary to w.
+// The store is not opened in dry run mode.
+func runImport(ctx context.Context, w, stderr io.Writer, stdin io.Reader, args []string, loc *time.Location, open storeOpener) error {
+	opts, err := parseImportArgs(args, stderr, loc)
+	if err != nil {
+		return err
+	}
+
+	agg := accesslog.NewAggregator(opts.loc)
+	for _, file := range opts.files {
+		if err = readAccessLog(agg, opts.format, file, stdin); err != nil {
+			return err
+		}
+	}
+	totals := agg.DailyTotals()
+
+	var imported int
+	if !opts.dryRun && len(totals) > 0 {
+		s, closeStore, err := open(ctx)
+		if err != nil {
+			return err
+		}
+		defer closeStore()
+
+		importer, ok := s.(store.Importer)
+		if !ok {
+			return errors.New("import: storage backend does not support importing daily totals")
+		}
+
+		imported, err = importBatches(ctx, importer, opts.service, totals, opts.mode, opts.batchSize)
+		if err != nil {
+			writeImportSummary(w, &agg.Summary, totals, imported, opts.dryRun)
+			last := totals[imported]
+			return fmt.Errorf("import: imported %d of %d daily totals, failed from %s: %w",
+				imported, len(totals), datepb.Time(last.GetDate()).Format("2006-01-02"), err,
+			)
+		}
+	}
+
+	return writeImportSummary(w, &agg.Summary, totals, imported, opts.dryRun)
+}
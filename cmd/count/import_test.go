@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+	"google.golang.org/genproto/googleapis/type/date"
+)
+
+const testAccessLog = `127.0.0.1 - - [16/Oct/2022:10:00:00 +0000] "GET /users?page=2 HTTP/1.1" 200 512 "-" "curl/7.85.0"
+127.0.0.1 - - [16/Oct/2022:11:00:00 +0000] "GET /users HTTP/1.1" 200 512 "-" "curl/7.85.0"
+127.0.0.1 - - [16/Oct/2022:23:30:00 +0000] "POST /users HTTP/1.1" 201 0 "-" "curl/7.85.0"
+not a log line
+`
+
+func Test_runImport(t *testing.T) {
+	ctx := context.Background()
+	zurich, err := time.LoadLocation("Europe/Zurich")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(t.TempDir(), "access.log")
+	if err = os.WriteFile(file, []byte(testAccessLog), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	mem := store.NewMemory()
+	day := &date.Date{Year: 2022, Month: 10, Day: 16}
+	if err = mem.ImportDailyTotals(ctx, store.DefaultService, []*countv1.MethodCount{
+		{Method: countv1.Method_GET, Path: "/users", Count: 1, Date: day},
+	}, store.Merge); err != nil {
+		t.Fatal(err)
+	}
+
+	var opened bool
+	open := func(context.Context) (store.Store, func(), error) {
+		opened = true
+		return mem, func() {}, nil
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		wantOpened bool
+		wantOut    []string
+		wantErr    bool
+	}{
+		{
+			name:    "dry run",
+			args:    []string{"-dry-run", file},
+			wantOut: []string{"Requests:         3", "Malformed lines:  1", "Days:             2", "Imported:         0 (dry run)", "line 4:"},
+		},
+		{
+			name:       "stdin",
+			args:       []string{"-tz=UTC"},
+			stdin:      testAccessLog,
+			wantOpened: true,
+			wantOut:    []string{"Days:             1", "Imported:         2"},
+		},
+		{
+			name:       "replace",
+			args:       []string{"-tz=UTC", "-replace", "-batch-size=1", file},
+			wantOpened: true,
+			wantOut:    []string{"Imported:         2"},
+		},
+		{
+			name:       "service",
+			args:       []string{"-tz=UTC", "-service=shop", file},
+			wantOpened: true,
+			wantOut:    []string{"Imported:         2"},
+		},
+		{
+			name:    "empty service",
+			args:    []string{"-service=", file},
+			wantErr: true,
+		},
+		{
+			name:    "bad format",
+			args:    []string{"-format=xml", file},
+			wantErr: true,
+		},
+		{
+			name:    "local time zone",
+			args:    []string{"-tz=Local", file},
+			wantErr: true,
+		},
+		{
+			name:    "missing file",
+			args:    []string{filepath.Join(t.TempDir(), "missing.log")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opened = false
+			var out bytes.Buffer
+			err := runImport(ctx, &out, io.Discard, strings.NewReader(tt.stdin), tt.args, zurich, open)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runImport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if opened != tt.wantOpened {
+				t.Errorf("runImport() opened store = %v, want %v", opened, tt.wantOpened)
+			}
+			for _, want := range tt.wantOut {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("runImport() output =\n%s\nwant %q", out.String(), want)
+				}
+			}
+		})
+	}
+
+	got, err := mem.ListDailyTotals(ctx, store.DefaultService, datepb.Time(day), datepb.Time(day))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// replaced after merging
+	if len(got) != 2 || got[0].GetCount() != 2 || got[1].GetCount() != 1 {
+		t.Errorf("merged totals = %v", got)
+	}
+
+	got, err = mem.ListDailyTotals(ctx, "shop", datepb.Time(day), datepb.Time(day))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].GetCount() != 2 || got[1].GetCount() != 1 {
+		t.Errorf("service totals = %v", got)
+	}
+}
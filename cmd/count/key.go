@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/muhlemmer/count/internal/service"
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+)
+
+const keyUsage = `usage: count key create NAME SCOPE...
+where SCOPE is ingest, read or admin`
+
+var errKeyUsage = errors.New(keyUsage)
+
+// parseScopes parses scope names, such as read,
+// into scopes.
+func parseScopes(names []string) ([]countv1.Scope, error) {
+	scopes := make([]countv1.Scope, len(names))
+	for i, name := range names {
+		v, ok := countv1.Scope_value["SCOPE_"+strings.ToUpper(name)]
+		if !ok || v == int32(countv1.Scope_SCOPE_UNSPECIFIED) {
+			return nil, fmt.Errorf("unknown scope %q, use ingest, read or admin", name)
+		}
+		scopes[i] = countv1.Scope(v)
+	}
+	return scopes, nil
+}
+
+// runKey creates an API key directly in the store,
+// which is used to create the first admin key.
+// The key is not bound to a service.
+// Other keys can be managed over the API, using countctl.
+func runKey(ctx context.Context, w io.Writer, args []string, open storeOpener) error {
+	if len(args) < 3 || args[0] != "create" {
+		return errKeyUsage
+	}
+	scopes, err := parseScopes(args[2:])
+	if err != nil {
+		return err
+	}
+
+	s, closeStore, err := open(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	keys, ok := s.(store.KeyStore)
+	if !ok {
+		return errors.New("key: storage backend does not support api keys")
+	}
+
+	key, apiKey, err := service.NewAPIKey(ctx, keys, args[1], "", scopes)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Created api key %d %q. Store the key now, it is not shown again:\n%s\n", apiKey.GetId(), apiKey.GetName(), key)
+	return nil
+}
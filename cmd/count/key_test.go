@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/muhlemmer/count/internal/service"
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+)
+
+func Test_runKey(t *testing.T) {
+	ctx := context.Background()
+	mem := store.NewMemory()
+	open := func(context.Context) (store.Store, func(), error) {
+		return mem, func() {}, nil
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{"no args", nil, true},
+		{"unknown command", []string{"delete", "foo", "read"}, true},
+		{"no scopes", []string{"create", "foo"}, true},
+		{"unknown scope", []string{"create", "foo", "write"}, true},
+		{"unspecified scope", []string{"create", "foo", "unspecified"}, true},
+		{"create", []string{"create", "admin", "admin", "READ"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := runKey(ctx, &out, tt.args, open); (err != nil) != tt.wantErr {
+				t.Fatalf("runKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			key, err := mem.LookupAPIKey(ctx, service.HashAPIKey(lines[len(lines)-1]))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if key.GetName() != "admin" || len(key.GetScopes()) != 2 || key.GetScopes()[1] != countv1.Scope_SCOPE_ADMIN {
+				t.Errorf("created key = %v", key)
+			}
+		})
+	}
+}
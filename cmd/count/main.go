@@ -2,15 +2,24 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
+	_ "time/tzdata"
 
 	"github.com/muhlemmer/count/internal/db"
 	"github.com/muhlemmer/count/internal/db/migrations"
+	"github.com/muhlemmer/count/internal/db/sqlite"
 	"github.com/muhlemmer/count/internal/service"
+	"github.com/muhlemmer/count/internal/store"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -24,7 +33,330 @@ const (
 	DefaultDSN        = "postgresql://muhlemmer@db:5432/muhlemmer?sslmode=disable"
 )
 
-func run() int {
+// Read replica configuration, only supported on PostgreSQL and CockroachDB.
+// ListDailyTotals and GetPeriodTotals are served by the replica,
+// unless a request asks for strong freshness. Reads fall back to
+// the primary while the replica is unavailable.
+// Follower reads run those queries as of follower_read_timestamp()
+// on CockroachDB, so that any node can serve them.
+const (
+	ReadDSNEnvKey       = "DB_READ_URL"
+	FollowerReadsEnvKey = "DB_FOLLOWER_READS"
+)
+
+// Service configuration
+const (
+	TimeZoneEnvKey  = "TIME_ZONE"
+	DefaultTimeZone = "UTC"
+)
+
+// Add configuration.
+// The amount of concurrent inserts of all Add streams is bounded.
+// Streams block while all inserts are in flight.
+const (
+	MaxInsertsEnvKey = "ADD_MAX_INSERTS"
+)
+
+// Metrics configuration.
+// When set, server metrics are served on the address
+// in expvar format, at /debug/vars.
+const (
+	MetricsAddrEnvKey = "METRICS_ADDR"
+)
+
+// Cache configuration.
+// Results of ListDailyTotals and GetPeriodTotals for intervals before today
+// are cached in memory up to the estimated size in bytes.
+// The TTL is parsed by time.ParseDuration and defaults to 10m.
+// The cache is disabled when the size is not set.
+const (
+	CacheMaxBytesEnvKey = "CACHE_MAX_BYTES"
+	CacheTTLEnvKey      = "CACHE_TTL"
+)
+
+// Authentication configuration.
+// When enabled, every RPC requires an API key with the matching scope.
+// The first admin key can be created with "count key create".
+const (
+	AuthEnabledEnvKey = "AUTH_ENABLED"
+)
+
+// Retention configuration.
+// Durations are parsed by time.ParseDuration, for example "720h".
+// Pruning is disabled when a max age is not set.
+const (
+	RequestsMaxAgeEnvKey    = "RETENTION_REQUESTS_MAX_AGE"
+	DailyTotalsMaxAgeEnvKey = "RETENTION_DAILY_TOTALS_MAX_AGE"
+	KeepPeriodTotalsEnvKey  = "RETENTION_KEEP_PERIOD_TOTALS"
+	PruneIntervalEnvKey     = "RETENTION_PRUNE_INTERVAL"
+	DefaultPruneInterval    = time.Hour
+
+	// obsoleteDownsampleEnvKey used to sum daily totals into monthly totals,
+	// which are now always kept up to date. It is rejected,
+	// as its value does not carry over to KeepPeriodTotalsEnvKey.
+	obsoleteDownsampleEnvKey = "RETENTION_DOWNSAMPLE_MONTHLY"
+)
+
+// Partitioning configuration, only supported on PostgreSQL.
+// count.requests is partitioned by the migrations.
+// Daily partitions are created when the amount
+// of days to create partitions for in advance is set.
+const (
+	PartitionDaysEnvKey      = "PARTITION_REQUESTS_DAYS_AHEAD"
+	PartitionIntervalEnvKey  = "PARTITION_INTERVAL"
+	DefaultPartitionInterval = time.Hour
+)
+
+// Limit configuration of datapoints added by the Add RPC.
+// Rates are datapoints per second and disabled when not set.
+// Bursts default to the rate.
+// The limit mode is "reject" (default) or "sample",
+// which drops datapoints over the rate limits.
+// Service quotas override the daily quota, as a comma separated
+// list of service=quota pairs, for example "shop=100000,blog=0".
+// A quota of zero is unlimited.
+const (
+	LimitGlobalRateEnvKey  = "LIMIT_GLOBAL_RATE"
+	LimitGlobalBurstEnvKey = "LIMIT_GLOBAL_BURST"
+	LimitClientRateEnvKey  = "LIMIT_CLIENT_RATE"
+	LimitClientBurstEnvKey = "LIMIT_CLIENT_BURST"
+	LimitModeEnvKey        = "LIMIT_MODE"
+	QuotaDailyEnvKey       = "QUOTA_DAILY"
+	QuotaServicesEnvKey    = "QUOTA_DAILY_SERVICES"
+)
+
+// Label configuration of datapoints added by the Add RPC.
+// Label keys is a comma separated allow-list, for example "region,version".
+// Labels are rejected when not set.
+// Label max values is the amount of distinct values of each key and service,
+// after which values are counted as "__overflow__".
+// Defaults to service.DefaultMaxLabelValues.
+// Label max sets is the amount of distinct label sets of each service,
+// after which label sets are counted as "__overflow__=__overflow__".
+// Defaults to service.DefaultMaxLabelSets.
+const (
+	LabelKeysEnvKey      = "LABEL_KEYS"
+	LabelMaxValuesEnvKey = "LABEL_MAX_VALUES"
+	LabelMaxSetsEnvKey   = "LABEL_MAX_SETS"
+)
+
+// loadLocation loads the IANA time zone name.
+// "Local" is rejected, as its name is not understood by the database.
+func loadLocation(name string) (*time.Location, error) {
+	if name == "Local" {
+		return nil, errors.New(`"Local" is not supported, use an IANA time zone name`)
+	}
+	return time.LoadLocation(name)
+}
+
+func durationFromEnv(key string, def time.Duration) time.Duration {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		panic(fmt.Errorf("%s: %w", key, err))
+	}
+	return d
+}
+
+func authEnabled() bool {
+	v, ok := os.LookupEnv(AuthEnabledEnvKey)
+	if !ok {
+		return false
+	}
+	enabled, err := strconv.ParseBool(v)
+	if err != nil {
+		panic(fmt.Errorf("%s: %w", AuthEnabledEnvKey, err))
+	}
+	return enabled
+}
+
+func retentionFromEnv() service.RetentionPolicy {
+	policy := service.RetentionPolicy{
+		RequestsMaxAge:    durationFromEnv(RequestsMaxAgeEnvKey, 0),
+		DailyTotalsMaxAge: durationFromEnv(DailyTotalsMaxAgeEnvKey, 0),
+	}
+
+	if _, ok := os.LookupEnv(obsoleteDownsampleEnvKey); ok {
+		panic(fmt.Errorf("%s is replaced by %s", obsoleteDownsampleEnvKey, KeepPeriodTotalsEnvKey))
+	}
+	if v, ok := os.LookupEnv(KeepPeriodTotalsEnvKey); ok {
+		var err error
+		if policy.KeepPeriodTotals, err = strconv.ParseBool(v); err != nil {
+			panic(fmt.Errorf("%s: %w", KeepPeriodTotalsEnvKey, err))
+		}
+	}
+
+	return policy
+}
+
+func parseEnv[T any](key string, parse func(string) (T, error)) T {
+	var v T
+	if s, ok := os.LookupEnv(key); ok {
+		var err error
+		if v, err = parse(s); err != nil {
+			panic(fmt.Errorf("%s: %w", key, err))
+		}
+	}
+	return v
+}
+
+func parseFloat(s string) (float64, error) { return strconv.ParseFloat(s, 64) }
+func parseInt(s string) (int64, error)     { return strconv.ParseInt(s, 10, 64) }
+
+func parseLimitMode(s string) (service.LimitMode, error) {
+	switch s {
+	case "reject":
+		return service.LimitReject, nil
+	case "sample":
+		return service.LimitSample, nil
+	default:
+		return 0, fmt.Errorf("invalid limit mode %q, use reject or sample", s)
+	}
+}
+
+func parseServiceQuotas(s string) (map[string]int64, error) {
+	quotas := make(map[string]int64)
+	for _, pair := range strings.Split(s, ",") {
+		name, v, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid service quota %q, use service=quota", pair)
+		}
+		quota, err := parseInt(v)
+		if err != nil {
+			return nil, err
+		}
+		quotas[name] = quota
+	}
+	return quotas, nil
+}
+
+func limitsFromEnv() service.LimitPolicy {
+	return service.LimitPolicy{
+		Global: service.RateLimit{
+			Rate:  parseEnv(LimitGlobalRateEnvKey, parseFloat),
+			Burst: int(parseEnv(LimitGlobalBurstEnvKey, parseInt)),
+		},
+		Client: service.RateLimit{
+			Rate:  parseEnv(LimitClientRateEnvKey, parseFloat),
+			Burst: int(parseEnv(LimitClientBurstEnvKey, parseInt)),
+		},
+		Mode:          parseEnv(LimitModeEnvKey, parseLimitMode),
+		DailyQuota:    parseEnv(QuotaDailyEnvKey, parseInt),
+		ServiceQuotas: parseEnv(QuotaServicesEnvKey, parseServiceQuotas),
+	}
+}
+
+func parseLabelKeys(s string) ([]string, error) {
+	var keys []string
+	for _, key := range strings.Split(s, ",") {
+		if key = strings.TrimSpace(key); key == "" {
+			return nil, fmt.Errorf("empty label key in %q", s)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func labelsFromEnv() service.LabelPolicy {
+	return service.LabelPolicy{
+		Keys:      parseEnv(LabelKeysEnvKey, parseLabelKeys),
+		MaxValues: int(parseEnv(LabelMaxValuesEnvKey, parseInt)),
+		MaxSets:   int(parseEnv(LabelMaxSetsEnvKey, parseInt)),
+	}
+}
+
+// databaseDSNs returns the DSN for the migrations and the storage backend,
+// chosen by the scheme of dsn:
+//   - sqlite://path/to/file.db for an embedded SQLite database.
+//   - cockroachdb:// for CockroachDB.
+//   - postgresql:// for PostgreSQL, or CockroachDB when migrDriver is cockroachdb.
+func databaseDSNs(dsn, migrDriver string) (migrDSN, storeDSN string, err error) {
+	scheme, rest, ok := strings.Cut(dsn, "://")
+	if !ok {
+		return "", "", fmt.Errorf("%s: missing scheme", DSNEnvKey)
+	}
+
+	switch scheme {
+	case "sqlite":
+		return dsn, dsn, nil
+	case "cockroachdb":
+		return dsn, "postgresql://" + rest, nil
+	case "postgresql", "postgres":
+		return migrDriver + "://" + rest, dsn, nil
+	default:
+		return "", "", fmt.Errorf("%s: unsupported scheme %q", DSNEnvKey, scheme)
+	}
+}
+
+// parseReadDSN returns the DSN of a read replica,
+// with a postgresql:// or cockroachdb:// scheme.
+func parseReadDSN(dsn string) (string, error) {
+	scheme, rest, ok := strings.Cut(dsn, "://")
+	if !ok {
+		return "", errors.New("missing scheme")
+	}
+
+	switch scheme {
+	case "cockroachdb":
+		return "postgresql://" + rest, nil
+	case "postgresql", "postgres":
+		return dsn, nil
+	default:
+		return "", fmt.Errorf("unsupported scheme %q", scheme)
+	}
+}
+
+func dbOptionsFromEnv() []db.Option {
+	var opts []db.Option
+	if dsn := parseEnv(ReadDSNEnvKey, parseReadDSN); dsn != "" {
+		opts = append(opts, db.WithReplica(dsn))
+	}
+	if parseEnv(FollowerReadsEnvKey, strconv.ParseBool) {
+		opts = append(opts, db.WithFollowerReads())
+	}
+	return opts
+}
+
+// openStore opens the storage backend for a DSN returned by databaseDSNs.
+// The options only apply to PostgreSQL and CockroachDB.
+func openStore(ctx context.Context, dsn string, opts ...db.Option) (store.Store, func(), error) {
+	if strings.HasPrefix(dsn, "sqlite://") {
+		db, err := sqlite.Open(ctx, dsn)
+		if err != nil {
+			return nil, nil, err
+		}
+		return db, db.Close, nil
+	}
+
+	db, err := db.New(ctx, dsn, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return db, db.Close, nil
+}
+
+const usage = `Usage:
+  count [flags]              run the server
+  count migrate <command>    manage database migrations
+  count import [FILE...]     import access logs into the daily totals
+  count key create NAME SCOPE...
+                             create an api key
+
+Flags:
+`
+
+func run(args []string) int {
+	flags := flag.NewFlagSet("count", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usage)
+		flags.PrintDefaults()
+	}
+	noMigrate := flags.Bool("no-migrate", false, "do not apply migrations on startup")
+	flags.Parse(args)
+
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, os.Kill, syscall.SIGHUP)
 	defer cancel()
 
@@ -40,17 +372,113 @@ func run() int {
 		dsn = DefaultDSN
 	}
 
-	migrDSN := strings.Replace(dsn, "postgresql", migrDriver, 1)
-	migrations.Up(migrDSN)
+	tz, ok := os.LookupEnv(TimeZoneEnvKey)
+	if !ok {
+		tz = DefaultTimeZone
+	}
+	loc, err := loadLocation(tz)
+	if err != nil {
+		panic(fmt.Errorf("%s: %w", TimeZoneEnvKey, err))
+	}
+
+	migrDSN, storeDSN, err := databaseDSNs(dsn, migrDriver)
+	if err != nil {
+		panic(err)
+	}
+
+	if flags.Arg(0) == "migrate" {
+		if err = runMigrate(os.Stdout, migrDSN, flags.Args()[1:]); err != nil {
+			logger.Err(err).Msg("migrate")
+			return 1
+		}
+		return 0
+	}
+
+	// open the store for subcommands, which require an up to date schema.
+	openChecked := func(ctx context.Context) (store.Store, func(), error) {
+		if err := migrations.CheckVersion(migrDSN); err != nil {
+			return nil, nil, err
+		}
+		return openStore(ctx, storeDSN)
+	}
+
+	switch flags.Arg(0) {
+	case "import":
+		if err = runImport(ctx, os.Stdout, os.Stderr, os.Stdin, flags.Args()[1:], loc, openChecked); err != nil {
+			logger.Err(err).Msg("import")
+			return 1
+		}
+		return 0
+	case "key":
+		if err = runKey(ctx, os.Stdout, flags.Args()[1:], openChecked); err != nil {
+			logger.Err(err).Msg("key")
+			return 1
+		}
+		return 0
+	}
+
+	if !*noMigrate {
+		if err = migrations.Up(migrDSN); err != nil {
+			logger.Err(err).Msg("migrations up")
+			return 1
+		}
+	}
+	if err = migrations.CheckVersion(migrDSN); err != nil {
+		logger.Err(err).Msg("refusing to serve, run \"count migrate up\" or check \"count migrate status\"")
+		return 1
+	}
 
-	db, err := db.New(ctx, dsn)
+	backend, closeStore, err := openStore(ctx, storeDSN, dbOptionsFromEnv()...)
 	if err != nil {
 		panic(err)
 	}
-	defer db.Close()
+	defer closeStore()
 
-	server := grpc.NewServer(grpc.Creds(insecure.NewCredentials()))
-	service.NewCountService(server, db)
+	serverOpts := []grpc.ServerOption{grpc.Creds(insecure.NewCredentials())}
+	if authEnabled() {
+		keys, ok := backend.(store.KeyStore)
+		if !ok {
+			panic(fmt.Errorf("%s: storage backend does not support api keys", AuthEnabledEnvKey))
+		}
+		auth := service.NewAuthenticator(keys)
+		serverOpts = append(serverOpts,
+			grpc.ChainUnaryInterceptor(auth.UnaryInterceptor()),
+			grpc.ChainStreamInterceptor(auth.StreamInterceptor()),
+		)
+	}
+
+	server := grpc.NewServer(serverOpts...)
+	retention := retentionFromEnv()
+	countServer := service.NewCountService(server, backend,
+		service.WithTimeZone(loc),
+		service.WithRetention(retention),
+		service.WithLimits(limitsFromEnv()),
+		service.WithLabelPolicy(labelsFromEnv()),
+		service.WithMaxInserts(int(parseEnv(MaxInsertsEnvKey, parseInt))),
+		service.WithCache(service.CachePolicy{
+			MaxBytes: parseEnv(CacheMaxBytesEnvKey, parseInt),
+			TTL:      durationFromEnv(CacheTTLEnvKey, service.DefaultCacheTTL),
+		}),
+	)
+	if retention.RequestsMaxAge > 0 || retention.DailyTotalsMaxAge > 0 {
+		go countServer.RunPruner(ctx, durationFromEnv(PruneIntervalEnvKey, DefaultPruneInterval))
+	}
+
+	if v, ok := os.LookupEnv(PartitionDaysEnvKey); ok {
+		days, err := strconv.Atoi(v)
+		if err != nil {
+			panic(fmt.Errorf("%s: %w", PartitionDaysEnvKey, err))
+		}
+		go countServer.RunPartitioner(ctx, days, durationFromEnv(PartitionIntervalEnvKey, DefaultPartitionInterval))
+	}
+
+	if addr, ok := os.LookupEnv(MetricsAddrEnvKey); ok {
+		go func() {
+			// expvar registers /debug/vars on the default mux.
+			err := http.ListenAndServe(addr, nil)
+			logger.Err(err).Str("addr", addr).Msg("metrics server terminated")
+		}()
+	}
 
 	lis, err := net.Listen("tcp", ":7777")
 	if err != nil {
@@ -80,5 +508,5 @@ func run() int {
 }
 
 func main() {
-	os.Exit(run())
+	os.Exit(run(os.Args[1:]))
 }
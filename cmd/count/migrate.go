@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/muhlemmer/count/internal/db/migrations"
+)
+
+const migrateUsage = `usage: count migrate up|down|goto N|version|force N|status`
+
+var errMigrateUsage = errors.New(migrateUsage)
+
+// migrateArg parses the version argument of goto and force.
+func migrateArg(args []string) (int, error) {
+	if len(args) != 2 {
+		return 0, errMigrateUsage
+	}
+	v, err := strconv.Atoi(args[1])
+	if err != nil {
+		return 0, fmt.Errorf("%s version: %w", args[0], err)
+	}
+	return v, nil
+}
+
+// runMigrate executes a migrate subcommand against dsn.
+func runMigrate(w io.Writer, dsn string, args []string) error {
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+
+	switch args[0] {
+	case "up":
+		return migrations.Up(dsn)
+
+	case "down":
+		return migrations.Down(dsn)
+
+	case "goto":
+		v, err := migrateArg(args)
+		if err != nil {
+			return err
+		}
+		if v < 0 {
+			return fmt.Errorf("goto version: must not be negative")
+		}
+		return migrations.Goto(dsn, uint(v))
+
+	case "force":
+		v, err := migrateArg(args)
+		if err != nil {
+			return err
+		}
+		return migrations.Force(dsn, v)
+
+	case "version":
+		version, dirty, err := migrations.Version(dsn)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%d", version)
+		if dirty {
+			fmt.Fprint(w, " (dirty)")
+		}
+		fmt.Fprintln(w)
+		return nil
+
+	case "status":
+		return migrateStatus(w, dsn)
+
+	default:
+		return errMigrateUsage
+	}
+}
+
+// migrateStatus prints all available migrations
+// and whether they are applied.
+func migrateStatus(w io.Writer, dsn string) error {
+	versions, err := migrations.Versions(dsn)
+	if err != nil {
+		return err
+	}
+	current, dirty, err := migrations.Version(dsn)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tSTATUS")
+	for _, v := range versions {
+		status := "pending"
+		switch {
+		case v == current && dirty:
+			status = "dirty"
+		case v <= current:
+			status = "applied"
+		}
+		fmt.Fprintf(tw, "%d\t%s\n", v, status)
+	}
+	if err = tw.Flush(); err != nil {
+		return err
+	}
+
+	if err = migrations.CheckVersion(dsn); errors.Is(err, migrations.ErrVersionMismatch) {
+		fmt.Fprintln(w, "\nThe server will refuse to start:", err)
+		return nil
+	}
+	return err
+}
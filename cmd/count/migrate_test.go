@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_migrateArg(t *testing.T) {
+	tests := []struct {
+		args    []string
+		want    int
+		wantErr bool
+	}{
+		{[]string{"goto"}, 0, true},
+		{[]string{"goto", "1", "2"}, 0, true},
+		{[]string{"goto", "one"}, 0, true},
+		{[]string{"force", "-1"}, -1, false},
+		{[]string{"goto", "20221107120000"}, 20221107120000, false},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			got, err := migrateArg(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("migrateArg() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("migrateArg() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_runMigrate(t *testing.T) {
+	dsn := "sqlite://" + filepath.Join(t.TempDir(), "count.db")
+
+	const (
+		allPending = `VERSION         STATUS
+20221107120000  pending
+20221108120000  pending
+20221109120000  pending
+`
+		allApplied = `VERSION         STATUS
+20221107120000  applied
+20221108120000  applied
+20221109120000  applied
+`
+		firstApplied = `VERSION         STATUS
+20221107120000  applied
+20221108120000  pending
+20221109120000  pending
+`
+		firstDirty = `VERSION         STATUS
+20221107120000  dirty
+20221108120000  pending
+20221109120000  pending
+`
+	)
+
+	// steps run in order against the same database.
+	steps := []struct {
+		name    string
+		args    []string
+		want    string
+		refuse  bool
+		wantErr bool
+	}{
+		{"empty version", []string{"version"}, "0\n", false, false},
+		{"empty status", []string{"status"}, allPending, true, false},
+		{"up", []string{"up"}, "", false, false},
+		{"up version", []string{"version"}, "20221109120000\n", false, false},
+		{"up status", []string{"status"}, allApplied, false, false},
+		{"goto", []string{"goto", "20221107120000"}, "", false, false},
+		{"goto status", []string{"status"}, firstApplied, true, false},
+		{"force", []string{"force", "-1"}, "", false, false},
+		// tables already exist
+		{"up dirty", []string{"up"}, "", false, true},
+		{"dirty version", []string{"version"}, "20221107120000 (dirty)\n", false, false},
+		{"dirty status", []string{"status"}, firstDirty, true, false},
+		{"force clean", []string{"force", "20221107120000"}, "", false, false},
+		{"down", []string{"down"}, "", false, false},
+		{"down version", []string{"version"}, "0\n", false, false},
+	}
+	for _, tt := range steps {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := runMigrate(&out, dsn, tt.args); (err != nil) != tt.wantErr {
+				t.Fatalf("runMigrate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got, refuse := out.String(), false
+			if i := strings.Index(got, "\nThe server will refuse to start"); i >= 0 {
+				got, refuse = got[:i], true
+			}
+			if got != tt.want {
+				t.Errorf("runMigrate() output =\n%s\nwant\n%s", got, tt.want)
+			}
+			if refuse != tt.refuse {
+				t.Errorf("runMigrate() refuse to start = %t, want %t", refuse, tt.refuse)
+			}
+		})
+	}
+}
+
+func Test_runMigrate_usage(t *testing.T) {
+	dsn := "sqlite://" + filepath.Join(t.TempDir(), "count.db")
+
+	for _, args := range [][]string{
+		nil,
+		{"sideways"},
+		{"goto"},
+		{"force", "1", "2"},
+	} {
+		if err := runMigrate(io.Discard, dsn, args); !errors.Is(err, errMigrateUsage) {
+			t.Errorf("runMigrate(%q) error = %v, want %v", args, err, errMigrateUsage)
+		}
+	}
+}
+
+func Test_runMigrate_error(t *testing.T) {
+	dsn := "sqlite://" + filepath.Join(t.TempDir(), "count.db")
+
+	tests := []struct {
+		name string
+		dsn  string
+		args []string
+	}{
+		{"negative goto", dsn, []string{"goto", "-1"}},
+		{"unknown goto version", dsn, []string{"goto", "1"}},
+		{"unknown scheme", "foo://bar", []string{"up"}},
+		{"unknown scheme version", "foo://bar", []string{"version"}},
+		{"unknown scheme status", "foo://bar", []string{"status"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := runMigrate(io.Discard, tt.dsn, tt.args); err == nil {
+				t.Error("runMigrate() expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+	"google.golang.org/genproto/googleapis/type/date"
+)
+
+var relativePrecisions = map[string]datepb.Precision{
+	"day":     datepb.Day,
+	"week":    datepb.Week,
+	"month":   datepb.Month,
+	"quarter": datepb.Quarter,
+	"year":    datepb.Year,
+}
+
+// parsePeriod parses a period in one of the formats of datepb.ParsePeriod,
+// or relative to now:
+//   - today or yesterday.
+//   - this-<precision> or last-<precision>, for example last-week.
+//
+// now is converted to its calendar date in loc.
+func parsePeriod(s string, now time.Time, loc *time.Location) (datepb.Period, error) {
+	year, month, day := now.In(loc).Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+
+	switch s {
+	case "today":
+		return datepb.NewPeriod(today, datepb.Day), nil
+	case "yesterday":
+		return datepb.NewPeriod(today, datepb.Day).Prev(), nil
+	}
+
+	if rel, name, ok := strings.Cut(s, "-"); ok {
+		if p, ok := relativePrecisions[name]; ok {
+			switch rel {
+			case "this":
+				return datepb.NewPeriod(today, p), nil
+			case "last":
+				return datepb.NewPeriod(today, p).Prev(), nil
+			}
+		}
+	}
+
+	p, err := datepb.ParsePeriod(s)
+	if err != nil {
+		return p, fmt.Errorf("invalid period %q, use a date like 2022-10-16, 2022-W42, 2022-10, 2022-Q4, 2022, today, yesterday, this-week or last-month", s)
+	}
+	return p, nil
+}
+
+// periodDate returns the date for the first day of p,
+// with month and day unset for year and month precision.
+func periodDate(p datepb.Period) *date.Date {
+	d := datepb.Date(p.Start)
+
+	switch p.Precision {
+	case datepb.Year:
+		d.Month, d.Day = 0, 0
+	case datepb.Month:
+		d.Day = 0
+	}
+	return d
+}
+
+// periodTotalsRequest builds a GetPeriodTotalsRequest for p.
+func periodTotalsRequest(p datepb.Period, tz string) *countv1.GetPeriodTotalsRequest {
+	req := &countv1.GetPeriodTotalsRequest{
+		TimeZone: tz,
+	}
+
+	switch p.Precision {
+	case datepb.Week:
+		year, week := p.Start.ISOWeek()
+		req.PeriodType = &countv1.GetPeriodTotalsRequest_Week{
+			Week: &countv1.IsoWeek{Year: int32(year), Week: int32(week)},
+		}
+	case datepb.Quarter:
+		req.PeriodType = &countv1.GetPeriodTotalsRequest_Quarter{
+			Quarter: &countv1.Quarter{Year: int32(p.Start.Year()), Quarter: int32((p.Start.Month()-1)/3 + 1)},
+		}
+	default:
+		req.PeriodType = &countv1.GetPeriodTotalsRequest_Period{
+			Period: periodDate(p),
+		}
+	}
+
+	return req
+}
+
+// latencyStatsRequest builds a GetLatencyStatsRequest for p,
+// with the same period type as periodTotalsRequest.
+func latencyStatsRequest(p datepb.Period, tz string) *countv1.GetLatencyStatsRequest {
+	req := &countv1.GetLatencyStatsRequest{
+		TimeZone: tz,
+	}
+
+	switch pt := periodTotalsRequest(p, tz).GetPeriodType().(type) {
+	case *countv1.GetPeriodTotalsRequest_Week:
+		req.PeriodType = &countv1.GetLatencyStatsRequest_Week{Week: pt.Week}
+	case *countv1.GetPeriodTotalsRequest_Quarter:
+		req.PeriodType = &countv1.GetLatencyStatsRequest_Quarter{Quarter: pt.Quarter}
+	case *countv1.GetPeriodTotalsRequest_Period:
+		req.PeriodType = &countv1.GetLatencyStatsRequest_Period{Period: pt.Period}
+	}
+
+	return req
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/protobuf/proto"
+)
+
+func Test_parsePeriod(t *testing.T) {
+	zurich, err := time.LoadLocation("Europe/Zurich")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Sunday 2022-10-16 in Zurich.
+	now := time.Date(2022, time.October, 15, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		s       string
+		want    string
+		wantErr bool
+	}{
+		{s: "today", want: "2022-10-16"},
+		{s: "yesterday", want: "2022-10-15"},
+		{s: "this-week", want: "2022-W41"},
+		{s: "last-week", want: "2022-W40"},
+		{s: "last-day", want: "2022-10-15"},
+		{s: "this-month", want: "2022-10"},
+		{s: "last-month", want: "2022-09"},
+		{s: "last-quarter", want: "2022-Q3"},
+		{s: "last-year", want: "2021"},
+		{s: "2022-10", want: "2022-10"},
+		{s: "2022-W42", want: "2022-W42"},
+		{s: "2022-Q4", want: "2022-Q4"},
+		{s: "1986-03-25", want: "1986-03-25"},
+		{s: "next-week", wantErr: true},
+		{s: "foo", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := parsePeriod(tt.s, now, zurich)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePeriod() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("parsePeriod() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_periodTotalsRequest(t *testing.T) {
+	tests := []struct {
+		period string
+		want   *countv1.GetPeriodTotalsRequest
+	}{
+		{
+			period: "2022",
+			want: &countv1.GetPeriodTotalsRequest{
+				PeriodType: &countv1.GetPeriodTotalsRequest_Period{Period: &date.Date{Year: 2022}},
+			},
+		},
+		{
+			period: "2022-10",
+			want: &countv1.GetPeriodTotalsRequest{
+				PeriodType: &countv1.GetPeriodTotalsRequest_Period{Period: &date.Date{Year: 2022, Month: 10}},
+			},
+		},
+		{
+			period: "2022-10-16",
+			want: &countv1.GetPeriodTotalsRequest{
+				PeriodType: &countv1.GetPeriodTotalsRequest_Period{Period: &date.Date{Year: 2022, Month: 10, Day: 16}},
+			},
+		},
+		{
+			period: "2021-W52",
+			want: &countv1.GetPeriodTotalsRequest{
+				PeriodType: &countv1.GetPeriodTotalsRequest_Week{Week: &countv1.IsoWeek{Year: 2021, Week: 52}},
+			},
+		},
+		{
+			period: "2022-Q4",
+			want: &countv1.GetPeriodTotalsRequest{
+				PeriodType: &countv1.GetPeriodTotalsRequest_Quarter{Quarter: &countv1.Quarter{Year: 2022, Quarter: 4}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			p, err := datepb.ParsePeriod(tt.period)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := periodTotalsRequest(p, ""); !proto.Equal(got, tt.want) {
+				t.Errorf("periodTotalsRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+)
+
+var csvHeader = []string{"date", "method", "path", "count"}
+
+// rowEncoder writes rows in a line based format.
+type rowEncoder interface {
+	encode(r row) error
+	flush() error
+}
+
+type csvEncoder struct{ w *csv.Writer }
+
+func (e csvEncoder) encode(r row) error {
+	return e.w.Write([]string{r.Date, r.Method, r.Path, strconv.FormatInt(r.Count, 10)})
+}
+
+func (e csvEncoder) flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type jsonlEncoder struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func newJSONLEncoder(w io.Writer) jsonlEncoder {
+	bw := bufio.NewWriter(w)
+	return jsonlEncoder{bw, json.NewEncoder(bw)}
+}
+
+func (e jsonlEncoder) encode(r row) error { return e.enc.Encode(r) }
+func (e jsonlEncoder) flush() error       { return e.w.Flush() }
+
+// lineDate returns the date of a CSV or JSON Lines row.
+// An empty date is returned for the CSV header.
+func lineDate(format, line string) (string, error) {
+	if format == "csv" {
+		date, _, _ := strings.Cut(line, ",")
+		if date == csvHeader[0] {
+			return "", nil
+		}
+		return date, nil
+	}
+
+	var r row
+	if err := json.Unmarshal([]byte(line), &r); err != nil {
+		return "", err
+	}
+	return r.Date, nil
+}
+
+// resumePoint scans an earlier export in format and returns the last exported date
+// and the offset of its first row. The last date might be incomplete when
+// the earlier export was interrupted, so it should be exported again from offset.
+// An empty date is returned when the export contains no rows.
+func resumePoint(r io.Reader, format string) (offset int64, date string, err error) {
+	var pos int64
+
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		if errors.Is(err, io.EOF) {
+			// ignore a partially written last line
+			return offset, date, nil
+		}
+		if err != nil {
+			return 0, "", err
+		}
+
+		d, err := lineDate(format, strings.TrimSuffix(line, "\n"))
+		if err != nil {
+			return 0, "", fmt.Errorf("resume at offset %d: %w", pos, err)
+		}
+		if d != "" && d != date {
+			offset, date = pos, d
+		}
+		pos += int64(len(line))
+		if date == "" {
+			offset = pos
+		}
+	}
+}
+
+type exportOptions struct {
+	file      string
+	resume    bool
+	chunkSize int
+}
+
+// openExportFile opens the export destination.
+// When resuming, the file is truncated before the last exported date,
+// which is returned.
+func openExportFile(opts *exportOptions, format string) (f *os.File, resumeDate string, err error) {
+	f, err = os.OpenFile(opts.file, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var offset int64
+	if opts.resume {
+		offset, resumeDate, err = resumePoint(f, format)
+		if err != nil {
+			f.Close()
+			return nil, "", err
+		}
+	}
+	if err = f.Truncate(offset); err == nil {
+		_, err = f.Seek(offset, io.SeekStart)
+	}
+	if err != nil {
+		f.Close()
+		return nil, "", err
+	}
+
+	return f, resumeDate, nil
+}
+
+func runExport(ctx context.Context, args []string, stdout, stderr io.Writer, now time.Time) error {
+	exp := new(exportOptions)
+	opts, periods, err := parseArgs("export", args, stderr, now, 1, 2, "csv", func(flags *flag.FlagSet) {
+		flags.StringVar(&exp.file, "file", "", "write to file instead of stdout")
+		flags.BoolVar(&exp.resume, "resume", false, "resume an interrupted export to file")
+		flags.IntVar(&exp.chunkSize, "chunk-size", 0, "minimal amount of rows per response, the server's default when zero")
+	})
+	if err != nil {
+		return err
+	}
+	if opts.output != "csv" && opts.output != "jsonl" {
+		return fmt.Errorf("invalid export format %q, use csv or jsonl", opts.output)
+	}
+	if opts.sort != "" {
+		return fmt.Errorf("export does not support sorting")
+	}
+	if exp.resume && exp.file == "" {
+		return fmt.Errorf("resume requires a file")
+	}
+
+	req := &countv1.ExportTotalsRequest{
+		StartDate: datepb.Date(periods[0].Start),
+		EndDate:   datepb.Date(periods[len(periods)-1].End()),
+		TimeZone:  opts.tz,
+		ChunkSize: int32(exp.chunkSize),
+		Service:   opts.service,
+	}
+
+	w, header := stdout, true
+	if exp.file != "" {
+		f, resumeDate, err := openExportFile(exp, opts.output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		if resumeDate != "" {
+			t, err := time.Parse("2006-01-02", resumeDate)
+			if err != nil {
+				return err
+			}
+			if t.After(datepb.Time(req.StartDate)) {
+				req.StartDate = datepb.Date(t)
+			}
+			header = false
+			fmt.Fprintln(stderr, "resuming export from", resumeDate)
+		}
+		w = f
+	}
+
+	var enc rowEncoder = newJSONLEncoder(w)
+	if opts.output == "csv" {
+		cw := csv.NewWriter(w)
+		if header {
+			cw.Write(csvHeader)
+		}
+		enc = csvEncoder{cw}
+	}
+
+	cc, err := dial(ctx, opts.addr, opts.key)
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	stream, err := countv1.NewCountServiceClient(cc).ExportTotals(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return enc.flush()
+		}
+		if err != nil {
+			return err
+		}
+
+		rows, err := opts.filter.apply(toRows(resp.GetMethodCounts()))
+		if err != nil {
+			return err
+		}
+		for _, r := range rows {
+			if err = enc.encode(r); err != nil {
+				return err
+			}
+		}
+		// write whole days, so that an interrupted export can be resumed.
+		if err = enc.flush(); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/muhlemmer/count/internal/service"
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"google.golang.org/grpc"
+)
+
+func Test_resumePoint(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     string
+		input      string
+		wantOffset int64
+		wantDate   string
+		wantErr    bool
+	}{
+		{
+			name:   "empty",
+			format: "csv",
+		},
+		{
+			name:       "csv header only",
+			format:     "csv",
+			input:      "date,method,path,count\n",
+			wantOffset: 23,
+		},
+		{
+			name:       "csv",
+			format:     "csv",
+			input:      "date,method,path,count\n2022-10-16,GET,/a,1\n2022-10-17,GET,/a,1\n2022-10-17,GET,/b,1\n",
+			wantOffset: 43,
+			wantDate:   "2022-10-17",
+		},
+		{
+			name:       "csv partial line",
+			format:     "csv",
+			input:      "date,method,path,count\n2022-10-16,GET,/a,1\n2022-10-17,G",
+			wantOffset: 23,
+			wantDate:   "2022-10-16",
+		},
+		{
+			name:       "jsonl",
+			format:     "jsonl",
+			input:      `{"date":"2022-10-16","method":"GET","path":"/a","count":1}` + "\n" + `{"date":"2022-10-17","method":"GET","path":"/a","count":1}` + "\n",
+			wantOffset: 59,
+			wantDate:   "2022-10-17",
+		},
+		{
+			name:    "jsonl error",
+			format:  "jsonl",
+			input:   "date,method,path,count\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset, date, err := resumePoint(strings.NewReader(tt.input), tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resumePoint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if offset != tt.wantOffset || date != tt.wantDate {
+				t.Errorf("resumePoint() = %d, %q, want %d, %q", offset, date, tt.wantOffset, tt.wantDate)
+			}
+		})
+	}
+}
+
+func Test_runExport_resume(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, time.October, 20, 12, 0, 0, 0, time.UTC)
+
+	mem := store.NewMemory()
+	for day := 16; day <= 18; day++ {
+		start := time.Date(2022, time.October, day, 0, 0, 0, 0, time.UTC)
+		if err := mem.InsertMethodRequest(ctx, store.DefaultService, countv1.Method_GET, "/users", start); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := mem.CountDailyMethodTotals(ctx, start, start.AddDate(0, 0, 1).Add(-1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	service.NewCountService(server, mem)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	file := filepath.Join(t.TempDir(), "export.csv")
+	// interrupted export, with an incomplete last day.
+	err = os.WriteFile(file, []byte("date,method,path,count\n2022-10-16,GET,/users,1\n2022-10-17,GET,/items,1\n2022-10"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := []string{"-addr=" + lis.Addr().String(), "-tz=UTC", "-file=" + file, "-resume", "2022-10"}
+	if err = runExport(ctx, args, io.Discard, io.Discard, now); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = "date,method,path,count\n2022-10-16,GET,/users,1\n2022-10-17,GET,/users,1\n2022-10-18,GET,/users,1\n"
+	if string(got) != want {
+		t.Errorf("export file =\n%s\nwant\n%s", got, want)
+	}
+}
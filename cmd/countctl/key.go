@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+)
+
+// parseScopes parses scope names, such as read, into scopes.
+func parseScopes(names []string) ([]countv1.Scope, error) {
+	scopes := make([]countv1.Scope, len(names))
+	for i, name := range names {
+		v, ok := countv1.Scope_value["SCOPE_"+strings.ToUpper(name)]
+		if !ok || v == int32(countv1.Scope_SCOPE_UNSPECIFIED) {
+			return nil, fmt.Errorf("unknown scope %q, use ingest, read or admin", name)
+		}
+		scopes[i] = countv1.Scope(v)
+	}
+	return scopes, nil
+}
+
+// scopeNames returns the lower case names of scopes, without prefix.
+func scopeNames(scopes []countv1.Scope) string {
+	names := make([]string, len(scopes))
+	for i, s := range scopes {
+		names[i] = strings.ToLower(strings.TrimPrefix(s.String(), "SCOPE_"))
+	}
+	return strings.Join(names, ",")
+}
+
+func writeKeys(w io.Writer, keys []*countv1.ApiKey) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tSERVICE\tSCOPES\tCREATED\tREVOKED")
+	for _, k := range keys {
+		revoked := ""
+		if k.GetRevokeTime() != nil {
+			revoked = k.GetRevokeTime().AsTime().Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\n",
+			k.GetId(), k.GetName(), k.GetService(), scopeNames(k.GetScopes()),
+			k.GetCreateTime().AsTime().Format(time.RFC3339), revoked,
+		)
+	}
+	return tw.Flush()
+}
+
+// runKey executes the key create, list and revoke commands.
+func runKey(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return fmt.Errorf("key: create, list or revoke required")
+	}
+
+	flags, opts := newFlagSet("key "+args[0], stderr, "table")
+	pos, err := parseFlags(flags, args[1:])
+	if err != nil {
+		return err
+	}
+
+	var (
+		req      func(client countv1.CountServiceClient) error
+		nArgs    = len(pos)
+		argsHelp string
+	)
+	switch args[0] {
+	case "create":
+		argsHelp = "NAME SCOPE..."
+		if nArgs < 2 {
+			break
+		}
+		scopes, err := parseScopes(pos[1:])
+		if err != nil {
+			return err
+		}
+		req = func(client countv1.CountServiceClient) error {
+			resp, err := client.CreateApiKey(ctx, &countv1.CreateApiKeyRequest{
+				Name:    pos[0],
+				Scopes:  scopes,
+				Service: opts.service,
+			})
+			if err != nil {
+				return err
+			}
+			if err = writeKeys(stdout, []*countv1.ApiKey{resp.GetApiKey()}); err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(stdout, "\nStore the key now, it is not shown again:\n%s\n", resp.GetKey())
+			return err
+		}
+
+	case "list":
+		if nArgs != 0 {
+			break
+		}
+		req = func(client countv1.CountServiceClient) error {
+			resp, err := client.ListApiKeys(ctx, &countv1.ListApiKeysRequest{})
+			if err != nil {
+				return err
+			}
+			return writeKeys(stdout, resp.GetApiKeys())
+		}
+
+	case "revoke":
+		argsHelp = "ID"
+		if nArgs != 1 {
+			break
+		}
+		id, err := strconv.ParseInt(pos[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("key revoke: %w", err)
+		}
+		req = func(client countv1.CountServiceClient) error {
+			resp, err := client.RevokeApiKey(ctx, &countv1.RevokeApiKeyRequest{Id: id})
+			if err != nil {
+				return err
+			}
+			return writeKeys(stdout, []*countv1.ApiKey{resp.GetApiKey()})
+		}
+
+	default:
+		fmt.Fprint(stderr, usage)
+		return fmt.Errorf("key: unknown command %q", args[0])
+	}
+	if req == nil {
+		return fmt.Errorf("usage: countctl key %s [flags] %s", args[0], argsHelp)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	cc, err := dial(ctx, opts.addr, opts.key)
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	return req(countv1.NewCountServiceClient(cc))
+}
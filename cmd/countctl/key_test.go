@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/muhlemmer/count/internal/service"
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"google.golang.org/grpc"
+)
+
+func Test_runKey(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	mem := store.NewMemory()
+	adminKey, _, err := service.NewAPIKey(ctx, mem, "admin", "", []countv1.Scope{countv1.Scope_SCOPE_ADMIN})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := service.NewAuthenticator(mem)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(auth.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(auth.StreamInterceptor()),
+	)
+	service.NewCountService(server, mem)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	addr := "-addr=" + lis.Addr().String()
+	key := "-key=" + adminKey
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantOut  []string
+		wantErr  bool
+		wantKeys int
+	}{
+		{
+			name:    "no key",
+			args:    []string{"key", "list", addr},
+			wantErr: true,
+		},
+		{
+			name:    "create",
+			args:    []string{"key", "create", addr, key, "cdn", "ingest", "read"},
+			wantOut: []string{"2   cdn            ingest,read", "count_"},
+		},
+		{
+			name:    "create bound",
+			args:    []string{"key", "create", addr, key, "-service=shop", "shop", "read"},
+			wantOut: []string{"3   shop  shop     read", "count_"},
+		},
+		{
+			name:    "create without scopes",
+			args:    []string{"key", "create", addr, key, "cdn"},
+			wantErr: true,
+		},
+		{
+			name:    "create unknown scope",
+			args:    []string{"key", "create", addr, key, "cdn", "write"},
+			wantErr: true,
+		},
+		{
+			name:    "revoke",
+			args:    []string{"key", "revoke", addr, key, "2"},
+			wantOut: []string{"2   cdn            ingest,read", now.UTC().Format("2006-01-02")},
+		},
+		{
+			name:    "revoke unknown",
+			args:    []string{"key", "revoke", addr, key, "4"},
+			wantErr: true,
+		},
+		{
+			name:    "list",
+			args:    []string{"key", "list", addr, key},
+			wantOut: []string{"ID  NAME   SERVICE  SCOPES", "1   admin           admin", "2   cdn             ingest,read", "3   shop   shop     read"},
+		},
+		{
+			name:    "unknown command",
+			args:    []string{"key", "delete", addr, key, "1"},
+			wantErr: true,
+		},
+		{
+			name:    "quota",
+			args:    []string{"quota", addr, key, "-service=shop"},
+			wantOut: []string{"SERVICE  DATE", "shop     " + now.UTC().Format("2006-01-02") + "  unlimited  0     -"},
+		},
+		{
+			name:    "quota with arguments",
+			args:    []string{"quota", addr, key, "shop"},
+			wantErr: true,
+		},
+		{
+			name:    "period without read scope",
+			args:    []string{"period", addr, "-key=count_foo", "2022"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			err := run(ctx, tt.args, &stdout, io.Discard, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.wantOut {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("run() =\n%s\nwant %q", stdout.String(), want)
+				}
+			}
+		})
+	}
+}
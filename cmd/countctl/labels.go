@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// labelFlag is a repeatable flag of key=value labels.
+type labelFlag map[string]string
+
+func (l labelFlag) String() string {
+	return formatLabels(l)
+}
+
+func (l labelFlag) Set(s string) error {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("invalid label %q, use key=value", s)
+	}
+	l[key] = value
+	return nil
+}
+
+// formatLabels formats labels as key=value pairs, sorted by key.
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// groupByLabels splits the comma separated label keys of the group-by flag.
+func (opts *options) groupByLabels() []string {
+	if opts.groupBy == "" {
+		return nil
+	}
+	return strings.Split(opts.groupBy, ",")
+}
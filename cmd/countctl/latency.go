@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+)
+
+// parseQuantiles parses a comma separated list of quantiles.
+func parseQuantiles(s string) ([]float64, error) {
+	var quantiles []float64
+	for _, field := range strings.Split(s, ",") {
+		q, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil || !(q >= 0 && q <= 1) {
+			return nil, fmt.Errorf("invalid quantile %q, use a number between 0 and 1", field)
+		}
+		quantiles = append(quantiles, q)
+	}
+	return quantiles, nil
+}
+
+// quantileName formats q as a percentile, for example p95.
+func quantileName(q float64) string {
+	return "p" + strconv.FormatFloat(q*100, 'f', -1, 64)
+}
+
+// latencyRow is a flattened LatencyStats.
+type latencyRow struct {
+	Method    string                   `json:"method"`
+	Path      string                   `json:"path"`
+	Count     int64                    `json:"count"`
+	Quantiles map[string]time.Duration `json:"-"`
+	Durations map[string]string        `json:"quantiles"`
+}
+
+func toLatencyRows(stats []*countv1.LatencyStats, f filter) ([]latencyRow, error) {
+	var rows []latencyRow
+	for _, ls := range stats {
+		ok, err := f.match(ls.GetMethod().String(), ls.GetPath())
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		r := latencyRow{
+			Method:    ls.GetMethod().String(),
+			Path:      ls.GetPath(),
+			Count:     ls.GetCount(),
+			Quantiles: make(map[string]time.Duration),
+			Durations: make(map[string]string),
+		}
+		for _, q := range ls.GetQuantiles() {
+			name, d := quantileName(q.GetQuantile()), q.GetDuration().AsDuration()
+			r.Quantiles[name] = d
+			r.Durations[name] = d.String()
+		}
+		rows = append(rows, r)
+	}
+	return rows, nil
+}
+
+type writeLatencyFunc func(w io.Writer, names []string, rows []latencyRow) error
+
+var latencyFormats = map[string]writeLatencyFunc{
+	"table": writeLatencyTable,
+	"csv":   writeLatencyCSV,
+	"json":  writeLatencyJSON,
+}
+
+func writeLatencyTable(w io.Writer, names []string, rows []latencyRow) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "METHOD\tPATH\tCOUNT\t%s\n", strings.ToUpper(strings.Join(names, "\t")))
+	for _, r := range rows {
+		fmt.Fprintf(tw, "%s\t%s\t%d", r.Method, r.Path, r.Count)
+		for _, name := range names {
+			fmt.Fprintf(tw, "\t%s", r.Quantiles[name])
+		}
+		fmt.Fprintln(tw)
+	}
+	return tw.Flush()
+}
+
+// writeLatencyCSV writes the quantiles in nanoseconds.
+func writeLatencyCSV(w io.Writer, names []string, rows []latencyRow) error {
+	cw := csv.NewWriter(w)
+	cw.Write(append([]string{"method", "path", "count"}, names...))
+	for _, r := range rows {
+		record := []string{r.Method, r.Path, strconv.FormatInt(r.Count, 10)}
+		for _, name := range names {
+			record = append(record, strconv.FormatInt(int64(r.Quantiles[name]), 10))
+		}
+		cw.Write(record)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeLatencyJSON(w io.Writer, names []string, rows []latencyRow) error {
+	if rows == nil {
+		rows = []latencyRow{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rows)
+}
+
+// runLatency executes the latency command.
+func runLatency(ctx context.Context, args []string, stdout, stderr io.Writer, now time.Time) error {
+	var quantiles string
+	opts, periods, err := parseArgs("latency", args, stderr, now, 1, 1, "table", func(flags *flag.FlagSet) {
+		flags.StringVar(&quantiles, "quantiles", "0.5,0.95,0.99", "latency: comma separated quantiles between 0 and 1")
+	})
+	if err != nil {
+		return err
+	}
+	if opts.sort != "" {
+		return fmt.Errorf("latency: -sort is not supported")
+	}
+	write, ok := latencyFormats[opts.output]
+	if !ok {
+		return fmt.Errorf("invalid output format %q, use table, csv or json", opts.output)
+	}
+	qs, err := parseQuantiles(quantiles)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	cc, err := dial(ctx, opts.addr, opts.key)
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	req := latencyStatsRequest(periods[0], opts.tz)
+	req.Service = opts.service
+	req.Freshness = opts.freshness()
+	req.Quantiles = qs
+
+	resp, err := countv1.NewCountServiceClient(cc).GetLatencyStats(ctx, req)
+	if err != nil {
+		return err
+	}
+	rows, err := toLatencyRows(resp.GetLatencyStats(), opts.filter)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(qs))
+	for i, q := range qs {
+		names[i] = quantileName(q)
+	}
+	return write(stdout, names, rows)
+}
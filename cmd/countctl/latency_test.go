@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/muhlemmer/count/internal/service"
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+	"google.golang.org/grpc"
+)
+
+func Test_runLatency(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, time.October, 17, 12, 0, 0, 0, time.UTC)
+	ts := time.Date(2022, time.October, 16, 1, 0, 0, 0, time.UTC)
+
+	mem := store.NewMemory()
+	for _, d := range []time.Duration{time.Millisecond, time.Millisecond, time.Second} {
+		d := d
+		if err := mem.InsertRequestDetails(ctx, store.DefaultService, countv1.Method_GET, "/users", ts, store.RequestDetails{Duration: &d}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	start, end := datepb.Interval(datepb.Date(ts))
+	if _, err := mem.CountDailyMethodTotals(ctx, start, end); err != nil {
+		t.Fatal(err)
+	}
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	service.NewCountService(server, mem)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	addr := "-addr=" + lis.Addr().String()
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "table",
+			args: []string{"latency", addr, "-quantiles=0.5,1", "2022-10"},
+			want: "METHOD  PATH    COUNT  P50        P100\nGET     /users  3      994.913µs  1.007402466s\n",
+		},
+		{
+			name: "csv",
+			args: []string{"latency", addr, "-o=csv", "-quantiles=0.5", "2022-W41"},
+			want: "method,path,count,p50\nGET,/users,3,994913\n",
+		},
+		{
+			name: "json",
+			args: []string{"latency", addr, "-o=json", "-quantiles=0.5", "2022-Q4"},
+			want: "[\n  {\n    \"method\": \"GET\",\n    \"path\": \"/users\",\n    \"count\": 3,\n    \"quantiles\": {\n      \"p50\": \"994.913µs\"\n    }\n  }\n]\n",
+		},
+		{
+			name: "filtered",
+			args: []string{"latency", addr, "-o=csv", "-method=POST", "2022-10"},
+			want: "method,path,count,p50,p95,p99\n",
+		},
+		{
+			name:    "bad quantile",
+			args:    []string{"latency", addr, "-quantiles=2", "2022-10"},
+			wantErr: true,
+		},
+		{
+			name:    "sorted",
+			args:    []string{"latency", addr, "-sort=count", "2022-10"},
+			wantErr: true,
+		},
+		{
+			name:    "not found",
+			args:    []string{"latency", addr, "2021"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			err := run(ctx, tt.args, &stdout, io.Discard, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := stdout.String(); got != tt.want {
+				t.Errorf("run() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+// Command countctl is an admin client for the count API.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+	_ "time/tzdata"
+
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+	"github.com/muhlemmer/count/pkg/queue"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Client configuration
+const (
+	AddrEnvKey  = "COUNT_ADDR"
+	DefaultAddr = "localhost:7777"
+	KeyEnvKey   = "COUNT_API_KEY"
+	Timeout     = 30 * time.Second
+)
+
+const usage = `Usage: countctl <command> [flags] <args>
+
+Flags may be given before or after the arguments,
+arguments after "--" are not parsed as flags.
+
+Commands:
+  count DATE          count the requests of DATE into daily totals
+  list START [END]    list daily totals from START until the end of END
+  period PERIOD       get the totals of PERIOD
+  latency PERIOD      get the latency quantiles of PERIOD
+  export START [END]  export daily totals from START until the end of END
+  quota               show the usage of today's quota of a service
+  key create NAME SCOPE...
+                      create an api key, SCOPE is ingest, read or admin
+  key list            list api keys
+  key revoke ID       revoke an api key
+
+Dates and periods:
+  2022-10-16, 2022-W42, 2022-10, 2022-Q4, 2022,
+  today, yesterday, this-<unit> or last-<unit>,
+  where unit is day, week, month, quarter or year.
+
+Flags:
+`
+
+type options struct {
+	addr    string
+	key     string
+	service string
+	tz      string
+	output  string
+	sort    string
+	desc    bool
+	strong  bool
+	filter  filter
+	labels  labelFlag
+	groupBy string
+}
+
+// freshness requested by the strong flag.
+func (opts *options) freshness() countv1.Freshness {
+	if opts.strong {
+		return countv1.Freshness_FRESHNESS_STRONG
+	}
+	return countv1.Freshness_FRESHNESS_UNSPECIFIED
+}
+
+func newFlagSet(name string, output io.Writer, defaultOutput string) (*flag.FlagSet, *options) {
+	opts := &options{labels: make(labelFlag)}
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usage)
+		flags.PrintDefaults()
+	}
+
+	addr, ok := os.LookupEnv(AddrEnvKey)
+	if !ok {
+		addr = DefaultAddr
+	}
+
+	flags.StringVar(&opts.addr, "addr", addr, "address of the count server, or "+AddrEnvKey)
+	flags.StringVar(&opts.key, "key", os.Getenv(KeyEnvKey), "api key, or "+KeyEnvKey)
+	flags.StringVar(&opts.service, "service", "", "service of the totals, the key's or the default service when empty")
+	flags.StringVar(&opts.tz, "tz", "", "IANA time zone of the dates, the server's time zone when empty")
+	flags.StringVar(&opts.output, "o", defaultOutput, "output format: table, csv or json, or csv or jsonl for export")
+	flags.StringVar(&opts.sort, "sort", "", "sort by date, method, path or count")
+	flags.BoolVar(&opts.desc, "desc", false, "sort in descending order")
+	flags.BoolVar(&opts.strong, "strong", false, "read the latest totals from the primary database, instead of a replica")
+	flags.StringVar(&opts.filter.method, "method", "", "only show method, for example GET")
+	flags.StringVar(&opts.filter.path, "path", "", "only show paths matching a glob pattern, for example /users/*")
+	flags.Var(opts.labels, "label", "only count requests with a label, as key=value, may be repeated")
+	flags.StringVar(&opts.groupBy, "group-by", "", "comma separated label keys by which totals are grouped")
+
+	return flags, opts
+}
+
+// command calls the count API and returns the resulting counts.
+type command func(ctx context.Context, client countv1.CountServiceClient, opts *options, periods []datepb.Period) ([]*countv1.MethodCount, error)
+
+var commands = map[string]struct {
+	// minimum and maximum amount of period arguments.
+	minArgs, maxArgs int
+	run              command
+}{
+	"count":  {1, 1, countDailyTotals},
+	"list":   {1, 2, listDailyTotals},
+	"period": {1, 1, getPeriodTotals},
+}
+
+func countDailyTotals(ctx context.Context, client countv1.CountServiceClient, opts *options, periods []datepb.Period) ([]*countv1.MethodCount, error) {
+	if periods[0].Precision != datepb.Day {
+		return nil, fmt.Errorf("count requires a single day, got %s", periods[0])
+	}
+
+	resp, err := client.CountDailyTotals(ctx, &countv1.CountDailyTotalsRequest{
+		Date:     datepb.Date(periods[0].Start),
+		TimeZone: opts.tz,
+	})
+	return resp.GetMethodCounts(), err
+}
+
+func listDailyTotals(ctx context.Context, client countv1.CountServiceClient, opts *options, periods []datepb.Period) ([]*countv1.MethodCount, error) {
+	start, end := periods[0], periods[len(periods)-1]
+
+	resp, err := client.ListDailyTotals(ctx, &countv1.ListDailyTotalsRequest{
+		StartDate:     datepb.Date(start.Start),
+		EndDate:       datepb.Date(end.End()),
+		TimeZone:      opts.tz,
+		Service:       opts.service,
+		Freshness:     opts.freshness(),
+		LabelFilter:   opts.labels,
+		GroupByLabels: opts.groupByLabels(),
+	})
+	return resp.GetMethodCounts(), err
+}
+
+func getPeriodTotals(ctx context.Context, client countv1.CountServiceClient, opts *options, periods []datepb.Period) ([]*countv1.MethodCount, error) {
+	req := periodTotalsRequest(periods[0], opts.tz)
+	req.Service = opts.service
+	req.Freshness = opts.freshness()
+	req.LabelFilter = opts.labels
+	req.GroupByLabels = opts.groupByLabels()
+	resp, err := client.GetPeriodTotals(ctx, req)
+	return resp.GetMethodCounts(), err
+}
+
+// parseFlags parses args with flags and returns the positional arguments.
+// Unlike flags.Parse, flags may follow positional arguments,
+// until a "--" argument, after which all arguments are positional.
+func parseFlags(flags *flag.FlagSet, args []string) (positional []string, err error) {
+	for {
+		if err = flags.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := flags.Args()
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		if parsed := len(args) - len(rest); parsed > 0 && args[parsed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// parseArgs parses the flags and period arguments of a command.
+// Additional flags can be defined by the extraFlags callback.
+func parseArgs(name string, args []string, stderr io.Writer, now time.Time, minArgs, maxArgs int, defaultOutput string, extraFlags func(*flag.FlagSet)) (*options, []datepb.Period, error) {
+	flags, opts := newFlagSet(name, stderr, defaultOutput)
+	if extraFlags != nil {
+		extraFlags(flags)
+	}
+	args, err := parseFlags(flags, args)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(args) < minArgs || len(args) > maxArgs {
+		flags.Usage()
+		return nil, nil, fmt.Errorf("%s: invalid amount of arguments", name)
+	}
+
+	loc := time.Local
+	if opts.tz != "" {
+		if loc, err = time.LoadLocation(opts.tz); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	periods := make([]datepb.Period, len(args))
+	for i, arg := range args {
+		if periods[i], err = parsePeriod(arg, now, loc); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return opts, periods, nil
+}
+
+// dial the server at addr. The api key is sent
+// with each RPC, when not empty.
+func dial(ctx context.Context, addr, key string) (*grpc.ClientConn, error) {
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if key != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(queue.APIKey{
+			Key:           key,
+			AllowInsecure: true,
+		}))
+	}
+	return grpc.DialContext(ctx, addr, opts...)
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer, now time.Time) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return fmt.Errorf("command required")
+	}
+	switch args[0] {
+	case "export":
+		return runExport(ctx, args[1:], stdout, stderr, now)
+	case "key":
+		return runKey(ctx, args[1:], stdout, stderr)
+	case "quota":
+		return runQuota(ctx, args[1:], stdout, stderr)
+	case "latency":
+		return runLatency(ctx, args[1:], stdout, stderr, now)
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprint(stderr, usage)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+
+	opts, periods, err := parseArgs(args[0], args[1:], stderr, now, cmd.minArgs, cmd.maxArgs, "table", nil)
+	if err != nil {
+		return err
+	}
+	write, ok := formats[opts.output]
+	if !ok {
+		return fmt.Errorf("invalid output format %q, use table, csv or json", opts.output)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	cc, err := dial(ctx, opts.addr, opts.key)
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	counts, err := cmd.run(ctx, countv1.NewCountServiceClient(cc), opts, periods)
+	if err != nil {
+		return err
+	}
+
+	rows, err := opts.filter.apply(toRows(counts))
+	if err != nil {
+		return err
+	}
+	if err = sortRows(rows, opts.sort, opts.desc); err != nil {
+		return err
+	}
+
+	return write(stdout, rows)
+}
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdout, os.Stderr, time.Now()); err != nil {
+		fmt.Fprintln(os.Stderr, "countctl:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/muhlemmer/count/internal/service"
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"google.golang.org/grpc"
+)
+
+func Test_run(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, time.October, 17, 12, 0, 0, 0, time.UTC)
+
+	mem := store.NewMemory()
+	for _, ts := range []time.Time{
+		time.Date(2022, time.October, 16, 1, 0, 0, 0, time.UTC),
+		time.Date(2022, time.October, 16, 2, 0, 0, 0, time.UTC),
+	} {
+		if err := mem.InsertMethodRequest(ctx, store.DefaultService, countv1.Method_GET, "/users", ts); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := mem.InsertMethodRequest(ctx, store.DefaultService, countv1.Method_POST, "/items", now); err != nil {
+		t.Fatal(err)
+	}
+
+	// labeled requests of another service, counted before the tests.
+	labeled := time.Date(2022, time.October, 10, 1, 0, 0, 0, time.UTC)
+	for _, region := range []string{"eu", "eu", "us"} {
+		details := store.RequestDetails{Labels: map[string]string{"region": region}}
+		if err := mem.InsertRequestDetails(ctx, "labeled", countv1.Method_GET, "/users", labeled, details); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := mem.CountDailyMethodTotals(ctx, labeled.Truncate(24*time.Hour), labeled.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	service.NewCountService(server, mem)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	addr := "-addr=" + lis.Addr().String()
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "count yesterday",
+			args: []string{"count", addr, "-tz=UTC", "-o=csv", "yesterday"},
+			want: "date,method,path,count\n2022-10-16,GET,/users,2\n",
+		},
+		{
+			name: "count today",
+			args: []string{"count", addr, "-tz=UTC", "-o=csv", "today"},
+			want: "date,method,path,count\n2022-10-17,POST,/items,1\n",
+		},
+		{
+			name: "list",
+			args: []string{"list", addr, "-o=csv", "-sort=count", "-desc", "last-week", "this-week"},
+			want: "date,method,path,count\n2022-10-16,GET,/users,2\n2022-10-17,POST,/items,1\n",
+		},
+		{
+			name: "list filtered",
+			args: []string{"list", addr, "-o=csv", "-method=POST", "2022-10-01", "2022-10"},
+			want: "date,method,path,count\n2022-10-17,POST,/items,1\n",
+		},
+		{
+			name: "list strong",
+			args: []string{"list", addr, "-o=csv", "-strong", "-method=POST", "2022-10-01", "2022-10"},
+			want: "date,method,path,count\n2022-10-17,POST,/items,1\n",
+		},
+		{
+			name: "list flags after dates",
+			args: []string{"list", addr, "last-week", "this-week", "-o=csv", "-method=POST"},
+			want: "date,method,path,count\n2022-10-17,POST,/items,1\n",
+		},
+		{
+			name:    "list other service",
+			args:    []string{"list", addr, "-o=csv", "-service=shop", "2022-10-01", "2022-10"},
+			wantErr: true,
+		},
+		{
+			name: "period",
+			args: []string{"period", addr, "-o=csv", "2022-Q4"},
+			want: "date,method,path,count\n,POST,/items,1\n,GET,/users,2\n",
+		},
+		{
+			name: "period strong",
+			args: []string{"period", addr, "-o=csv", "-strong", "2022-Q4"},
+			want: "date,method,path,count\n,POST,/items,1\n,GET,/users,2\n",
+		},
+		{
+			name: "list group by labels",
+			args: []string{"list", addr, "-o=csv", "-service=labeled", "-group-by=region", "2022-10-10"},
+			want: "date,method,path,labels,count\n2022-10-10,GET,/users,region=eu,2\n2022-10-10,GET,/users,region=us,1\n",
+		},
+		{
+			name: "period label filter",
+			args: []string{"period", addr, "-o=csv", "-service=labeled", "-label=region=eu", "2022-10"},
+			want: "date,method,path,count\n,GET,/users,2\n",
+		},
+		{
+			name:    "bad label",
+			args:    []string{"period", addr, "-label=region", "2022-10"},
+			wantErr: true,
+		},
+		{
+			name: "export",
+			args: []string{"export", addr, "last-week", "this-week"},
+			want: "date,method,path,count\n2022-10-16,GET,/users,2\n2022-10-17,POST,/items,1\n",
+		},
+		{
+			name: "export jsonl",
+			args: []string{"export", addr, "-o=jsonl", "-path=/users", "2022-10"},
+			want: `{"date":"2022-10-16","method":"GET","path":"/users","count":2}` + "\n",
+		},
+		{
+			name:    "export sorted",
+			args:    []string{"export", addr, "-sort=count", "2022-10"},
+			wantErr: true,
+		},
+		{
+			name:    "export resume stdout",
+			args:    []string{"export", addr, "-resume", "2022-10"},
+			wantErr: true,
+		},
+		{
+			name:    "not found",
+			args:    []string{"period", addr, "last-year"},
+			wantErr: true,
+		},
+		{
+			name:    "count month",
+			args:    []string{"count", addr, "this-month"},
+			wantErr: true,
+		},
+		{
+			name:    "too many arguments",
+			args:    []string{"period", addr, "2022", "2023"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown command",
+			args:    []string{"foo"},
+			wantErr: true,
+		},
+		{
+			name:    "bad format",
+			args:    []string{"period", addr, "-o=xml", "2022"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			err := run(ctx, tt.args, &stdout, io.Discard, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := stdout.String(); got != tt.want {
+				t.Errorf("run() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseFlags(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantPos    []string
+		wantOutput string
+		wantErr    bool
+	}{
+		{"flags first", []string{"-o=json", "a", "b"}, []string{"a", "b"}, "json", false},
+		{"flags last", []string{"a", "b", "-o", "json"}, []string{"a", "b"}, "json", false},
+		{"interspersed", []string{"a", "-o=json", "b"}, []string{"a", "b"}, "json", false},
+		{"terminator", []string{"a", "--", "-o=json"}, []string{"a", "-o=json"}, "table", false},
+		{"unknown flag", []string{"a", "-foo"}, nil, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags, opts := newFlagSet("test", io.Discard, "table")
+			gotPos, err := parseFlags(flags, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(gotPos, tt.wantPos) {
+				t.Errorf("parseFlags() = %q, want %q", gotPos, tt.wantPos)
+			}
+			if opts.output != tt.wantOutput {
+				t.Errorf("parseFlags() output = %q, want %q", opts.output, tt.wantOutput)
+			}
+		})
+	}
+}
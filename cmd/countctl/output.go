@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+)
+
+// row is a flattened MethodCount.
+type row struct {
+	Date   string `json:"date,omitempty"`
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Count  int64  `json:"count"`
+
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+func toRows(counts []*countv1.MethodCount) []row {
+	rows := make([]row, len(counts))
+	for i, mc := range counts {
+		rows[i] = row{
+			Method: mc.GetMethod().String(),
+			Path:   mc.GetPath(),
+			Count:  mc.GetCount(),
+			Labels: mc.GetLabels(),
+		}
+		if mc.GetDate() != nil {
+			rows[i].Date = datepb.Time(mc.GetDate()).Format("2006-01-02")
+		}
+	}
+	return rows
+}
+
+// filter options for the output.
+type filter struct {
+	// method name, all methods when empty.
+	method string
+	// path glob pattern, as used by path.Match. All paths when empty.
+	path string
+}
+
+// match reports whether the method and path pair passes the filter.
+func (f filter) match(method, p string) (bool, error) {
+	if f.method != "" && method != f.method {
+		return false, nil
+	}
+	if f.path != "" {
+		ok, err := path.Match(f.path, p)
+		if err != nil {
+			return false, fmt.Errorf("path filter: %w", err)
+		}
+		return ok, nil
+	}
+	return true, nil
+}
+
+func (f filter) apply(rows []row) ([]row, error) {
+	var out []row
+	for _, r := range rows {
+		ok, err := f.match(r.Method, r.Path)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+var sortLess = map[string]func(a, b row) bool{
+	"date":   func(a, b row) bool { return a.Date < b.Date },
+	"method": func(a, b row) bool { return a.Method < b.Method },
+	"path":   func(a, b row) bool { return a.Path < b.Path },
+	"count":  func(a, b row) bool { return a.Count < b.Count },
+}
+
+// sortRows sorts by field. The server order is kept for an empty field.
+func sortRows(rows []row, field string, desc bool) error {
+	if field == "" {
+		return nil
+	}
+	less, ok := sortLess[field]
+	if !ok {
+		return fmt.Errorf("invalid sort field %q, use date, method, path or count", field)
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if desc {
+			return less(rows[j], rows[i])
+		}
+		return less(rows[i], rows[j])
+	})
+	return nil
+}
+
+type writeFunc func(w io.Writer, rows []row) error
+
+var formats = map[string]writeFunc{
+	"table": writeTable,
+	"csv":   writeCSV,
+	"json":  writeJSON,
+}
+
+// hasLabels reports whether any row has labels,
+// which adds a labels column to the table and csv formats.
+func hasLabels(rows []row) bool {
+	for _, r := range rows {
+		if len(r.Labels) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func writeTable(w io.Writer, rows []row) error {
+	labels := hasLabels(rows)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if labels {
+		fmt.Fprintln(tw, "DATE\tMETHOD\tPATH\tLABELS\tCOUNT")
+	} else {
+		fmt.Fprintln(tw, "DATE\tMETHOD\tPATH\tCOUNT")
+	}
+	for _, r := range rows {
+		if labels {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\n", r.Date, r.Method, r.Path, formatLabels(r.Labels), r.Count)
+		} else {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\n", r.Date, r.Method, r.Path, r.Count)
+		}
+	}
+	return tw.Flush()
+}
+
+func writeCSV(w io.Writer, rows []row) error {
+	labels := hasLabels(rows)
+	cw := csv.NewWriter(w)
+	if labels {
+		cw.Write([]string{"date", "method", "path", "labels", "count"})
+	} else {
+		cw.Write([]string{"date", "method", "path", "count"})
+	}
+	for _, r := range rows {
+		if labels {
+			cw.Write([]string{r.Date, r.Method, r.Path, formatLabels(r.Labels), strconv.FormatInt(r.Count, 10)})
+		} else {
+			cw.Write([]string{r.Date, r.Method, r.Path, strconv.FormatInt(r.Count, 10)})
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeJSON(w io.Writer, rows []row) error {
+	if rows == nil {
+		rows = []row{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rows)
+}
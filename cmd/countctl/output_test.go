@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+var testRows = []row{
+	{Date: "2022-10-16", Method: "GET", Path: "/users", Count: 3},
+	{Date: "2022-10-16", Method: "POST", Path: "/users", Count: 1},
+	{Date: "2022-10-17", Method: "GET", Path: "/items/1", Count: 2},
+}
+
+func Test_filter_apply(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  filter
+		want    []row
+		wantErr bool
+	}{
+		{"none", filter{}, testRows, false},
+		{"method", filter{method: "GET"}, []row{testRows[0], testRows[2]}, false},
+		{"path", filter{path: "/items/*"}, []row{testRows[2]}, false},
+		{"both", filter{method: "POST", path: "/items/*"}, nil, false},
+		{"bad pattern", filter{path: "["}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.filter.apply(testRows)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("filter.apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filter.apply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_sortRows(t *testing.T) {
+	rows := append([]row(nil), testRows...)
+
+	if err := sortRows(rows, "count", true); err != nil {
+		t.Fatal(err)
+	}
+	want := []row{testRows[0], testRows[2], testRows[1]}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("sortRows() = %v, want %v", rows, want)
+	}
+
+	if err := sortRows(rows, "foo", false); err == nil {
+		t.Error("sortRows() expected error")
+	}
+}
+
+func Test_formats(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: "table",
+			want: `DATE        METHOD  PATH      COUNT
+2022-10-16  GET     /users    3
+2022-10-16  POST    /users    1
+2022-10-17  GET     /items/1  2
+`,
+		},
+		{
+			format: "csv",
+			want: `date,method,path,count
+2022-10-16,GET,/users,3
+2022-10-16,POST,/users,1
+2022-10-17,GET,/items/1,2
+`,
+		},
+		{
+			format: "json",
+			want: `[
+  {
+    "date": "2022-10-16",
+    "method": "GET",
+    "path": "/users",
+    "count": 3
+  },
+  {
+    "date": "2022-10-16",
+    "method": "POST",
+    "path": "/users",
+    "count": 1
+  },
+  {
+    "date": "2022-10-17",
+    "method": "GET",
+    "path": "/items/1",
+    "count": 2
+  }
+]
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := formats[tt.format](&buf, testRows); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("%s =\n%s\nwant\n%s", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_formats_labels(t *testing.T) {
+	rows := []row{
+		{Date: "2022-10-16", Method: "GET", Path: "/users", Count: 3, Labels: map[string]string{"tier": "free", "region": "eu"}},
+		{Date: "2022-10-16", Method: "GET", Path: "/users", Count: 1},
+	}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: "table",
+			want: `DATE        METHOD  PATH    LABELS               COUNT
+2022-10-16  GET     /users  region=eu,tier=free  3
+2022-10-16  GET     /users                       1
+`,
+		},
+		{
+			format: "csv",
+			want: `date,method,path,labels,count
+2022-10-16,GET,/users,"region=eu,tier=free",3
+2022-10-16,GET,/users,,1
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := formats[tt.format](&buf, rows); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("%s =\n%s\nwant\n%s", tt.format, got, tt.want)
+			}
+		})
+	}
+}
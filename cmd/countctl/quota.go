@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+)
+
+func writeQuota(w io.Writer, quota *countv1.Quota) error {
+	limit, remaining := "unlimited", "-"
+	if quota.GetLimit() > 0 {
+		limit = fmt.Sprint(quota.GetLimit())
+		remaining = fmt.Sprint(quota.GetRemaining())
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "SERVICE\tDATE\tLIMIT\tUSED\tREMAINING")
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n",
+		quota.GetService(), datepb.Time(quota.GetDate()).Format("2006-01-02"),
+		limit, quota.GetUsed(), remaining,
+	)
+	return tw.Flush()
+}
+
+// runQuota executes the quota command.
+func runQuota(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	flags, opts := newFlagSet("quota", stderr, "table")
+	pos, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 0 {
+		flags.Usage()
+		return fmt.Errorf("quota: invalid amount of arguments")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	cc, err := dial(ctx, opts.addr, opts.key)
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	resp, err := countv1.NewCountServiceClient(cc).GetQuota(ctx, &countv1.GetQuotaRequest{
+		Service: opts.service,
+	})
+	if err != nil {
+		return err
+	}
+	return writeQuota(stdout, resp.GetQuota())
+}
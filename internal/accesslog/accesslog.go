@@ -0,0 +1,223 @@
+// Package accesslog parses web server access logs,
+// for importing the history of requests as daily totals.
+package accesslog
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/queue"
+)
+
+// Format of an access log.
+type Format string
+
+// Supported formats.
+const (
+	// Common Log Format, as written by Apache and NGINX.
+	CLF Format = "clf"
+	// Combined Log Format, which is CLF followed by the referer and user agent.
+	Combined Format = "combined"
+	// JSON Lines, one JSON object per request.
+	JSON Format = "json"
+)
+
+// ParseFormat returns the Format named s.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case CLF, Combined, JSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("accesslog: unknown format %q, use clf, combined or json", s)
+	}
+}
+
+// Entry is a single request from an access log.
+type Entry struct {
+	Method countv1.Method
+	Path   string
+	Time   time.Time
+}
+
+func newEntry(method, target string, ts time.Time) Entry {
+	return Entry{
+		Method: queue.NormalizeMethod(method),
+		Path:   queue.NormalizePath(target),
+		Time:   ts,
+	}
+}
+
+// ErrMalformed is returned for lines which cannot be parsed.
+var ErrMalformed = errors.New("accesslog: malformed line")
+
+const clfTimeLayout = "02/Jan/2006:15:04:05 -0700"
+
+// clfPattern matches the host, identity, user, time and request line.
+// The status, size and Combined fields are not used.
+var clfPattern = regexp.MustCompile(`^\S+ \S+ .*?\[([^\]]+)\] "(\S+) (\S+)(?: [^"]*)?" `)
+
+// parseCLF parses a line in the Common or Combined Log Format.
+func parseCLF(line string) (Entry, error) {
+	m := clfPattern.FindStringSubmatch(line)
+	if m == nil {
+		return Entry{}, ErrMalformed
+	}
+
+	ts, err := time.Parse(clfTimeLayout, m[1])
+	if err != nil {
+		return Entry{}, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	return newEntry(m[2], m[3], ts), nil
+}
+
+// Keys which are tried, in order, to find the fields of a JSON log entry.
+// Nested objects are addressed with dots.
+// They cover the defaults of common web servers,
+// such as Caddy, Traefik and NGINX's escape=json.
+var (
+	jsonMethodKeys  = []string{"method", "request_method", "request.method", "RequestMethod"}
+	jsonPathKeys    = []string{"path", "uri", "request_uri", "url", "request.uri", "RequestPath"}
+	jsonTimeKeys    = []string{"time", "timestamp", "ts", "@timestamp", "time_local", "time_iso8601", "StartUTC"}
+	jsonRequestKeys = []string{"request"}
+)
+
+// lookup returns the first of keys found in obj.
+func lookup(obj map[string]interface{}, keys []string) (interface{}, bool) {
+	for _, key := range keys {
+		var (
+			v  interface{} = obj
+			ok bool
+		)
+		for _, name := range strings.Split(key, ".") {
+			var o map[string]interface{}
+			if o, ok = v.(map[string]interface{}); !ok {
+				break
+			}
+			if v, ok = o[name]; !ok {
+				break
+			}
+		}
+		if ok && v != nil {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func lookupString(obj map[string]interface{}, keys []string) (string, bool) {
+	v, ok := lookup(obj, keys)
+	if !ok {
+		return "", false
+	}
+	s, ok := v.(string)
+	return s, ok
+}
+
+// parseJSONTime parses a RFC 3339 or CLF timestamp,
+// or a number of seconds since the Unix epoch.
+func parseJSONTime(v interface{}) (time.Time, error) {
+	switch v := v.(type) {
+	case float64:
+		sec, frac := math.Modf(v)
+		return time.Unix(int64(sec), int64(frac*1e9)), nil
+	case string:
+		if ts, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return ts, nil
+		}
+		return time.Parse(clfTimeLayout, v)
+	default:
+		return time.Time{}, fmt.Errorf("unsupported time value %v", v)
+	}
+}
+
+// parseJSON parses a JSON object with the method, path and time of a request.
+// A request line, as in CLF, can be used instead of the method and path.
+func parseJSON(line string) (Entry, error) {
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &obj); err != nil {
+		return Entry{}, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+
+	v, ok := lookup(obj, jsonTimeKeys)
+	if !ok {
+		return Entry{}, fmt.Errorf("%w: no time", ErrMalformed)
+	}
+	ts, err := parseJSONTime(v)
+	if err != nil {
+		return Entry{}, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+
+	method, okMethod := lookupString(obj, jsonMethodKeys)
+	target, okPath := lookupString(obj, jsonPathKeys)
+	if !okMethod || !okPath {
+		request, ok := lookupString(obj, jsonRequestKeys)
+		fields := strings.Fields(request)
+		if !ok || len(fields) < 2 {
+			return Entry{}, fmt.Errorf("%w: no method and path", ErrMalformed)
+		}
+		method, target = fields[0], fields[1]
+	}
+
+	return newEntry(method, target, ts), nil
+}
+
+// ParseLine parses a single line of an access log in format.
+func ParseLine(format Format, line string) (Entry, error) {
+	if format == JSON {
+		return parseJSON(line)
+	}
+	return parseCLF(line)
+}
+
+// LineError is a line which could not be parsed.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// Parse r line by line in format and calls fn for each entry.
+// Empty lines are ignored. Lines which cannot be parsed
+// are passed to fn as a *LineError, with a zero Entry.
+// Parsing stops when fn returns an error, which is returned.
+func Parse(r io.Reader, format Format, fn func(Entry, error) error) error {
+	br := bufio.NewReader(r)
+
+	for n := 1; ; n++ {
+		line, err := br.ReadString('\n')
+		if errors.Is(err, io.EOF) && line == "" {
+			return nil
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			continue
+		}
+
+		entry, err := ParseLine(format, line)
+		if err != nil {
+			err = &LineError{Line: n, Err: err}
+		}
+		if err = fn(entry, err); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package accesslog
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+)
+
+func TestParseFormat(t *testing.T) {
+	for _, s := range []string{"clf", "combined", "json"} {
+		if got, err := ParseFormat(s); err != nil || string(got) != s {
+			t.Errorf("ParseFormat(%q) = %q, %v", s, got, err)
+		}
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("ParseFormat(xml) expected error")
+	}
+}
+
+func TestParseLine(t *testing.T) {
+	ts := time.Date(2000, time.October, 10, 13, 55, 36, 0, time.FixedZone("", -7*3600))
+
+	tests := []struct {
+		name    string
+		format  Format
+		line    string
+		want    Entry
+		wantErr bool
+	}{
+		{
+			name:   "clf",
+			format: CLF,
+			line:   `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif?x=1 HTTP/1.0" 200 2326`,
+			want:   Entry{countv1.Method_GET, "/apache_pb.gif", ts},
+		},
+		{
+			name:   "combined",
+			format: Combined,
+			line:   `127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "POST /users HTTP/1.1" 201 - "http://example.com/" "Mozilla/5.0 (X11)"`,
+			want:   Entry{countv1.Method_POST, "/users", ts},
+		},
+		{
+			name:   "clf unknown method",
+			format: CLF,
+			line:   `::1 - - [10/Oct/2000:13:55:36 -0700] "PATCH /users/1 HTTP/1.1" 204 0`,
+			want:   Entry{countv1.Method_METHOD_UNSPECIFIED, "/users/1", ts},
+		},
+		{
+			name:    "clf bad time",
+			format:  CLF,
+			line:    `127.0.0.1 - - [10/Oct/2000 13:55:36] "GET / HTTP/1.0" 200 2326`,
+			wantErr: true,
+		},
+		{
+			name:    "clf garbage",
+			format:  CLF,
+			line:    `foo`,
+			wantErr: true,
+		},
+		{
+			name:   "json",
+			format: JSON,
+			line:   `{"time":"2000-10-10T13:55:36-07:00","method":"GET","path":"/users?id=1"}`,
+			want:   Entry{countv1.Method_GET, "/users", ts},
+		},
+		{
+			name:   "json caddy",
+			format: JSON,
+			line:   `{"level":"info","ts":971211336.5,"request":{"method":"DELETE","uri":"/users/1"},"status":200}`,
+			want:   Entry{countv1.Method_DELETE, "/users/1", time.Unix(971211336, 5e8)},
+		},
+		{
+			name:   "json request line",
+			format: JSON,
+			line:   `{"time_local":"10/Oct/2000:13:55:36 -0700","request":"PUT /users/1 HTTP/1.1","status":"200"}`,
+			want:   Entry{countv1.Method_PUT, "/users/1", ts},
+		},
+		{
+			name:    "json no time",
+			format:  JSON,
+			line:    `{"method":"GET","path":"/"}`,
+			wantErr: true,
+		},
+		{
+			name:    "json no path",
+			format:  JSON,
+			line:    `{"time":"2000-10-10T13:55:36-07:00","method":"GET"}`,
+			wantErr: true,
+		},
+		{
+			name:    "json syntax",
+			format:  JSON,
+			line:    `{"time":`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLine(tt.format, tt.line)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLine() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrMalformed) {
+				t.Errorf("ParseLine() error = %v, want %v", err, ErrMalformed)
+			}
+			if got.Method != tt.want.Method || got.Path != tt.want.Path || !got.Time.Equal(tt.want.Time) {
+				t.Errorf("ParseLine() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	const input = `127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "GET / HTTP/1.0" 200 2326
+
+foo
+127.0.0.1 - - [10/Oct/2000:13:55:37 -0700] "GET /bar HTTP/1.0" 200 2326`
+
+	var (
+		paths []string
+		lines []int
+	)
+	err := Parse(strings.NewReader(input), CLF, func(e Entry, err error) error {
+		var lineErr *LineError
+		if errors.As(err, &lineErr) {
+			lines = append(lines, lineErr.Line)
+			return nil
+		}
+		paths = append(paths, e.Path)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(paths, ",") != "/,/bar" {
+		t.Errorf("Parse() paths = %v", paths)
+	}
+	if len(lines) != 1 || lines[0] != 3 {
+		t.Errorf("Parse() error lines = %v, want [3]", lines)
+	}
+
+	errStop := errors.New("stop")
+	err = Parse(strings.NewReader(input), CLF, func(Entry, error) error { return errStop })
+	if !errors.Is(err, errStop) {
+		t.Errorf("Parse() err = %v, want %v", err, errStop)
+	}
+}
@@ -0,0 +1,109 @@
+package accesslog
+
+import (
+	"sort"
+	"time"
+
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+)
+
+// MaxErrors is the amount of line errors kept in a Summary.
+const MaxErrors = 10
+
+// Summary of the entries added to an Aggregator.
+type Summary struct {
+	Requests      int // Amount of parsed requests.
+	Malformed     int // Amount of lines which could not be parsed.
+	UnknownMethod int // Requests counted with METHOD_UNSPECIFIED.
+
+	// First and last request time, in the time zone of the Aggregator.
+	First, Last time.Time
+
+	// First MaxErrors line errors.
+	Errors []error
+}
+
+type dailyKey struct {
+	day    time.Time
+	method countv1.Method
+	path   string
+}
+
+// Aggregator counts entries into daily totals,
+// against their date in a time zone.
+type Aggregator struct {
+	loc    *time.Location
+	totals map[dailyKey]int64
+	Summary
+}
+
+// NewAggregator returns an empty Aggregator which counts
+// entries against their date in loc.
+func NewAggregator(loc *time.Location) *Aggregator {
+	return &Aggregator{
+		loc:    loc,
+		totals: make(map[dailyKey]int64),
+	}
+}
+
+// Add an entry, or a line error which is recorded in the summary.
+// It never returns an error and can be passed to Parse.
+func (a *Aggregator) Add(entry Entry, err error) error {
+	if err != nil {
+		a.Malformed++
+		if len(a.Errors) < MaxErrors {
+			a.Errors = append(a.Errors, err)
+		}
+		return nil
+	}
+
+	ts := entry.Time.In(a.loc)
+	if a.Requests == 0 || ts.Before(a.First) {
+		a.First = ts
+	}
+	if a.Requests == 0 || ts.After(a.Last) {
+		a.Last = ts
+	}
+	a.Requests++
+	if entry.Method == countv1.Method_METHOD_UNSPECIFIED {
+		a.UnknownMethod++
+	}
+
+	a.totals[dailyKey{
+		day:    time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, time.UTC),
+		method: entry.Method,
+		path:   entry.Path,
+	}]++
+	return nil
+}
+
+// DailyTotals returns the counted totals,
+// ordered by date, path and method.
+func (a *Aggregator) DailyTotals() []*countv1.MethodCount {
+	keys := make([]dailyKey, 0, len(a.totals))
+	for k := range a.totals {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if !a.day.Equal(b.day) {
+			return a.day.Before(b.day)
+		}
+		if a.path != b.path {
+			return a.path < b.path
+		}
+		return a.method.String() < b.method.String()
+	})
+
+	results := make([]*countv1.MethodCount, len(keys))
+	for i, k := range keys {
+		results[i] = &countv1.MethodCount{
+			Method: k.method,
+			Path:   k.path,
+			Count:  a.totals[k],
+			Date:   datepb.Date(k.day),
+		}
+	}
+	return results
+}
@@ -0,0 +1,56 @@
+package accesslog
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestAggregator(t *testing.T) {
+	loc := time.FixedZone("", 2*3600)
+	a := NewAggregator(loc)
+
+	for _, e := range []Entry{
+		{countv1.Method_GET, "/b", time.Date(2022, 10, 16, 23, 0, 0, 0, time.UTC)},
+		{countv1.Method_GET, "/a", time.Date(2022, 10, 16, 12, 0, 0, 0, time.UTC)},
+		{countv1.Method_GET, "/a", time.Date(2022, 10, 16, 13, 0, 0, 0, time.UTC)},
+		{countv1.Method_METHOD_UNSPECIFIED, "/a", time.Date(2022, 10, 16, 14, 0, 0, 0, time.UTC)},
+	} {
+		a.Add(e, nil)
+	}
+	for i := 0; i < MaxErrors+1; i++ {
+		a.Add(Entry{}, ErrMalformed)
+	}
+
+	want := []*countv1.MethodCount{
+		{Method: countv1.Method_GET, Path: "/a", Count: 2, Date: &date.Date{Year: 2022, Month: 10, Day: 16}},
+		{Method: countv1.Method_METHOD_UNSPECIFIED, Path: "/a", Count: 1, Date: &date.Date{Year: 2022, Month: 10, Day: 16}},
+		{Method: countv1.Method_GET, Path: "/b", Count: 1, Date: &date.Date{Year: 2022, Month: 10, Day: 17}},
+	}
+	got := a.DailyTotals()
+	if len(got) != len(want) {
+		t.Fatalf("DailyTotals() =\n%v\nwant\n%v", got, want)
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Fatalf("DailyTotals() =\n%v\nwant\n%v", got, want)
+		}
+	}
+
+	if a.Requests != 4 || a.UnknownMethod != 1 || a.Malformed != MaxErrors+1 || len(a.Errors) != MaxErrors {
+		t.Errorf("Summary = %+v", a.Summary)
+	}
+	if !errors.Is(a.Errors[0], ErrMalformed) {
+		t.Errorf("Summary.Errors[0] = %v", a.Errors[0])
+	}
+	if first := time.Date(2022, 10, 16, 14, 0, 0, 0, loc); !a.First.Equal(first) || a.First.Location() != loc {
+		t.Errorf("Summary.First = %v, want %v", a.First, first)
+	}
+	if last := time.Date(2022, 10, 17, 1, 0, 0, 0, loc); !a.Last.Equal(last) {
+		t.Errorf("Summary.Last = %v, want %v", a.Last, last)
+	}
+}
@@ -0,0 +1,214 @@
+// Package backoff provides retries with exponential backoff and jitter.
+package backoff
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Jitter randomizes the delays of a Policy,
+// so that clients which failed at the same time do not retry in lockstep.
+type Jitter int
+
+const (
+	// NoJitter uses the exponential delay as is.
+	NoJitter Jitter = iota
+
+	// FullJitter picks a random delay between zero and the exponential delay.
+	FullJitter
+
+	// EqualJitter picks a random delay between half and the full exponential delay.
+	EqualJitter
+
+	// DecorrelatedJitter picks a random delay between Initial and three times
+	// the previous delay, capped by Max. Multiplier is not used.
+	DecorrelatedJitter
+)
+
+// Clock provides the time for a Policy.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// Policy defaults
+const (
+	DefaultInitial    = 100 * time.Millisecond
+	DefaultMultiplier = 2
+)
+
+// Policy determines the delays between retries.
+// The delay of attempt n is Initial * Multiplier^n, capped by Max
+// and randomized by Jitter.
+type Policy struct {
+	// Initial delay, defaults to DefaultInitial.
+	Initial time.Duration
+
+	// Max delay. Zero means no maximum.
+	Max time.Duration
+
+	// Multiplier of the delay after each attempt.
+	// Defaults to DefaultMultiplier.
+	Multiplier float64
+
+	Jitter Jitter
+
+	// MaxAttempts is the maximum amount of attempts,
+	// including the first. Zero means unlimited.
+	MaxAttempts int
+
+	// MaxElapsed is the maximum time since the first attempt,
+	// after which no more attempts are made. Zero means unlimited.
+	MaxElapsed time.Duration
+
+	// Clock defaults to the system clock.
+	Clock Clock
+
+	// Rand used for jitter, defaults to the math/rand source.
+	Rand *rand.Rand
+}
+
+// Backoff tracks the attempts of a Policy.
+type Backoff struct {
+	policy  Policy
+	start   time.Time
+	attempt int
+	prev    time.Duration
+}
+
+// Start a Backoff, for a first attempt made now.
+func (p Policy) Start() *Backoff {
+	if p.Initial <= 0 {
+		p.Initial = DefaultInitial
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = DefaultMultiplier
+	}
+	if p.Clock == nil {
+		p.Clock = realClock{}
+	}
+	return &Backoff{
+		policy: p,
+		start:  p.Clock.Now(),
+		prev:   p.Initial,
+	}
+}
+
+func (b *Backoff) random(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	if b.policy.Rand != nil {
+		return time.Duration(b.policy.Rand.Int63n(int64(d)))
+	}
+	return time.Duration(rand.Int63n(int64(d)))
+}
+
+// capped returns d, or Max when d exceeds Max.
+func (b *Backoff) capped(d float64) time.Duration {
+	if max := b.policy.Max; max > 0 && d > float64(max) {
+		return max
+	}
+	if d > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(d)
+}
+
+// Next returns the delay before the next attempt.
+// False is returned when no more attempts should be made.
+func (b *Backoff) Next() (time.Duration, bool) {
+	p := b.policy
+	b.attempt++
+	if p.MaxAttempts > 0 && b.attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	var d time.Duration
+	if p.Jitter == DecorrelatedJitter {
+		upper := b.capped(3 * float64(b.prev))
+		d = b.capped(float64(p.Initial + b.random(upper-p.Initial)))
+	} else {
+		d = b.capped(float64(p.Initial) * math.Pow(p.Multiplier, float64(b.attempt-1)))
+		switch p.Jitter {
+		case FullJitter:
+			d = b.random(d)
+		case EqualJitter:
+			d = d/2 + b.random(d-d/2)
+		}
+	}
+	b.prev = d
+
+	if p.MaxElapsed > 0 && p.Clock.Now().Add(d).Sub(b.start) > p.MaxElapsed {
+		return 0, false
+	}
+	return d, true
+}
+
+// Wait for the delay before the next attempt.
+// False is returned when no more attempts should be made
+// or ctx is done.
+func (b *Backoff) Wait(ctx context.Context) bool {
+	d, ok := b.Next()
+	if !ok {
+		return false
+	}
+	select {
+	case <-ctx.Done():
+		return false
+	case <-b.policy.Clock.After(d):
+		return true
+	}
+}
+
+// PermanentError is an error which should not be retried.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string { return e.Err.Error() }
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+// Permanent wraps err in a PermanentError.
+// Nil is returned for a nil err.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+// IsPermanent reports whether err wraps a PermanentError.
+func IsPermanent(err error) bool {
+	var perm *PermanentError
+	return errors.As(err, &perm)
+}
+
+// Retry calls op until it returns nil or a permanent error,
+// the policy is exhausted or ctx is done.
+// The last error returned by op is returned,
+// unwrapped from a PermanentError.
+func Retry(ctx context.Context, p Policy, op func(ctx context.Context) error) error {
+	b := p.Start()
+	for {
+		err := op(ctx)
+		if err == nil {
+			return nil
+		}
+		var perm *PermanentError
+		if errors.As(err, &perm) {
+			return perm.Err
+		}
+		if !b.Wait(ctx) {
+			return err
+		}
+	}
+}
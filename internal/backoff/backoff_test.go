@@ -0,0 +1,228 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// fakeClock advances its time by the waited durations.
+type fakeClock struct {
+	now    time.Time
+	waited []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.now = c.now.Add(d)
+	c.waited = append(c.waited, d)
+
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func TestBackoff_Next(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+		want   []time.Duration
+	}{
+		{
+			name:   "defaults",
+			policy: Policy{MaxAttempts: 5},
+			want:   []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond},
+		},
+		{
+			name:   "max",
+			policy: Policy{Initial: time.Second, Max: 3 * time.Second, Multiplier: 3, MaxAttempts: 4},
+			want:   []time.Duration{time.Second, 3 * time.Second, 3 * time.Second},
+		},
+		{
+			name:   "single attempt",
+			policy: Policy{MaxAttempts: 1},
+		},
+		{
+			name:   "max elapsed",
+			policy: Policy{Initial: time.Second, MaxElapsed: 4 * time.Second},
+			want:   []time.Duration{time.Second, 2 * time.Second},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fakeClock{now: time.Unix(0, 0)}
+			tt.policy.Clock = clock
+			b := tt.policy.Start()
+
+			for b.Wait(context.Background()) {
+			}
+			if len(clock.waited) != len(tt.want) {
+				t.Fatalf("Backoff waited %v, want %v", clock.waited, tt.want)
+			}
+			for i, d := range tt.want {
+				if clock.waited[i] != d {
+					t.Errorf("Backoff waited %v, want %v", clock.waited, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestBackoff_Next_jitter(t *testing.T) {
+	const (
+		initial = 100 * time.Millisecond
+		max     = 10 * time.Second
+	)
+	tests := []struct {
+		name   string
+		jitter Jitter
+		// bounds of attempt n, with the exponential delay d of attempt n
+		// and the previous delay prev.
+		bounds func(d, prev time.Duration) (min, max time.Duration)
+	}{
+		{
+			name:   "full",
+			jitter: FullJitter,
+			bounds: func(d, _ time.Duration) (time.Duration, time.Duration) { return 0, d },
+		},
+		{
+			name:   "equal",
+			jitter: EqualJitter,
+			bounds: func(d, _ time.Duration) (time.Duration, time.Duration) { return d / 2, d },
+		},
+		{
+			name:   "decorrelated",
+			jitter: DecorrelatedJitter,
+			bounds: func(_, prev time.Duration) (time.Duration, time.Duration) {
+				if prev*3 > max {
+					return initial, max
+				}
+				return initial, prev * 3
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := Policy{
+				Initial: initial,
+				Max:     max,
+				Jitter:  tt.jitter,
+				Clock:   &fakeClock{},
+				Rand:    rand.New(rand.NewSource(1)),
+			}.Start()
+
+			d, prev := initial, initial
+			for i := 0; i < 20; i++ {
+				got, ok := b.Next()
+				if !ok {
+					t.Fatal("Backoff.Next() exhausted")
+				}
+				if min, max := tt.bounds(d, prev); got < min || got > max {
+					t.Errorf("attempt %d: Backoff.Next() = %v, want between %v and %v", i, got, min, max)
+				}
+				if d *= 2; d > max {
+					d = max
+				}
+				prev = got
+			}
+		})
+	}
+}
+
+func TestBackoff_Wait_context(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	b := Policy{Initial: time.Hour}.Start()
+	if b.Wait(ctx) {
+		t.Error("Backoff.Wait() = true, want false")
+	}
+}
+
+func TestPermanent(t *testing.T) {
+	if Permanent(nil) != nil {
+		t.Error("Permanent(nil) != nil")
+	}
+
+	errFoo := errors.New("foo")
+	err := fmt.Errorf("bar: %w", Permanent(errFoo))
+	if !IsPermanent(err) {
+		t.Errorf("IsPermanent(%v) = false", err)
+	}
+	if !errors.Is(err, errFoo) {
+		t.Errorf("errors.Is(%v, %v) = false", err, errFoo)
+	}
+	if IsPermanent(errFoo) {
+		t.Errorf("IsPermanent(%v) = true", errFoo)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	errFoo := errors.New("foo")
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		errs      []error
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			errs:      []error{nil},
+			wantCalls: 1,
+		},
+		{
+			name:      "retried",
+			ctx:       context.Background(),
+			errs:      []error{errFoo, errFoo, nil},
+			wantCalls: 3,
+		},
+		{
+			name:      "permanent",
+			ctx:       context.Background(),
+			errs:      []error{errFoo, Permanent(errFoo)},
+			wantCalls: 2,
+			wantErr:   errFoo,
+		},
+		{
+			name:      "max attempts",
+			ctx:       context.Background(),
+			errs:      []error{errFoo, errFoo, errFoo, errFoo},
+			wantCalls: 3,
+			wantErr:   errFoo,
+		},
+		{
+			name:      "context",
+			ctx:       canceled,
+			errs:      []error{errFoo, nil},
+			wantCalls: 1,
+			wantErr:   errFoo,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			err := Retry(tt.ctx, Policy{
+				Initial:     time.Millisecond,
+				MaxAttempts: 3,
+			}, func(context.Context) error {
+				calls++
+				return tt.errs[calls-1]
+			})
+			if err != tt.wantErr {
+				t.Errorf("Retry() err = %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("Retry() calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
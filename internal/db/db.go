@@ -2,61 +2,33 @@ package db
 
 import (
 	"context"
-	"errors"
-	"fmt"
-	"strings"
+	"sync"
 	"time"
 
-	"github.com/jackc/pgconn"
-	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgtype"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/log/zerologadapter"
 	"github.com/jackc/pgx/v4/pgxpool"
-	"github.com/muhlemmer/count/internal/timer"
+	"github.com/muhlemmer/count/internal/backoff"
+	"github.com/muhlemmer/count/internal/store"
 	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
 	"github.com/rs/zerolog"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
-type multiError []error
-
-func (errs multiError) Error() string {
-	s := make([]string, len(errs))
-	for i, err := range errs {
-		s[i] = err.Error()
-	}
-
-	return fmt.Sprintf("multiple errors: %s", strings.Join(s, ", "))
-}
-
-func statusError(err error, desc string) error {
-	if err == nil {
-		return nil
-	}
-
-	var code codes.Code
-
-	pge := new(pgconn.PgError)
-	if errors.As(err, &pge) {
-		switch pge.Code {
-		case pgerrcode.UniqueViolation:
-			code = codes.AlreadyExists
-		default:
-			code = codes.Internal
-		}
-
-		return status.Errorf(code, "%s: %s", desc, pge.Detail)
-	}
-
-	return err
-}
-
 // DB provides high level query execution over
 // a PGX connection pool.
+// Queries which allow stale results may be served
+// by an optional replica pool.
 type DB struct {
 	pool *pgxpool.Pool
+
+	replica       *pgxpool.Pool
+	replicaHealth replicaHealth
+	followerReads bool
+
+	dialectMu sync.Mutex
+	dialect   Dialect
 }
 
 func Wrap(pool *pgxpool.Pool) *DB {
@@ -65,7 +37,12 @@ func Wrap(pool *pgxpool.Pool) *DB {
 
 // New configures a new PGX connection pool
 // with a zerolog adapter taken from context.
-func New(ctx context.Context, dsn string) (*DB, error) {
+func New(ctx context.Context, dsn string, opts ...Option) (*DB, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	conf, err := pgxpool.ParseConfig(dsn)
 	if err != nil {
 		return nil, err
@@ -78,28 +55,50 @@ func New(ctx context.Context, dsn string) (*DB, error) {
 		return nil, err
 	}
 
-	return &DB{pool}, pool.Ping(ctx)
+	db := &DB{
+		pool:          pool,
+		followerReads: o.followerReads,
+	}
+	if o.replicaDSN != "" {
+		if db.replica, err = connectReplica(ctx, o.replicaDSN); err != nil {
+			pool.Close()
+			return nil, err
+		}
+	}
+
+	dialect, err := db.Dialect(ctx)
+	if err == nil && o.followerReads && dialect != CockroachDB {
+		zerolog.Ctx(ctx).Warn().Stringer("dialect", dialect).Msg("db follower reads are only supported on CockroachDB")
+	}
+	return db, err
 }
 
 func (db *DB) Close() {
 	db.pool.Close()
+	if db.replica != nil {
+		db.replica.Close()
+	}
+}
+
+// insertRetryPolicy retries inserts until the context expires.
+var insertRetryPolicy = backoff.Policy{
+	Initial: time.Second,
+	Max:     10 * time.Second,
+	Jitter:  backoff.DecorrelatedJitter,
 }
 
-func (db *DB) execRetry(ctx context.Context, min, max time.Duration, sql string, args ...interface{}) error {
+// execRetry executes sql, retrying retryable errors by policy.
+// All errors are returned when the statement failed more than once.
+func (db *DB) execRetry(ctx context.Context, policy backoff.Policy, sql string, args ...interface{}) error {
 	logger := zerolog.Ctx(ctx).Sample(zerolog.Often)
 	var errs multiError
 
-retry:
-	for {
+	err := backoff.Retry(ctx, policy, func(ctx context.Context) error {
 		// fail-fast wrapper function for isolated context and cancelation.
-		err := func(ctx context.Context) error {
-			ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
-			defer cancel()
-
-			_, err := db.pool.Exec(ctx, sql, args...)
-			return err
-		}(ctx)
+		ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		defer cancel()
 
+		_, err := db.pool.Exec(ctx, sql, args...)
 		if err == nil {
 			return nil
 		}
@@ -107,62 +106,133 @@ retry:
 		logger.Err(err).Msg("db exec retry")
 		errs = append(errs, err)
 
-		select {
-		case <-ctx.Done():
-			break retry
-		case <-timer.RandomTimer(min, max):
-			// retry
+		if !retryable(err) {
+			return backoff.Permanent(err)
 		}
-	}
+		return err
+	})
 
+	if err == nil {
+		return nil
+	}
 	if len(errs) == 1 {
 		return errs[0]
 	}
-
 	return errs
 }
 
-// InsertMethodRequest inserts a request for a certain method and path.
+// InsertMethodRequest inserts a request for a certain method and path of service.
 // Inserts are retried untill the operation succeeds without error
 // or when the passed context expires.
-func (db *DB) InsertMethodRequest(ctx context.Context, method countv1.Method, path string, requestTS time.Time) error {
+func (db *DB) InsertMethodRequest(ctx context.Context, service string, method countv1.Method, path string, requestTS time.Time) error {
 	return statusError(
-		db.execRetry(ctx, time.Second, 10*time.Second, insertMethodRequestSQL, method.String(), path, requestTS),
+		db.execRetry(ctx, insertRetryPolicy, insertMethodRequestSQL, method.String(), path, requestTS, service, nil, nil),
 		"insert method request",
 	)
+}
 
+// InsertRequestDetails is like InsertMethodRequest, for a request with details.
+// The client hash and duration are stored with the request, when set,
+// and counted into the clients and latencies sketches of the totals.
+// Labels are stored as a label set in count.label_sets, which
+// is referenced by a count.methods entry for each label set of a method and path.
+func (db *DB) InsertRequestDetails(ctx context.Context, service string, method countv1.Method, path string, requestTS time.Time, details store.RequestDetails) error {
+	var client, duration *int64
+	if details.Client != nil {
+		h := int64(*details.Client)
+		client = &h
+	}
+	if details.Duration != nil {
+		d := int64(*details.Duration)
+		duration = &d
+	}
+	if len(details.Labels) == 0 {
+		return statusError(
+			db.execRetry(ctx, insertRetryPolicy, insertMethodRequestSQL, method.String(), path, requestTS, service, client, duration),
+			"insert request details",
+		)
+	}
+	return statusError(
+		db.execRetry(ctx, insertRetryPolicy, insertLabeledRequestSQL, method.String(), path, requestTS, service, client, duration, store.EncodeLabels(details.Labels)),
+		"insert request details",
+	)
 }
 
 // CountDailyMethodTotals deletes entries from count.requests for the given day.
-// Deleted entries are counted for each method and path pair and inserted in the
-// count.daily_method_totals table.
-// The resulting count enties are returned.
+// Deleted entries are counted for each service, method and path and added to the
+// count.daily_method_totals, count.monthly_method_totals and count.yearly_method_totals tables,
+// so that totals imported for the same day are kept.
+// Entries are counted against their date in the location of start,
+// which must be a loaded IANA time zone or UTC.
+// The resulting daily totals are returned, with their service set.
+// The client hashes and durations of the requests are merged into the
+// clients and latencies sketches of the daily, monthly and yearly totals.
+//
+// On PostgreSQL, when count.requests is partitioned and a partition
+// exists for the day, the partition is counted and dropped instead.
 func (db *DB) CountDailyMethodTotals(ctx context.Context, start, end time.Time) ([]*countv1.MethodCount, error) {
 	const errDesc = "count daily method totals"
 
-	rows, err := db.pool.Query(ctx, countDailyMethodTotalsSQL,
-		pgtype.Timestamptz{
-			Time:   start,
-			Status: pgtype.Present,
-		},
-		pgtype.Timestamptz{
-			Time:   end,
-			Status: pgtype.Present,
-		},
-	)
-	if err = statusError(err, errDesc); err != nil {
+	dialect, err := db.Dialect(ctx)
+	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	if dialect == PostgreSQL {
+		name := partitionName(start)
+		exists, err := db.requestsPartitionExists(ctx, name)
+		if err != nil {
+			return nil, statusError(err, errDesc)
+		}
+		if exists {
+			results, err := db.countRequestsPartition(ctx, name, start, end)
+			return results, statusError(err, errDesc)
+		}
+	}
 
-	results, err := scanMethodCountRows(rows)
+	var results []*countv1.MethodCount
+	err = db.retryTx(ctx, func(ctx context.Context) error {
+		return db.pool.BeginTxFunc(ctx, pgx.TxOptions{}, func(tx pgx.Tx) error {
+			sketches, err := requestSketches(ctx, tx, start, end)
+			if err != nil {
+				return err
+			}
+
+			rows, err := tx.Query(ctx, countDailyMethodTotalsSQL,
+				pgtype.Timestamptz{
+					Time:   start,
+					Status: pgtype.Present,
+				},
+				pgtype.Timestamptz{
+					Time:   end,
+					Status: pgtype.Present,
+				},
+				start.Location().String(),
+			)
+			if err != nil {
+				return err
+			}
+
+			results, err = scanMethodCountRows(rows)
+			rows.Close()
+			if err != nil {
+				return err
+			}
+
+			return mergeSketches(ctx, tx, sketches)
+		})
+	})
 	return results, statusError(err, errDesc)
 }
 
-// dateIntervalQuery is a generalized function for queries that use a start / end date interval.
-// the passed query is executed with start and end as arguments.
-func (db *DB) dateIntervalQuery(ctx context.Context, query string, start, end time.Time) (pgx.Rows, error) {
-	return db.pool.Query(ctx, query,
+// dateIntervalQuery is a generalized function for read-only queries that use a start / end date interval.
+// the passed query is executed with start, end and service as arguments,
+// on the primary or replica by the freshness of ctx, see readQuery.
+func (db *DB) dateIntervalQuery(ctx context.Context, query, service string, start, end time.Time) ([]*countv1.MethodCount, error) {
+	var results []*countv1.MethodCount
+	err := db.readQuery(ctx, func(rows pgx.Rows) (err error) {
+		results, err = scanMethodCountRows(rows)
+		return err
+	}, query,
 		pgtype.Date{
 			Time:   start,
 			Status: pgtype.Present,
@@ -171,31 +241,93 @@ func (db *DB) dateIntervalQuery(ctx context.Context, query string, start, end ti
 			Time:   end,
 			Status: pgtype.Present,
 		},
+		service,
 	)
+	return results, err
 }
 
-// ListDailyTotals selects entries from count.daily_method_totals in the
-// date interval of start-end inclusive.
-func (db *DB) ListDailyTotals(ctx context.Context, start, end time.Time) ([]*countv1.MethodCount, error) {
-	const errDesc = "list daily totals"
+// ListDailyTotals selects entries of service from count.daily_method_totals
+// in the date interval of start-end inclusive.
+// The replica is used, unless Strong freshness is set on ctx.
+func (db *DB) ListDailyTotals(ctx context.Context, service string, start, end time.Time) ([]*countv1.MethodCount, error) {
+	results, err := db.dateIntervalQuery(ctx, listDailyTotalsSQL, service, start, end)
+	return results, statusError(err, "list daily totals")
+}
+
+// periodQuery returns the query on the coarsest table
+// which exactly covers the interval start-end inclusive.
+// The yearly or monthly query is used when the interval
+// consists of whole years or months, otherwise the daily query.
+func periodQuery(start, end time.Time, daily, monthly, yearly string) string {
+	if start.Day() != 1 {
+		return daily
+	}
+
+	next := end.Add(1)
+	if !next.Equal(time.Date(next.Year(), next.Month(), 1, 0, 0, 0, 0, next.Location())) {
+		return daily
+	}
+	if start.Month() == time.January && next.Month() == time.January {
+		return yearly
+	}
+
+	return monthly
+}
+
+// periodTotalsQuery returns the period query of the totals, see periodQuery.
+func periodTotalsQuery(start, end time.Time) string {
+	return periodQuery(start, end, getPeriodTotalsSQL, getPeriodTotalsMonthlySQL, getPeriodTotalsYearlySQL)
+}
 
-	rows, err := db.dateIntervalQuery(ctx, listDailyTotalsSQL, start, end)
+// GetPeriodTotals sums the totals of service in count.daily_method_totals,
+// grouped by method and path.
+// Start and end times are inclusive.
+// When the interval consists of whole years or months,
+// the pre-aggregated count.yearly_method_totals or
+// count.monthly_method_totals tables are used instead.
+// The replica is used, unless Strong freshness is set on ctx.
+func (db *DB) GetPeriodTotals(ctx context.Context, service string, start, end time.Time) ([]*countv1.MethodCount, error) {
+	results, err := db.dateIntervalQuery(ctx, periodTotalsQuery(start, end), service, start, end)
+	return results, statusError(err, "get period totals")
+}
+
+// ComparePeriods sums the totals of service from count.daily_method_totals
+// for two periods and compares them for each method and path pair.
+// Start and end times are inclusive.
+func (db *DB) ComparePeriods(ctx context.Context, service string, start, end, refStart, refEnd time.Time) ([]*countv1.PeriodComparison, error) {
+	const errDesc = "compare periods"
+
+	rows, err := db.pool.Query(ctx, comparePeriodsSQL,
+		pgtype.Date{Time: start, Status: pgtype.Present},
+		pgtype.Date{Time: end, Status: pgtype.Present},
+		pgtype.Date{Time: refStart, Status: pgtype.Present},
+		pgtype.Date{Time: refEnd, Status: pgtype.Present},
+		service,
+	)
 	if err = statusError(err, errDesc); err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	results, err := scanMethodCountRows(rows)
+	results, err := scanPeriodComparisonRows(rows)
 	return results, statusError(err, errDesc)
 }
 
-// GetPeriodTotals selects entries from count.daily_method_totals and
-// sums the totals columns, grouped by method and path.
+// GetTimeSeries selects entries of service from count.daily_method_totals and
+// sums the totals columns, grouped by bucket, method and path.
+// Each bucket has the length of precision and its date is set to the
+// first day of the bucket. Results are limited to paths, unless empty.
 // Start and end times are inclusive.
-func (db *DB) GetPeriodTotals(ctx context.Context, start, end time.Time) ([]*countv1.MethodCount, error) {
-	const errDesc = "get period totals"
-
-	rows, err := db.dateIntervalQuery(ctx, getPeriodTotalsSQL, start, end)
+func (db *DB) GetTimeSeries(ctx context.Context, service string, start, end time.Time, precision datepb.Precision, paths []string) ([]*countv1.MethodCount, error) {
+	const errDesc = "get time series"
+
+	rows, err := db.pool.Query(ctx, getTimeSeriesSQL,
+		pgtype.Date{Time: start, Status: pgtype.Present},
+		pgtype.Date{Time: end, Status: pgtype.Present},
+		precision.String(),
+		paths,
+		service,
+	)
 	if err = statusError(err, errDesc); err != nil {
 		return nil, err
 	}
@@ -8,9 +8,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/muhlemmer/count/internal/backoff"
+	"github.com/muhlemmer/count/internal/store"
 	"github.com/muhlemmer/count/internal/tester"
 	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
 	"github.com/muhlemmer/count/pkg/datepb"
+	"google.golang.org/genproto/googleapis/type/date"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -93,13 +96,11 @@ func TestDB_execRetry(t *testing.T) {
 			args:    args{R.ErrCTX, "select $1::int;", []interface{}{1}},
 			wantErr: true,
 		},
-		/*
-			{
-				name:    "repeated error",
-				args:    args{R.CTX, "foo $1::int;", []interface{}{1}},
-				wantErr: true,
-			},
-		*/
+		{
+			name:    "permanent error",
+			args:    args{R.CTX, "foo $1::int;", []interface{}{1}},
+			wantErr: true,
+		},
 		{
 			name: "success",
 			args: args{R.CTX, "select $1::int;", []interface{}{1}},
@@ -110,7 +111,7 @@ func TestDB_execRetry(t *testing.T) {
 			ctx, cancel := context.WithTimeout(tt.args.ctx, time.Second)
 			defer cancel()
 
-			if err := testDB.execRetry(ctx, time.Microsecond, time.Second/10, tt.args.sql, tt.args.args...); (err != nil) != tt.wantErr {
+			if err := testDB.execRetry(ctx, backoff.Policy{Initial: time.Microsecond, Max: time.Second / 10}, tt.args.sql, tt.args.args...); (err != nil) != tt.wantErr {
 				t.Errorf("DB.execRetry() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -141,7 +142,7 @@ func TestDB_InsertMethodRequest(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := testDB.InsertMethodRequest(tt.args.ctx, tt.args.method, tt.args.path, tt.args.requestTS); (err != nil) != tt.wantErr {
+			if err := testDB.InsertMethodRequest(tt.args.ctx, store.DefaultService, tt.args.method, tt.args.path, tt.args.requestTS); (err != nil) != tt.wantErr {
 				t.Errorf("DB.InsertMethodRequest() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -185,30 +186,32 @@ func TestDB_CountDailyMethodTotals(t *testing.T) {
 			name: "success",
 			args: args{R.CTX, date},
 			want: []*countv1.MethodCount{
-				{Method: countv1.Method_DELETE, Path: "/actions", Count: 50, Date: datepb.Date(date)},
-				{Method: countv1.Method_GET, Path: "/actions", Count: 50, Date: datepb.Date(date)},
-				{Method: countv1.Method_GRPC, Path: "/actions", Count: 50, Date: datepb.Date(date)},
-				{Method: countv1.Method_POST, Path: "/actions", Count: 50, Date: datepb.Date(date)},
-				{Method: countv1.Method_DELETE, Path: "/items", Count: 50, Date: datepb.Date(date)},
-				{Method: countv1.Method_GET, Path: "/items", Count: 50, Date: datepb.Date(date)},
-				{Method: countv1.Method_GRPC, Path: "/items", Count: 50, Date: datepb.Date(date)},
-				{Method: countv1.Method_POST, Path: "/items", Count: 50, Date: datepb.Date(date)},
-				{Method: countv1.Method_DELETE, Path: "/users", Count: 50, Date: datepb.Date(date)},
-				{Method: countv1.Method_GET, Path: "/users", Count: 49, Date: datepb.Date(date)},
-				{Method: countv1.Method_GRPC, Path: "/users", Count: 50, Date: datepb.Date(date)},
-				{Method: countv1.Method_POST, Path: "/users", Count: 50, Date: datepb.Date(date)},
+				{Method: countv1.Method_DELETE, Path: "/actions", Count: 50, Date: datepb.Date(date), Service: store.DefaultService},
+				{Method: countv1.Method_GET, Path: "/actions", Count: 50, Date: datepb.Date(date), Service: store.DefaultService},
+				{Method: countv1.Method_GRPC, Path: "/actions", Count: 50, Date: datepb.Date(date), Service: store.DefaultService},
+				{Method: countv1.Method_POST, Path: "/actions", Count: 50, Date: datepb.Date(date), Service: store.DefaultService},
+				{Method: countv1.Method_DELETE, Path: "/items", Count: 50, Date: datepb.Date(date), Service: store.DefaultService},
+				{Method: countv1.Method_GET, Path: "/items", Count: 50, Date: datepb.Date(date), Service: store.DefaultService},
+				{Method: countv1.Method_GRPC, Path: "/items", Count: 50, Date: datepb.Date(date), Service: store.DefaultService},
+				{Method: countv1.Method_POST, Path: "/items", Count: 50, Date: datepb.Date(date), Service: store.DefaultService},
+				{Method: countv1.Method_DELETE, Path: "/users", Count: 50, Date: datepb.Date(date), Service: store.DefaultService},
+				{Method: countv1.Method_GET, Path: "/users", Count: 49, Date: datepb.Date(date), Service: store.DefaultService},
+				{Method: countv1.Method_GRPC, Path: "/users", Count: 50, Date: datepb.Date(date), Service: store.DefaultService},
+				{Method: countv1.Method_POST, Path: "/users", Count: 50, Date: datepb.Date(date), Service: store.DefaultService},
 			},
 		},
 		{
-			name:    "conflict",
-			args:    args{R.CTX, date},
-			wantErr: true,
+			name: "merge",
+			args: args{R.CTX, date},
+			want: []*countv1.MethodCount{
+				{Method: countv1.Method_POST, Path: "/items", Count: 51, Date: datepb.Date(date), Service: store.DefaultService},
+			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if tt.name == "conflict" {
-				err := testDB.InsertMethodRequest(R.CTX, countv1.Method_POST, "/items", date)
+			if tt.name == "merge" {
+				err := testDB.InsertMethodRequest(R.CTX, store.DefaultService, countv1.Method_POST, "/items", date)
 				if err != nil {
 					t.Fatal(err)
 				}
@@ -287,7 +290,7 @@ func TestDB_ListDailyTotals(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := testDB.ListDailyTotals(tt.args.ctx, tt.args.from, tt.args.till)
+			got, err := testDB.ListDailyTotals(tt.args.ctx, store.DefaultService, tt.args.from, tt.args.till)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("DB.ListDailyTotals() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -383,7 +386,7 @@ func TestDB_GetPeriodTotals(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := testDB.GetPeriodTotals(tt.args.ctx, tt.args.start, tt.args.end)
+			got, err := testDB.GetPeriodTotals(tt.args.ctx, store.DefaultService, tt.args.start, tt.args.end)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("DB.GetPeriodTotals() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -392,3 +395,265 @@ func TestDB_GetPeriodTotals(t *testing.T) {
 		})
 	}
 }
+
+func newComparison(method countv1.Method, path string, count, refCount int64) *countv1.PeriodComparison {
+	c := &countv1.PeriodComparison{
+		Method:         method,
+		Path:           path,
+		Count:          count,
+		ReferenceCount: refCount,
+		Delta:          count - refCount,
+		Presence:       periodPresence(count != 0, refCount != 0),
+	}
+	if refCount != 0 {
+		c.RelativeChange = float64(c.Delta) / float64(refCount)
+	}
+	return c
+}
+
+func TestDB_ComparePeriods(t *testing.T) {
+	var (
+		month = &date.Date{Year: 1986, Month: 3}
+		day   = &date.Date{Year: 1986, Month: 3, Day: 25}
+		empty = &date.Date{Year: 1977}
+	)
+
+	type args struct {
+		ctx       context.Context
+		period    *date.Date
+		refPeriod *date.Date
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []*countv1.PeriodComparison
+		wantErr bool
+	}{
+		{
+			name:    "context error",
+			args:    args{R.ErrCTX, month, day},
+			wantErr: true,
+		},
+		{
+			name: "month to day",
+			args: args{R.CTX, month, day},
+			want: []*countv1.PeriodComparison{
+				newComparison(countv1.Method_DELETE, "/actions", 6762, 46),
+				newComparison(countv1.Method_GET, "/actions", 11360, 565),
+				newComparison(countv1.Method_GRPC, "/actions", 9233, 725),
+				newComparison(countv1.Method_POST, "/actions", 8438, 211),
+				newComparison(countv1.Method_DELETE, "/items", 7759, 756),
+				newComparison(countv1.Method_GET, "/items", 8490, 853),
+				newComparison(countv1.Method_GRPC, "/items", 7744, 210),
+				newComparison(countv1.Method_POST, "/items", 7931, 107),
+				newComparison(countv1.Method_DELETE, "/users", 7220, 627),
+				newComparison(countv1.Method_GET, "/users", 8138, 473),
+				newComparison(countv1.Method_GRPC, "/users", 9961, 856),
+				newComparison(countv1.Method_POST, "/users", 9510, 288),
+			},
+		},
+		{
+			name: "reference only",
+			args: args{R.CTX, empty, day},
+			want: []*countv1.PeriodComparison{
+				newComparison(countv1.Method_DELETE, "/actions", 0, 46),
+				newComparison(countv1.Method_GET, "/actions", 0, 565),
+				newComparison(countv1.Method_GRPC, "/actions", 0, 725),
+				newComparison(countv1.Method_POST, "/actions", 0, 211),
+				newComparison(countv1.Method_DELETE, "/items", 0, 756),
+				newComparison(countv1.Method_GET, "/items", 0, 853),
+				newComparison(countv1.Method_GRPC, "/items", 0, 210),
+				newComparison(countv1.Method_POST, "/items", 0, 107),
+				newComparison(countv1.Method_DELETE, "/users", 0, 627),
+				newComparison(countv1.Method_GET, "/users", 0, 473),
+				newComparison(countv1.Method_GRPC, "/users", 0, 856),
+				newComparison(countv1.Method_POST, "/users", 0, 288),
+			},
+		},
+		{
+			name: "period only",
+			args: args{R.CTX, day, empty},
+			want: []*countv1.PeriodComparison{
+				newComparison(countv1.Method_DELETE, "/actions", 46, 0),
+				newComparison(countv1.Method_GET, "/actions", 565, 0),
+				newComparison(countv1.Method_GRPC, "/actions", 725, 0),
+				newComparison(countv1.Method_POST, "/actions", 211, 0),
+				newComparison(countv1.Method_DELETE, "/items", 756, 0),
+				newComparison(countv1.Method_GET, "/items", 853, 0),
+				newComparison(countv1.Method_GRPC, "/items", 210, 0),
+				newComparison(countv1.Method_POST, "/items", 107, 0),
+				newComparison(countv1.Method_DELETE, "/users", 627, 0),
+				newComparison(countv1.Method_GET, "/users", 473, 0),
+				newComparison(countv1.Method_GRPC, "/users", 856, 0),
+				newComparison(countv1.Method_POST, "/users", 288, 0),
+			},
+		},
+		{
+			name: "no results",
+			args: args{R.CTX, empty, empty},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := datepb.Interval(tt.args.period)
+			refStart, refEnd := datepb.Interval(tt.args.refPeriod)
+
+			got, err := testDB.ComparePeriods(tt.args.ctx, store.DefaultService, start, end, refStart, refEnd)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DB.ComparePeriods() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("DB.ComparePeriods() =\n%v\nwant\n%v", got, tt.want)
+			}
+			for i, want := range tt.want {
+				if !proto.Equal(got[i], want) {
+					t.Errorf("DB.ComparePeriods() #%d =\n%v\nwant\n%v", i, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestDB_GetTimeSeries(t *testing.T) {
+	var (
+		day1 = R.DailyTotalsBegin.Add(24 * time.Hour)
+		day2 = R.DailyTotalsBegin.Add(48 * time.Hour)
+	)
+
+	type args struct {
+		ctx       context.Context
+		precision datepb.Precision
+		paths     []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []*countv1.MethodCount
+		wantErr bool
+	}{
+		{
+			name:    "context error",
+			args:    args{R.ErrCTX, datepb.Day, nil},
+			wantErr: true,
+		},
+		{
+			name: "day",
+			args: args{R.CTX, datepb.Day, []string{"/users"}},
+			want: []*countv1.MethodCount{
+				{Date: datepb.Date(day1), Path: "/users", Method: countv1.Method_DELETE, Count: 146},
+				{Date: datepb.Date(day1), Path: "/users", Method: countv1.Method_GET, Count: 358},
+				{Date: datepb.Date(day1), Path: "/users", Method: countv1.Method_GRPC, Count: 409},
+				{Date: datepb.Date(day1), Path: "/users", Method: countv1.Method_POST, Count: 933},
+				{Date: datepb.Date(day2), Path: "/users", Method: countv1.Method_DELETE, Count: 159},
+				{Date: datepb.Date(day2), Path: "/users", Method: countv1.Method_GET, Count: 49},
+				{Date: datepb.Date(day2), Path: "/users", Method: countv1.Method_GRPC, Count: 542},
+				{Date: datepb.Date(day2), Path: "/users", Method: countv1.Method_POST, Count: 176},
+			},
+		},
+		{
+			// day1 is a Sunday, so each day falls in its own week.
+			name: "week",
+			args: args{R.CTX, datepb.Week, []string{"/users"}},
+			want: []*countv1.MethodCount{
+				{Date: &date.Date{Year: 1986, Month: 3, Day: 10}, Path: "/users", Method: countv1.Method_DELETE, Count: 146},
+				{Date: &date.Date{Year: 1986, Month: 3, Day: 10}, Path: "/users", Method: countv1.Method_GET, Count: 358},
+				{Date: &date.Date{Year: 1986, Month: 3, Day: 10}, Path: "/users", Method: countv1.Method_GRPC, Count: 409},
+				{Date: &date.Date{Year: 1986, Month: 3, Day: 10}, Path: "/users", Method: countv1.Method_POST, Count: 933},
+				{Date: &date.Date{Year: 1986, Month: 3, Day: 17}, Path: "/users", Method: countv1.Method_DELETE, Count: 159},
+				{Date: &date.Date{Year: 1986, Month: 3, Day: 17}, Path: "/users", Method: countv1.Method_GET, Count: 49},
+				{Date: &date.Date{Year: 1986, Month: 3, Day: 17}, Path: "/users", Method: countv1.Method_GRPC, Count: 542},
+				{Date: &date.Date{Year: 1986, Month: 3, Day: 17}, Path: "/users", Method: countv1.Method_POST, Count: 176},
+			},
+		},
+		{
+			name: "year",
+			args: args{R.CTX, datepb.Year, []string{"/actions", "/users"}},
+			want: []*countv1.MethodCount{
+				{Date: &date.Date{Year: 1986, Month: 1, Day: 1}, Path: "/actions", Method: countv1.Method_DELETE, Count: 217 + 185},
+				{Date: &date.Date{Year: 1986, Month: 1, Day: 1}, Path: "/actions", Method: countv1.Method_GET, Count: 2 + 563},
+				{Date: &date.Date{Year: 1986, Month: 1, Day: 1}, Path: "/actions", Method: countv1.Method_GRPC, Count: 510 + 404},
+				{Date: &date.Date{Year: 1986, Month: 1, Day: 1}, Path: "/actions", Method: countv1.Method_POST, Count: 818 + 813},
+				{Date: &date.Date{Year: 1986, Month: 1, Day: 1}, Path: "/users", Method: countv1.Method_DELETE, Count: 146 + 159},
+				{Date: &date.Date{Year: 1986, Month: 1, Day: 1}, Path: "/users", Method: countv1.Method_GET, Count: 358 + 49},
+				{Date: &date.Date{Year: 1986, Month: 1, Day: 1}, Path: "/users", Method: countv1.Method_GRPC, Count: 409 + 542},
+				{Date: &date.Date{Year: 1986, Month: 1, Day: 1}, Path: "/users", Method: countv1.Method_POST, Count: 933 + 176},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testDB.GetTimeSeries(tt.args.ctx, store.DefaultService, day1, day2, tt.args.precision, tt.args.paths)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DB.GetTimeSeries() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			compareMethodCounts(t, "DB.GetTimeSeries()", got, tt.want)
+		})
+	}
+}
+
+func Test_periodTotalsQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		date *date.Date
+		want string
+	}{
+		{"year", &date.Date{Year: 1986}, getPeriodTotalsYearlySQL},
+		{"month", &date.Date{Year: 1986, Month: 3}, getPeriodTotalsMonthlySQL},
+		{"day", &date.Date{Year: 1986, Month: 3, Day: 25}, getPeriodTotalsSQL},
+		{"first of month", &date.Date{Year: 1986, Month: 3, Day: 1}, getPeriodTotalsSQL},
+		{"last of month", &date.Date{Year: 1986, Month: 3, Day: 31}, getPeriodTotalsSQL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := periodTotalsQuery(datepb.Interval(tt.date)); got != tt.want {
+				t.Errorf("periodTotalsQuery() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("quarter", func(t *testing.T) {
+		if got := periodTotalsQuery(datepb.QuarterInterval(1986, 1)); got != getPeriodTotalsMonthlySQL {
+			t.Errorf("periodTotalsQuery() =\n%s\nwant\n%s", got, getPeriodTotalsMonthlySQL)
+		}
+	})
+	t.Run("week", func(t *testing.T) {
+		if got := periodTotalsQuery(datepb.WeekInterval(1986, 13)); got != getPeriodTotalsSQL {
+			t.Errorf("periodTotalsQuery() =\n%s\nwant\n%s", got, getPeriodTotalsSQL)
+		}
+	})
+}
+
+// dailyPeriodTotals sums the daily totals, without using the pre-aggregated tables.
+func dailyPeriodTotals(t *testing.T, start, end time.Time) []*countv1.MethodCount {
+	results, err := testDB.dateIntervalQuery(R.CTX, getPeriodTotalsSQL, store.DefaultService, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return results
+}
+
+func TestDB_GetPeriodTotals_aggregates(t *testing.T) {
+	tests := []struct {
+		name  string
+		start time.Time
+		end   time.Time
+	}{
+		{"year data", time.Date(1986, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(1987, time.January, 1, 0, 0, 0, -1, time.UTC)},
+		{"month data", time.Date(1986, time.April, 1, 0, 0, 0, 0, time.UTC), time.Date(1986, time.May, 1, 0, 0, 0, -1, time.UTC)},
+		{"quarter data", time.Date(1986, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(1986, time.April, 1, 0, 0, 0, -1, time.UTC)},
+		{"multi year", time.Date(1985, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(1987, time.January, 1, 0, 0, 0, -1, time.UTC)},
+		{"rolled up requests", time.Date(2022, time.October, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, time.November, 1, 0, 0, 0, -1, time.UTC)},
+		{"rolled up requests year", time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, time.January, 1, 0, 0, 0, -1, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testDB.GetPeriodTotals(R.CTX, store.DefaultService, tt.start, tt.end)
+			if err != nil {
+				t.Fatal(err)
+			}
+			compareMethodCounts(t, "DB.GetPeriodTotals()", got, dailyPeriodTotals(t, tt.start, tt.end))
+		})
+	}
+}
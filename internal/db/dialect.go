@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Dialect of the SQL database server.
+// Some features, such as partitioning, are only
+// available on a specific dialect.
+type Dialect int
+
+const (
+	DialectUnknown Dialect = iota
+	PostgreSQL
+	CockroachDB
+)
+
+func (d Dialect) String() string {
+	switch d {
+	case PostgreSQL:
+		return "PostgreSQL"
+	case CockroachDB:
+		return "CockroachDB"
+	default:
+		return fmt.Sprintf("Dialect(%d)", int(d))
+	}
+}
+
+// parseDialect determines the dialect from
+// the output of the version() function.
+func parseDialect(version string) Dialect {
+	if strings.Contains(version, "CockroachDB") {
+		return CockroachDB
+	}
+	return PostgreSQL
+}
+
+// Dialect of the connected database server.
+// The dialect is detected on first use and cached afterwards.
+func (db *DB) Dialect(ctx context.Context) (Dialect, error) {
+	db.dialectMu.Lock()
+	defer db.dialectMu.Unlock()
+
+	if db.dialect != DialectUnknown {
+		return db.dialect, nil
+	}
+
+	var version string
+	if err := db.pool.QueryRow(ctx, versionSQL).Scan(&version); err != nil {
+		return DialectUnknown, statusError(err, "detect dialect")
+	}
+
+	db.dialect = parseDialect(version)
+	return db.dialect, nil
+}
@@ -0,0 +1,36 @@
+package db
+
+import "testing"
+
+func Test_parseDialect(t *testing.T) {
+	tests := []struct {
+		version string
+		want    Dialect
+	}{
+		{
+			version: "PostgreSQL 14.5 (Debian 14.5-1.pgdg110+1) on x86_64-pc-linux-gnu, compiled by gcc (Debian 10.2.1-6) 10.2.1 20210110, 64-bit",
+			want:    PostgreSQL,
+		},
+		{
+			version: "CockroachDB CCL v22.1.9 (x86_64-pc-linux-gnu, built 2022/10/17 15:48:19, go1.17.11)",
+			want:    CockroachDB,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want.String(), func(t *testing.T) {
+			if got := parseDialect(tt.version); got != tt.want {
+				t.Errorf("parseDialect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDB_Dialect(t *testing.T) {
+	got, err := testDB.Dialect(R.CTX)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != PostgreSQL && got != CockroachDB {
+		t.Errorf("DB.Dialect() = %v", got)
+	}
+}
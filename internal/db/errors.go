@@ -0,0 +1,202 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/muhlemmer/count/internal/backoff"
+	"github.com/rs/zerolog"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type multiError []error
+
+func (errs multiError) Error() string {
+	s := make([]string, len(errs))
+	for i, err := range errs {
+		s[i] = err.Error()
+	}
+
+	return fmt.Sprintf("multiple errors: %s", strings.Join(s, ", "))
+}
+
+// Unwrap returns the last error, which determined the outcome.
+func (errs multiError) Unwrap() error {
+	return errs[len(errs)-1]
+}
+
+// ErrorDomain is the domain of the errdetails.ErrorInfo
+// attached to database errors.
+const ErrorDomain = "count.muhlemmer.com"
+
+// RetryDelay is the delay suggested to clients in errdetails.RetryInfo,
+// for errors which may succeed when retried.
+const RetryDelay = time.Second
+
+// errorClass describes how an error is reported to clients.
+type errorClass struct {
+	code codes.Code
+
+	// reason of errdetails.ErrorInfo.
+	reason string
+}
+
+// sqlStateClasses maps SQLSTATE codes to error classes.
+// Codes which are not listed are mapped by sqlStateClassClasses.
+var sqlStateClasses = map[string]errorClass{
+	pgerrcode.UniqueViolation:            {codes.AlreadyExists, "UNIQUE_VIOLATION"},
+	pgerrcode.SerializationFailure:       {codes.Aborted, "SERIALIZATION_FAILURE"},
+	pgerrcode.DeadlockDetected:           {codes.Aborted, "DEADLOCK_DETECTED"},
+	pgerrcode.LockNotAvailable:           {codes.Aborted, "LOCK_NOT_AVAILABLE"},
+	pgerrcode.QueryCanceled:              {codes.DeadlineExceeded, "QUERY_CANCELED"},
+	pgerrcode.InsufficientPrivilege:      {codes.PermissionDenied, "INSUFFICIENT_PRIVILEGE"},
+	pgerrcode.UndefinedTable:             {codes.FailedPrecondition, "SCHEMA_MISMATCH"},
+	pgerrcode.UndefinedColumn:            {codes.FailedPrecondition, "SCHEMA_MISMATCH"},
+	pgerrcode.UndefinedFunction:          {codes.FailedPrecondition, "SCHEMA_MISMATCH"},
+	pgerrcode.ReadOnlySQLTransaction:     {codes.FailedPrecondition, "READ_ONLY_TRANSACTION"},
+	pgerrcode.StatementCompletionUnknown: {codes.Unavailable, "STATEMENT_COMPLETION_UNKNOWN"},
+}
+
+// sqlStateClassClasses maps the class, the first two
+// characters of SQLSTATE codes, to error classes.
+// Classes which are not listed are Internal.
+var sqlStateClassClasses = map[string]errorClass{
+	"08": {codes.Unavailable, "CONNECTION_EXCEPTION"},
+	"22": {codes.InvalidArgument, "DATA_EXCEPTION"},
+	"23": {codes.FailedPrecondition, "INTEGRITY_CONSTRAINT_VIOLATION"},
+	"40": {codes.Aborted, "TRANSACTION_ROLLBACK"},
+	"53": {codes.ResourceExhausted, "INSUFFICIENT_RESOURCES"},
+	"54": {codes.ResourceExhausted, "PROGRAM_LIMIT_EXCEEDED"},
+	"55": {codes.FailedPrecondition, "OBJECT_NOT_IN_PREREQUISITE_STATE"},
+	"57": {codes.Unavailable, "OPERATOR_INTERVENTION"},
+	"58": {codes.Unavailable, "SYSTEM_ERROR"},
+}
+
+// classify returns the error class of err.
+// Server errors are classified by SQLSTATE,
+// other errors by their cause.
+func classify(err error) errorClass {
+	pge := new(pgconn.PgError)
+	if errors.As(err, &pge) {
+		if class, ok := sqlStateClasses[pge.Code]; ok {
+			return class
+		}
+		if len(pge.Code) == 5 {
+			if class, ok := sqlStateClassClasses[pge.Code[:2]]; ok {
+				return class
+			}
+		}
+		return errorClass{codes.Internal, "DATABASE_ERROR"}
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return errorClass{codes.NotFound, "NO_ROWS"}
+	case errors.Is(err, context.Canceled):
+		return errorClass{codes.Canceled, "CANCELED"}
+	case errors.Is(err, context.DeadlineExceeded), pgconn.Timeout(err):
+		return errorClass{codes.DeadlineExceeded, "TIMEOUT"}
+	case errors.As(err, &netErr), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return errorClass{codes.Unavailable, "CONNECTION_FAILURE"}
+	}
+	return errorClass{codes.Unknown, "UNKNOWN"}
+}
+
+// retryableCode reports whether an error of code may succeed when retried.
+// Unknown errors are permanent, as retrying them may repeat a failure
+// which is not transient.
+func retryableCode(code codes.Code) bool {
+	switch code {
+	case codes.Unavailable, codes.Aborted, codes.ResourceExhausted, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryable reports whether a failed statement may succeed when retried.
+// Errors from the server are permanent, unless they are caused by the
+// connection, a transaction conflict, resource exhaustion or a restart.
+// Of the other errors only network errors and timeouts are retryable.
+func retryable(err error) bool {
+	return retryableCode(classify(err).code)
+}
+
+// statusError converts err into a gRPC status error, described by desc.
+// The status carries an errdetails.ErrorInfo with the reason and, for
+// server errors, the SQLSTATE. Errors which may succeed when retried
+// also carry an errdetails.RetryInfo.
+// Status errors are returned as is.
+func statusError(err error, desc string) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	class := classify(err)
+	info := &errdetails.ErrorInfo{
+		Reason: class.reason,
+		Domain: ErrorDomain,
+	}
+
+	msg := err.Error()
+	pge := new(pgconn.PgError)
+	if errors.As(err, &pge) {
+		info.Metadata = map[string]string{"sqlstate": pge.Code}
+		if msg = pge.Detail; msg == "" {
+			msg = pge.Message
+		}
+	}
+
+	st := status.Newf(class.code, "%s: %s", desc, msg)
+	if retryableCode(class.code) {
+		st, err = st.WithDetails(info, &errdetails.RetryInfo{
+			RetryDelay: durationpb.New(RetryDelay),
+		})
+	} else {
+		st, err = st.WithDetails(info)
+	}
+	if err != nil {
+		return status.Errorf(class.code, "%s: %s", desc, msg)
+	}
+	return st.Err()
+}
+
+// txRetryPolicy retries transactions which failed by a serialization failure.
+var txRetryPolicy = backoff.Policy{
+	Initial:     50 * time.Millisecond,
+	Max:         time.Second,
+	Jitter:      backoff.FullJitter,
+	MaxAttempts: 5,
+}
+
+// retryTx calls fn, which runs a transaction or a single statement,
+// again when it fails by a serialization failure. CockroachDB returns those
+// to restart transactions which conflict with concurrent transactions,
+// which are rolled back, so it is safe to retry them as a whole.
+func (db *DB) retryTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return backoff.Retry(ctx, txRetryPolicy, func(ctx context.Context) error {
+		err := fn(ctx)
+
+		pge := new(pgconn.PgError)
+		if errors.As(err, &pge) && pge.Code == pgerrcode.SerializationFailure {
+			zerolog.Ctx(ctx).Debug().Err(err).Msg("db restart transaction")
+			return err
+		}
+		return backoff.Permanent(err)
+	})
+}
@@ -0,0 +1,195 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_multiError_Unwrap(t *testing.T) {
+	err := multiError{context.DeadlineExceeded, &pgconn.PgError{Code: pgerrcode.UniqueViolation}}
+	if retryable(err) {
+		t.Error("retryable() = true, want false by the last error")
+	}
+	if code := classify(err).code; code != codes.AlreadyExists {
+		t.Errorf("classify() code = %s, want %s", code, codes.AlreadyExists)
+	}
+}
+
+func Test_retryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"network", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, true},
+		{"unknown", errors.New("foo"), false},
+		{"deadline", context.DeadlineExceeded, true},
+		{"serialization", &pgconn.PgError{Code: pgerrcode.SerializationFailure}, true},
+		{"connection", &pgconn.PgError{Code: pgerrcode.ConnectionFailure}, true},
+		{"too many connections", &pgconn.PgError{Code: pgerrcode.TooManyConnections}, true},
+		{"admin shutdown", &pgconn.PgError{Code: pgerrcode.AdminShutdown}, true},
+		{"unique violation", &pgconn.PgError{Code: pgerrcode.UniqueViolation}, false},
+		{"not null violation", &pgconn.PgError{Code: pgerrcode.NotNullViolation}, false},
+		{"syntax error", &pgconn.PgError{Code: pgerrcode.SyntaxError}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryable(tt.err); got != tt.want {
+				t.Errorf("retryable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_classify(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want errorClass
+	}{
+		{"unique violation", &pgconn.PgError{Code: pgerrcode.UniqueViolation}, errorClass{codes.AlreadyExists, "UNIQUE_VIOLATION"}},
+		{"foreign key violation", &pgconn.PgError{Code: pgerrcode.ForeignKeyViolation}, errorClass{codes.FailedPrecondition, "INTEGRITY_CONSTRAINT_VIOLATION"}},
+		{"data exception", &pgconn.PgError{Code: pgerrcode.NumericValueOutOfRange}, errorClass{codes.InvalidArgument, "DATA_EXCEPTION"}},
+		{"serialization failure", &pgconn.PgError{Code: pgerrcode.SerializationFailure}, errorClass{codes.Aborted, "SERIALIZATION_FAILURE"}},
+		{"connection failure", &pgconn.PgError{Code: pgerrcode.ConnectionFailure}, errorClass{codes.Unavailable, "CONNECTION_EXCEPTION"}},
+		{"too many connections", &pgconn.PgError{Code: pgerrcode.TooManyConnections}, errorClass{codes.ResourceExhausted, "INSUFFICIENT_RESOURCES"}},
+		{"query canceled", &pgconn.PgError{Code: pgerrcode.QueryCanceled}, errorClass{codes.DeadlineExceeded, "QUERY_CANCELED"}},
+		{"admin shutdown", &pgconn.PgError{Code: pgerrcode.AdminShutdown}, errorClass{codes.Unavailable, "OPERATOR_INTERVENTION"}},
+		{"undefined table", &pgconn.PgError{Code: pgerrcode.UndefinedTable}, errorClass{codes.FailedPrecondition, "SCHEMA_MISMATCH"}},
+		{"syntax error", &pgconn.PgError{Code: pgerrcode.SyntaxError}, errorClass{codes.Internal, "DATABASE_ERROR"}},
+		{"wrapped", fmt.Errorf("foo: %w", &pgconn.PgError{Code: pgerrcode.DeadlockDetected}), errorClass{codes.Aborted, "DEADLOCK_DETECTED"}},
+		{"no rows", pgx.ErrNoRows, errorClass{codes.NotFound, "NO_ROWS"}},
+		{"canceled", context.Canceled, errorClass{codes.Canceled, "CANCELED"}},
+		{"deadline", context.DeadlineExceeded, errorClass{codes.DeadlineExceeded, "TIMEOUT"}},
+		{"network", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, errorClass{codes.Unavailable, "CONNECTION_FAILURE"}},
+		{"unknown", errors.New("foo"), errorClass{codes.Unknown, "UNKNOWN"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classify(tt.err); got != tt.want {
+				t.Errorf("classify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_statusError(t *testing.T) {
+	if statusError(nil, "foo") != nil {
+		t.Error("statusError(nil) != nil")
+	}
+
+	notFound := status.Error(codes.NotFound, "not found")
+	if err := statusError(notFound, "foo"); err != notFound {
+		t.Errorf("statusError() = %v, want %v", err, notFound)
+	}
+
+	tests := []struct {
+		name      string
+		err       error
+		wantCode  codes.Code
+		wantMsg   string
+		wantState string
+		wantRetry bool
+	}{
+		{
+			name:      "detail",
+			err:       &pgconn.PgError{Code: pgerrcode.UniqueViolation, Message: "duplicate key", Detail: "Key (id)=(1) already exists."},
+			wantCode:  codes.AlreadyExists,
+			wantMsg:   "foo: Key (id)=(1) already exists.",
+			wantState: pgerrcode.UniqueViolation,
+		},
+		{
+			name:      "restart transaction",
+			err:       &pgconn.PgError{Code: pgerrcode.SerializationFailure, Message: "restart transaction"},
+			wantCode:  codes.Aborted,
+			wantMsg:   "foo: restart transaction",
+			wantState: pgerrcode.SerializationFailure,
+			wantRetry: true,
+		},
+		{
+			name:      "deadline",
+			err:       context.DeadlineExceeded,
+			wantCode:  codes.DeadlineExceeded,
+			wantMsg:   "foo: context deadline exceeded",
+			wantRetry: true,
+		},
+		{
+			name:     "unknown",
+			err:      errors.New("bar"),
+			wantCode: codes.Unknown,
+			wantMsg:  "foo: bar",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(statusError(tt.err, "foo"))
+			if st.Code() != tt.wantCode || st.Message() != tt.wantMsg {
+				t.Errorf("statusError() = %s %q, want %s %q", st.Code(), st.Message(), tt.wantCode, tt.wantMsg)
+			}
+
+			var (
+				info  *errdetails.ErrorInfo
+				retry bool
+			)
+			for _, d := range st.Details() {
+				switch d := d.(type) {
+				case *errdetails.ErrorInfo:
+					info = d
+				case *errdetails.RetryInfo:
+					retry = d.GetRetryDelay().AsDuration() == RetryDelay
+				}
+			}
+			if info.GetDomain() != ErrorDomain || info.GetReason() != classify(tt.err).reason {
+				t.Errorf("statusError() info = %v", info)
+			}
+			if state := info.GetMetadata()["sqlstate"]; state != tt.wantState {
+				t.Errorf("statusError() sqlstate = %q, want %q", state, tt.wantState)
+			}
+			if retry != tt.wantRetry {
+				t.Errorf("statusError() retry info = %v, want %v", retry, tt.wantRetry)
+			}
+		})
+	}
+}
+
+func TestDB_retryTx(t *testing.T) {
+	restart := &pgconn.PgError{Code: pgerrcode.SerializationFailure}
+	unique := &pgconn.PgError{Code: pgerrcode.UniqueViolation}
+
+	tests := []struct {
+		name      string
+		errs      []error
+		wantCalls int
+		wantErr   error
+	}{
+		{"success", []error{nil}, 1, nil},
+		{"restarted", []error{restart, restart, nil}, 3, nil},
+		{"permanent", []error{restart, unique}, 2, unique},
+		{"max attempts", []error{restart, restart, restart, restart, restart}, 5, restart},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			err := new(DB).retryTx(context.Background(), func(context.Context) error {
+				calls++
+				return tt.errs[calls-1]
+			})
+			if err != tt.wantErr {
+				t.Errorf("DB.retryTx() err = %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("DB.retryTx() calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgtype"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+)
+
+// ExportDailyTotals reads count.daily_method_totals of service between the dates
+// of start and end inclusive, in pages of at most pageSize entries.
+// Pages are read with keyset pagination on day, path and method,
+// so no transaction or server side cursor is kept open between pages.
+// fn is called for each page, until there are no more entries
+// or fn returns an error.
+func (db *DB) ExportDailyTotals(ctx context.Context, service string, start, end time.Time, pageSize int, fn func(page []*countv1.MethodCount) error) error {
+	const errDesc = "export daily totals"
+
+	var (
+		cursorDay                = pgtype.Date{Status: pgtype.Null}
+		cursorPath, cursorMethod string
+	)
+
+	for {
+		rows, err := db.pool.Query(ctx, exportDailyTotalsSQL,
+			pgtype.Date{Time: start, Status: pgtype.Present},
+			pgtype.Date{Time: end, Status: pgtype.Present},
+			cursorDay, cursorPath, cursorMethod, pageSize, service,
+		)
+		if err = statusError(err, errDesc); err != nil {
+			return err
+		}
+
+		page, err := scanMethodCountRows(rows)
+		rows.Close()
+		if err = statusError(err, errDesc); err != nil {
+			return err
+		}
+		if len(page) == 0 {
+			return nil
+		}
+		if err = fn(page); err != nil {
+			return err
+		}
+		if len(page) < pageSize {
+			return nil
+		}
+
+		last := page[len(page)-1]
+		cursorDay = pgtype.Date{Time: datepb.Time(last.GetDate()), Status: pgtype.Present}
+		cursorPath, cursorMethod = last.GetPath(), last.GetMethod().String()
+	}
+}
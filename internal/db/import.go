@@ -0,0 +1,41 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+)
+
+// ImportDailyTotals upserts totals of service into count.daily_method_totals,
+// resolving the method IDs through count.methods.
+// Existing totals for the same day, service, method and path are incremented
+// or replaced, depending on mode. count.monthly_method_totals and
+// count.yearly_method_totals are updated with the difference.
+// All totals are imported in a single statement, so either all or none are imported.
+func (db *DB) ImportDailyTotals(ctx context.Context, service string, totals []*countv1.MethodCount, mode store.ImportMode) error {
+	const errDesc = "import daily totals"
+
+	var (
+		days    = make([]pgtype.Date, len(totals))
+		methods = make([]string, len(totals))
+		paths   = make([]string, len(totals))
+		counts  = make([]int64, len(totals))
+	)
+	for i, mc := range totals {
+		days[i] = pgtype.Date{Time: datepb.Time(mc.GetDate()), Status: pgtype.Present}
+		methods[i] = mc.GetMethod().String()
+		paths[i] = mc.GetPath()
+		counts[i] = mc.GetCount()
+	}
+
+	err := db.retryTx(ctx, func(ctx context.Context) error {
+		var imported int64
+		return db.pool.QueryRow(ctx, importDailyTotalsSQL,
+			days, methods, paths, counts, mode == store.Replace, service,
+		).Scan(&imported)
+	})
+	return statusError(err, errDesc)
+}
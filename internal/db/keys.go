@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// scanAPIKey scans a row of id, name, scopes, create_time, revoke_time and service.
+func scanAPIKey(row pgx.Row) (*countv1.ApiKey, error) {
+	var (
+		key                    countv1.ApiKey
+		scopes                 int64
+		createTime, revokeTime pgtype.Timestamptz
+		service                pgtype.Varchar
+	)
+	if err := row.Scan(&key.Id, &key.Name, &scopes, &createTime, &revokeTime, &service); err != nil {
+		return nil, err
+	}
+
+	key.Service = service.String
+	key.Scopes = store.MaskScopes(scopes)
+	if createTime.Status == pgtype.Present {
+		key.CreateTime = timestamppb.New(createTime.Time)
+	}
+	if revokeTime.Status == pgtype.Present {
+		key.RevokeTime = timestamppb.New(revokeTime.Time)
+	}
+	return &key, nil
+}
+
+// keyError is like statusError, but returns NotFound for pgx.ErrNoRows.
+func keyError(err error, desc string) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return status.Errorf(codes.NotFound, "%s: key not found", desc)
+	}
+	return statusError(err, desc)
+}
+
+// CreateAPIKey inserts an API key into count.api_keys.
+// AlreadyExists is returned when the hash already exists.
+func (db *DB) CreateAPIKey(ctx context.Context, name, service string, scopes []countv1.Scope, hash []byte) (*countv1.ApiKey, error) {
+	key, err := scanAPIKey(db.pool.QueryRow(ctx, createAPIKeySQL, name, hash, store.ScopeMask(scopes), service))
+	return key, keyError(err, "create api key")
+}
+
+// ListAPIKeys selects all API keys from count.api_keys, ordered by id.
+func (db *DB) ListAPIKeys(ctx context.Context) (keys []*countv1.ApiKey, err error) {
+	const errDesc = "list api keys"
+
+	rows, err := db.pool.Query(ctx, listAPIKeysSQL)
+	if err = statusError(err, errDesc); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, statusError(err, errDesc)
+		}
+		keys = append(keys, key)
+	}
+	return keys, statusError(rows.Err(), errDesc)
+}
+
+// RevokeAPIKey sets the revoke time of an API key in count.api_keys.
+// NotFound is returned when the key does not exist or is already revoked.
+func (db *DB) RevokeAPIKey(ctx context.Context, id int64) (*countv1.ApiKey, error) {
+	key, err := scanAPIKey(db.pool.QueryRow(ctx, revokeAPIKeySQL, id))
+	return key, keyError(err, "revoke api key")
+}
+
+// LookupAPIKey selects an API key which is not revoked by its hash from count.api_keys.
+// NotFound is returned when no such key exists.
+func (db *DB) LookupAPIKey(ctx context.Context, hash []byte) (*countv1.ApiKey, error) {
+	key, err := scanAPIKey(db.pool.QueryRow(ctx, lookupAPIKeySQL, hash))
+	return key, keyError(err, "lookup api key")
+}
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	"github.com/muhlemmer/count/internal/hll"
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+)
+
+// labeledPeriodTotalsQuery returns the period query of the labeled totals, see periodQuery.
+func labeledPeriodTotalsQuery(start, end time.Time) string {
+	return periodQuery(start, end, getLabeledPeriodTotalsSQL, getLabeledPeriodTotalsMonthlySQL, getLabeledPeriodTotalsYearlySQL)
+}
+
+// scanLabeledTotalRows scans Rows of date, method, path, total,
+// an array of clients sketches and an encoded label set.
+func scanLabeledTotalRows(rows pgx.Rows) (results []store.LabeledTotal, err error) {
+	for rows.Next() {
+		var (
+			date    pgtype.Date
+			method  pgtype.Varchar
+			path    pgtype.Varchar
+			total   pgtype.Int8
+			clients pgtype.ByteaArray
+			labels  pgtype.Varchar
+		)
+		if err = rows.Scan(&date, &method, &path, &total, &clients, &labels); err != nil {
+			return nil, err
+		}
+
+		t := store.LabeledTotal{
+			Method: countv1.Method(countv1.Method_value[method.String]),
+			Path:   path.String,
+			Count:  total.Int,
+		}
+		if t.Labels, err = store.DecodeLabels(labels.String); err != nil {
+			return nil, err
+		}
+		if t.Clients, err = mergedSketch[hll.Sketch]("clients", clients); err != nil {
+			return nil, err
+		}
+		if date.Status == pgtype.Present {
+			t.Date = datepb.Date(date.Time)
+		}
+
+		results = append(results, t)
+	}
+
+	return results, rows.Err()
+}
+
+// labeledQuery executes query with start, end, service and
+// the filter of q as arguments, on the primary or replica by
+// the freshness of ctx, see readQuery.
+// The totals are grouped by q, see store.LabelQuery.Apply.
+func (db *DB) labeledQuery(ctx context.Context, query, service string, start, end time.Time, q store.LabelQuery) ([]*countv1.MethodCount, error) {
+	var totals []store.LabeledTotal
+	err := db.readQuery(ctx, func(rows pgx.Rows) (err error) {
+		totals, err = scanLabeledTotalRows(rows)
+		return err
+	}, query,
+		pgtype.Date{
+			Time:   start,
+			Status: pgtype.Present,
+		},
+		pgtype.Date{
+			Time:   end,
+			Status: pgtype.Present,
+		},
+		service,
+		store.EncodeLabels(q.Filter),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return q.Apply(totals), nil
+}
+
+// ListLabeledTotals is like ListDailyTotals,
+// with the totals filtered and grouped by the labels of q.
+func (db *DB) ListLabeledTotals(ctx context.Context, service string, start, end time.Time, q store.LabelQuery) ([]*countv1.MethodCount, error) {
+	results, err := db.labeledQuery(ctx, listLabeledTotalsSQL, service, start, end, q)
+	return results, statusError(err, "list labeled totals")
+}
+
+// GetLabeledPeriodTotals is like GetPeriodTotals,
+// with the totals filtered and grouped by the labels of q.
+// The monthly or yearly totals are used when the interval
+// consists of whole months or years.
+func (db *DB) GetLabeledPeriodTotals(ctx context.Context, service string, start, end time.Time, q store.LabelQuery) ([]*countv1.MethodCount, error) {
+	results, err := db.labeledQuery(ctx, labeledPeriodTotalsQuery(start, end), service, start, end, q)
+	return results, statusError(err, "get labeled period totals")
+}
@@ -0,0 +1,31 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_labeledPeriodTotalsQuery(t *testing.T) {
+	tests := []struct {
+		start, end time.Time
+		want       string
+	}{
+		{
+			time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 11, 10, 0, 0, 0, 0, time.UTC),
+			getLabeledPeriodTotalsSQL,
+		},
+		{
+			time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 12, 1, 0, 0, 0, -1, time.UTC),
+			getLabeledPeriodTotalsMonthlySQL,
+		},
+		{
+			time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 1, 1, 0, 0, 0, -1, time.UTC),
+			getLabeledPeriodTotalsYearlySQL,
+		},
+	}
+	for _, tt := range tests {
+		if got := labeledPeriodTotalsQuery(tt.start, tt.end); got != tt.want {
+			t.Errorf("labeledPeriodTotalsQuery(%v, %v) =\n%s\nwant\n%s", tt.start, tt.end, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	"github.com/muhlemmer/count/internal/ddsketch"
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+)
+
+// latencyStatsQuery returns the period query of the latencies, see periodQuery.
+func latencyStatsQuery(start, end time.Time) string {
+	return periodQuery(start, end, getLatencyStatsSQL, getLatencyStatsMonthlySQL, getLatencyStatsYearlySQL)
+}
+
+// scanLatencyStatsRows scans Rows of method, path and an array of latencies sketches
+// into a slice of *countv1.LatencyStats, with the quantiles of the merged sketches.
+func scanLatencyStatsRows(rows pgx.Rows, quantiles []float64) (results []*countv1.LatencyStats, err error) {
+	for rows.Next() {
+		var (
+			method    pgtype.Varchar
+			path      pgtype.Varchar
+			latencies pgtype.ByteaArray
+		)
+		if err = rows.Scan(&method, &path, &latencies); err != nil {
+			return nil, err
+		}
+
+		merged, err := mergedSketch[ddsketch.Sketch]("latencies", latencies)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, store.NewLatencyStats(
+			countv1.Method(countv1.Method_value[method.String]), path.String, merged, quantiles,
+		))
+	}
+
+	return results, rows.Err()
+}
+
+// GetLatencyStats merges the latencies sketches of service in count.daily_method_totals,
+// grouped by method and path, and estimates the quantiles from the merged sketches.
+// Start and end times are inclusive.
+// Like GetPeriodTotals, the monthly or yearly totals are used
+// when the interval consists of whole months or years.
+// The replica is used, unless Strong freshness is set on ctx.
+func (db *DB) GetLatencyStats(ctx context.Context, service string, start, end time.Time, quantiles []float64) ([]*countv1.LatencyStats, error) {
+	var results []*countv1.LatencyStats
+	err := db.readQuery(ctx, func(rows pgx.Rows) (err error) {
+		results, err = scanLatencyStatsRows(rows, quantiles)
+		return err
+	}, latencyStatsQuery(start, end),
+		pgtype.Date{
+			Time:   start,
+			Status: pgtype.Present,
+		},
+		pgtype.Date{
+			Time:   end,
+			Status: pgtype.Present,
+		},
+		service,
+	)
+	return results, statusError(err, "get latency stats")
+}
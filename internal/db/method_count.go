@@ -3,30 +3,72 @@ package db
 import (
 	"github.com/jackc/pgtype"
 	"github.com/jackc/pgx/v4"
+	"github.com/muhlemmer/count/internal/store"
 	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
 	"github.com/muhlemmer/count/pkg/datepb"
 )
 
-// scanMethodCountRows scans Rows into a slice of *countv1.MethodCount.
+// scanMethodCountRows scans Rows of date, method, path and total
+// into a slice of *countv1.MethodCount.
+// Rows may have additional columns, by name:
+// a service column, which sets the service,
+// a clients column of sketches, which sets the distinct clients,
+// and a labels column of encoded label sets, which sets the labels.
 func scanMethodCountRows(rows pgx.Rows) (results []*countv1.MethodCount, err error) {
+	var withService, withClients, withLabels bool
+	for _, f := range rows.FieldDescriptions() {
+		switch string(f.Name) {
+		case "service":
+			withService = true
+		case "clients":
+			withClients = true
+		case "labels":
+			withLabels = true
+		}
+	}
+
 	for rows.Next() {
 		var (
-			date   pgtype.Date
-			method pgtype.Varchar
-			path   pgtype.Varchar
-			total  pgtype.Int8
+			date    pgtype.Date
+			method  pgtype.Varchar
+			path    pgtype.Varchar
+			total   pgtype.Int8
+			service pgtype.Varchar
+			clients pgtype.ByteaArray
+			labels  pgtype.Varchar
 		)
 
-		if err = rows.Scan(&date, &method, &path, &total); err != nil {
+		dest := []interface{}{&date, &method, &path, &total}
+		if withService {
+			dest = append(dest, &service)
+		}
+		if withClients {
+			dest = append(dest, &clients)
+		}
+		if withLabels {
+			dest = append(dest, &labels)
+		}
+		if err = rows.Scan(dest...); err != nil {
 			return nil, err
 		}
 
 		mc := &countv1.MethodCount{
-			Method: countv1.Method(countv1.Method_value[method.String]),
-			Path:   path.String,
-			Count:  total.Int,
+			Method:  countv1.Method(countv1.Method_value[method.String]),
+			Path:    path.String,
+			Count:   total.Int,
+			Service: service.String,
 		}
 
+		if withClients {
+			if mc.DistinctClients, err = distinctClients(clients); err != nil {
+				return nil, err
+			}
+		}
+		if labels.Status == pgtype.Present {
+			if mc.Labels, err = store.DecodeLabels(labels.String); err != nil {
+				return nil, err
+			}
+		}
 		if date.Status == pgtype.Present {
 			mc.Date = datepb.Date(date.Time)
 		}
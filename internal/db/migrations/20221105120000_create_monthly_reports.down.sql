@@ -0,0 +1 @@
+drop table if exists count.monthly_method_totals;
@@ -0,0 +1,7 @@
+create table count.monthly_method_totals(
+  month date not null,
+  method_id bigint not null references count.methods(id),
+  total bigint,
+
+  primary key(month, method_id)
+);
@@ -0,0 +1 @@
+drop table if exists count.yearly_method_totals;
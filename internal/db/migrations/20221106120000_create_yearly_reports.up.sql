@@ -0,0 +1,19 @@
+create table count.yearly_method_totals(
+  year date not null,
+  method_id bigint not null references count.methods(id),
+  total bigint,
+
+  primary key(year, method_id)
+);
+
+insert into count.monthly_method_totals (month, method_id, total)
+  select date_trunc('month', day::timestamp)::date, method_id, sum(total)::bigint
+  from count.daily_method_totals
+  group by date_trunc('month', day::timestamp)::date, method_id
+on conflict (month, method_id)
+do update set total = monthly_method_totals.total + excluded.total;
+
+insert into count.yearly_method_totals (year, method_id, total)
+  select date_trunc('year', month::timestamp)::date, method_id, sum(total)::bigint
+  from count.monthly_method_totals
+  group by date_trunc('year', month::timestamp)::date, method_id;
@@ -0,0 +1 @@
+drop table if exists count.api_keys;
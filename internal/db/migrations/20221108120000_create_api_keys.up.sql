@@ -0,0 +1,10 @@
+create table count.api_keys(
+  id bigserial primary key,
+  name varchar not null,
+  -- sha256 of the key, the key itself is not stored.
+  key_hash bytea not null unique,
+  -- bit mask of count.v1.Scope values.
+  scopes bigint not null,
+  create_time timestamptz not null default now(),
+  revoke_time timestamptz
+);
@@ -0,0 +1,12 @@
+-- Fails when the same method and path is used by multiple services.
+alter table count.api_keys
+  drop column if exists service;
+
+alter table count.methods
+  drop constraint if exists methods_service_method_path_key;
+
+alter table count.methods
+  add constraint methods_method_path_key unique (method, path);
+
+alter table count.methods
+  drop column if exists service;
@@ -0,0 +1,13 @@
+-- Existing methods are assigned to the default service.
+alter table count.methods
+  add column service varchar not null default 'default';
+
+alter table count.methods
+  drop constraint methods_method_path_key;
+
+alter table count.methods
+  add constraint methods_service_method_path_key unique (service, method, path);
+
+-- service the key is bound to, null for keys which can access all services.
+alter table count.api_keys
+  add column service varchar;
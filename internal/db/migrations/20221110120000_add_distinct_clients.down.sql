@@ -0,0 +1,11 @@
+alter table count.yearly_method_totals
+  drop column if exists clients;
+
+alter table count.monthly_method_totals
+  drop column if exists clients;
+
+alter table count.daily_method_totals
+  drop column if exists clients;
+
+alter table count.requests
+  drop column if exists client_hash;
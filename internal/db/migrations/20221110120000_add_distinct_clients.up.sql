@@ -0,0 +1,13 @@
+-- hash of the client ID of a request, see hll.Hash.
+alter table count.requests
+  add column client_hash bigint;
+
+-- HyperLogLog sketches of the client hashes, see hll.Sketch.
+alter table count.daily_method_totals
+  add column clients bytea;
+
+alter table count.monthly_method_totals
+  add column clients bytea;
+
+alter table count.yearly_method_totals
+  add column clients bytea;
@@ -0,0 +1,11 @@
+alter table count.yearly_method_totals
+  drop column if exists latencies;
+
+alter table count.monthly_method_totals
+  drop column if exists latencies;
+
+alter table count.daily_method_totals
+  drop column if exists latencies;
+
+alter table count.requests
+  drop column if exists duration_ns;
@@ -0,0 +1,13 @@
+-- duration of a request in nanoseconds.
+alter table count.requests
+  add column duration_ns bigint;
+
+-- DDSketches of the request durations, see ddsketch.Sketch.
+alter table count.daily_method_totals
+  add column latencies bytea;
+
+alter table count.monthly_method_totals
+  add column latencies bytea;
+
+alter table count.yearly_method_totals
+  add column latencies bytea;
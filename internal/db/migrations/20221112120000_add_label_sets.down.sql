@@ -0,0 +1,11 @@
+-- Fails when the same method and path is used with multiple label sets.
+alter table count.methods
+  drop constraint if exists methods_service_method_path_labels_key;
+
+alter table count.methods
+  add constraint methods_service_method_path_key unique (service, method, path);
+
+alter table count.methods
+  drop column if exists label_set_id;
+
+drop table if exists count.label_sets;
@@ -0,0 +1,18 @@
+-- label sets of requests, as JSON objects with sorted keys.
+-- The empty set has id 0, which is the default of existing methods.
+create table count.label_sets (
+  id bigserial primary key,
+  labels varchar not null unique
+);
+
+insert into count.label_sets (id, labels)
+  values (0, '{}');
+
+alter table count.methods
+  add column label_set_id bigint not null default 0 references count.label_sets(id);
+
+alter table count.methods
+  drop constraint methods_service_method_path_key;
+
+alter table count.methods
+  add constraint methods_service_method_path_labels_key unique (service, method, path, label_set_id);
@@ -0,0 +1,11 @@
+-- Entries of the daily partitions are moved to the default partition,
+-- which becomes count.requests again.
+alter table count.requests detach partition count.requests_default;
+
+insert into count.requests_default
+  select * from count.requests;
+
+drop table count.requests cascade;
+
+alter table count.requests_default rename to requests;
+alter index count.requests_default_date_idx rename to requests_date_idx;
@@ -0,0 +1,19 @@
+-- PostgreSQL only: count.requests becomes partitioned by day.
+-- Existing entries are kept in the default partition,
+-- which also receives entries for days without a partition.
+-- Daily partitions are created by the server.
+alter table count.requests rename to requests_default;
+alter index count.requests_date_idx rename to requests_default_date_idx;
+
+-- columns are copied from the existing table, so they follow its migrations.
+create table count.requests
+(like count.requests_default including all excluding indexes)
+partition by range (request_timestamp);
+
+alter table count.requests
+  add foreign key (method_id) references count.methods(id);
+
+alter table count.requests attach partition count.requests_default default;
+
+create index requests_date_idx
+on count.requests (request_timestamp);
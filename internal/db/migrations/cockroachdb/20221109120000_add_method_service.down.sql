@@ -0,0 +1,14 @@
+-- CockroachDB implements unique constraints as indexes,
+-- which can't be dropped with drop constraint.
+
+-- Fails when the same method and path is used by multiple services.
+alter table count.api_keys
+  drop column if exists service;
+
+drop index if exists count.methods@methods_service_method_path_key cascade;
+
+alter table count.methods
+  add constraint methods_method_path_key unique (method, path);
+
+alter table count.methods
+  drop column if exists service;
@@ -0,0 +1,15 @@
+-- CockroachDB implements unique constraints as indexes,
+-- which can't be dropped with drop constraint.
+
+-- Existing methods are assigned to the default service.
+alter table count.methods
+  add column service varchar not null default 'default';
+
+drop index count.methods@methods_method_path_key cascade;
+
+alter table count.methods
+  add constraint methods_service_method_path_key unique (service, method, path);
+
+-- service the key is bound to, null for keys which can access all services.
+alter table count.api_keys
+  add column service varchar;
@@ -0,0 +1,13 @@
+-- CockroachDB implements unique constraints as indexes,
+-- which can't be dropped with drop constraint.
+
+-- Fails when the same method and path is used with multiple label sets.
+drop index if exists count.methods@methods_service_method_path_labels_key cascade;
+
+alter table count.methods
+  add constraint methods_service_method_path_key unique (service, method, path);
+
+alter table count.methods
+  drop column if exists label_set_id;
+
+drop table if exists count.label_sets;
@@ -0,0 +1,20 @@
+-- CockroachDB implements unique constraints as indexes,
+-- which can't be dropped with drop constraint.
+
+-- label sets of requests, as JSON objects with sorted keys.
+-- The empty set has id 0, which is the default of existing methods.
+create table count.label_sets (
+  id bigserial primary key,
+  labels varchar not null unique
+);
+
+insert into count.label_sets (id, labels)
+  values (0, '{}');
+
+alter table count.methods
+  add column label_set_id bigint not null default 0 references count.label_sets(id);
+
+drop index count.methods@methods_service_method_path_key cascade;
+
+alter table count.methods
+  add constraint methods_service_method_path_labels_key unique (service, method, path, label_set_id);
@@ -2,7 +2,11 @@ package migrations
 
 import (
 	"embed"
+	"errors"
 	"fmt"
+	"io/fs"
+	"path"
+	"strings"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/source"
@@ -10,49 +14,194 @@ import (
 
 	_ "github.com/golang-migrate/migrate/v4/database/cockroachdb"
 	_ "github.com/golang-migrate/migrate/v4/database/pgx"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
 )
 
 var (
 	//go:embed *.sql
-	files           embed.FS
-	migrationSource source.Driver
+	files embed.FS
+
+	// SQLite has its own schema, without the count namespace
+	// and with portable column types.
+	//go:embed sqlite/*.sql
+	sqliteFiles embed.FS
+
+	// CockroachDB shares the PostgreSQL migrations,
+	// except for the files in the cockroachdb directory,
+	// which replace those with the same name.
+	//go:embed cockroachdb/*.sql
+	cockroachFiles embed.FS
 )
 
-func panicOnErr(err error) {
-	if err == migrate.ErrNoChange {
-		return
+// ErrVersionMismatch is returned by CheckVersion.
+var ErrVersionMismatch = errors.New("db/migrations: schema version mismatch")
+
+func wrapErr(err error) error {
+	if err == nil || errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return fmt.Errorf("db/migrations: %w", err)
+}
+
+// overlayFS opens files from dir in upper when they exist there,
+// and from lower otherwise. Directories are listed from lower only,
+// so upper can only replace existing files.
+type overlayFS struct {
+	upper fs.FS
+	dir   string
+	lower fs.FS
+}
+
+func (o overlayFS) Open(name string) (fs.File, error) {
+	f, err := o.upper.Open(path.Join(o.dir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return o.lower.Open(name)
+	}
+	return f, err
+}
+
+func (o overlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return fs.ReadDir(o.lower, name)
+}
+
+// cockroachSchemes are the schemes of the
+// golang-migrate cockroachdb driver.
+var cockroachSchemes = []string{"cockroachdb://", "cockroach://", "crdb-postgres://"}
+
+// sourceFor returns the migration files for the
+// database driver in the scheme of dsn.
+func sourceFor(dsn string) (fsys fs.FS, path string) {
+	if strings.HasPrefix(dsn, "sqlite://") {
+		return sqliteFiles, "sqlite"
+	}
+	for _, scheme := range cockroachSchemes {
+		if strings.HasPrefix(dsn, scheme) {
+			return overlayFS{upper: cockroachFiles, dir: "cockroachdb", lower: files}, "."
+		}
 	}
+	return files, "."
+}
+
+func newSource(dsn string) (source.Driver, error) {
+	return iofs.New(sourceFor(dsn))
+}
 
+// run m on a new migrate instance for dsn,
+// which is closed afterwards.
+func run(dsn string, f func(m *migrate.Migrate) error) (err error) {
+	src, err := newSource(dsn)
+	if err != nil {
+		return wrapErr(err)
+	}
+	m, err := migrate.NewWithSourceInstance("embed", src, dsn)
 	if err != nil {
-		panic(fmt.Errorf("db/migrations: %w", err))
+		return wrapErr(err)
 	}
+	defer func() {
+		srcErr, dbErr := m.Close()
+		if err == nil {
+			err = wrapErr(srcErr)
+		}
+		if err == nil {
+			err = wrapErr(dbErr)
+		}
+	}()
+
+	return wrapErr(f(m))
+}
+
+// Up applies all pending migrations.
+func Up(dsn string) error {
+	return run(dsn, func(m *migrate.Migrate) error {
+		return m.Up()
+	})
+}
+
+// Down reverts all migrations.
+func Down(dsn string) error {
+	return run(dsn, func(m *migrate.Migrate) error {
+		return m.Down()
+	})
 }
 
-func init() {
-	var err error
-	migrationSource, err = iofs.New(files, ".")
-	panicOnErr(err)
+// Goto migrates up or down to version.
+func Goto(dsn string, version uint) error {
+	return run(dsn, func(m *migrate.Migrate) error {
+		return m.Migrate(version)
+	})
 }
 
-/*
-func dbURL() string {
-	return strings.Replace(os.Getenv(DB_URL), "postgresql", "cockroachdb", 1)
+// Force sets the version, without running migrations, and clears the dirty state.
+// It is used to recover from a failed migration, after manual repair.
+// A version of -1 means no migrations are applied.
+func Force(dsn string, version int) error {
+	return run(dsn, func(m *migrate.Migrate) error {
+		return m.Force(version)
+	})
 }
-*/
 
-func Up(dsn string) {
-	m, err := migrate.NewWithSourceInstance("embed", migrationSource, dsn)
-	panicOnErr(err)
-	panicOnErr(m.Up())
+// Version returns the current version of the schema.
+// Version is zero when no migrations are applied.
+// Dirty is true when a migration failed.
+func Version(dsn string) (version uint, dirty bool, err error) {
+	err = run(dsn, func(m *migrate.Migrate) error {
+		version, dirty, err = m.Version()
+		if errors.Is(err, migrate.ErrNilVersion) {
+			return nil
+		}
+		return err
+	})
+	return version, dirty, err
+}
+
+// Versions returns all available migration versions
+// for the driver of dsn, in ascending order.
+func Versions(dsn string) (versions []uint, err error) {
+	src, err := newSource(dsn)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	defer src.Close()
+
+	version, err := src.First()
+	for err == nil {
+		versions = append(versions, version)
+		version, err = src.Next(version)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, wrapErr(err)
+	}
+
+	return versions, nil
 }
 
-func Down(dsn string) {
-	m, err := migrate.NewWithSourceInstance("embed", migrationSource, dsn)
-	panicOnErr(err)
+// Latest returns the version the binary expects
+// for the driver of dsn, which is the last available migration.
+func Latest(dsn string) (uint, error) {
+	versions, err := Versions(dsn)
+	if err != nil || len(versions) == 0 {
+		return 0, err
+	}
+	return versions[len(versions)-1], nil
+}
 
-	if err = m.Down(); err == migrate.ErrNoChange {
-		return
+// CheckVersion returns ErrVersionMismatch when the schema
+// is dirty or not at the latest version.
+func CheckVersion(dsn string) error {
+	latest, err := Latest(dsn)
+	if err != nil {
+		return err
+	}
+	version, dirty, err := Version(dsn)
+	if err != nil {
+		return err
 	}
 
-	panicOnErr(err)
+	if dirty {
+		return fmt.Errorf("%w: version %d is dirty", ErrVersionMismatch, version)
+	}
+	if version != latest {
+		return fmt.Errorf("%w: version %d, want %d", ErrVersionMismatch, version, latest)
+	}
+	return nil
 }
@@ -2,41 +2,44 @@ package migrations
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
 )
 
-func Test_panicOnErr(t *testing.T) {
-	type args struct {
-		err error
-	}
+func Test_wrapErr(t *testing.T) {
 	tests := []struct {
 		name    string
-		args    args
+		err     error
 		wantErr bool
 	}{
 		{
 			name:    "nil",
-			args:    args{nil},
+			err:     nil,
+			wantErr: false,
+		},
+		{
+			name:    "no change",
+			err:     fmt.Errorf("foo: %w", migrate.ErrNoChange),
 			wantErr: false,
 		},
 		{
 			name:    "error",
-			args:    args{errors.New("foo")},
+			err:     errors.New("foo"),
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			defer func() {
-				err, _ := recover().(error)
-				if tt.wantErr != (err != nil) {
-					t.Errorf("panicOnErr err = %v, wantErr %v", err, tt.wantErr)
-				}
-			}()
-
-			panicOnErr(tt.args.err)
+			if err := wrapErr(tt.err); (err != nil) != tt.wantErr {
+				t.Errorf("wrapErr() err = %v, wantErr %v", err, tt.wantErr)
+			}
 		})
 	}
 }
@@ -61,7 +64,144 @@ func TestDownUp(t *testing.T) {
 
 	dsn = strings.Replace(dsn, "postgresql", migrDriver, 1)
 
-	Down(dsn)
-	Up(dsn)
-	Down(dsn)
+	for _, f := range []func(string) error{Down, Up, CheckVersion, Down} {
+		if err := f(dsn); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestVersions(t *testing.T) {
+	got, err := Versions("sqlite://count.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []uint{20221107120000, 20221108120000, 20221109120000}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Versions() = %v, want %v", got, want)
+	}
+
+	got, err = Versions("pgx://")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) < 2 || got[0] != 20221007154326 {
+		t.Errorf("Versions() = %v", got)
+	}
+}
+
+func Test_sourceFor_cockroachdb(t *testing.T) {
+	pgVersions, err := Versions("pgx://")
+	if err != nil {
+		t.Fatal(err)
+	}
+	crdbVersions, err := Versions("cockroachdb://")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(crdbVersions, pgVersions) {
+		t.Errorf("Versions() = %v, want %v", crdbVersions, pgVersions)
+	}
+
+	readUp := func(dsn string, version uint) string {
+		t.Helper()
+		src, err := newSource(dsn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer src.Close()
+
+		r, _, err := src.ReadUp(version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer r.Close()
+
+		b, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+
+	tests := []struct {
+		dsn     string
+		version uint
+		want    string
+	}{
+		{"pgx://", 20221109120000, "drop constraint methods_method_path_key"},
+		{"cockroachdb://", 20221109120000, "drop index count.methods@methods_method_path_key cascade"},
+		{"cockroachdb://", 20221007154326, "create schema"},
+		{"pgx://", 20221112120000, "drop constraint methods_service_method_path_key"},
+		{"cockroachdb://", 20221112120000, "drop index count.methods@methods_service_method_path_key cascade"},
+		{"pgx://", 20221113120000, "partition by range (request_timestamp)"},
+		{"cockroachdb://", 20221113120000, "CockroachDB handles partitioning differently"},
+	}
+	for _, tt := range tests {
+		if got := readUp(tt.dsn, tt.version); !strings.Contains(got, tt.want) {
+			t.Errorf("%s %d up = %q, want %q", tt.dsn, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestMigrations_sqlite(t *testing.T) {
+	dsn := "sqlite://" + filepath.Join(t.TempDir(), "count.db")
+
+	assertVersion := func(wantVersion uint, wantDirty bool) {
+		t.Helper()
+		version, dirty, err := Version(dsn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if version != wantVersion || dirty != wantDirty {
+			t.Errorf("Version() = %d, %t, want %d, %t", version, dirty, wantVersion, wantDirty)
+		}
+	}
+
+	assertVersion(0, false)
+	if err := CheckVersion(dsn); !errors.Is(err, ErrVersionMismatch) {
+		t.Errorf("CheckVersion() err = %v, want %v", err, ErrVersionMismatch)
+	}
+
+	if err := Up(dsn); err != nil {
+		t.Fatal(err)
+	}
+	assertVersion(20221109120000, false)
+	if err := CheckVersion(dsn); err != nil {
+		t.Error(err)
+	}
+
+	// no change
+	if err := Goto(dsn, 20221109120000); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Goto(dsn, 20221107120000); err != nil {
+		t.Fatal(err)
+	}
+	assertVersion(20221107120000, false)
+
+	if err := Force(dsn, -1); err != nil {
+		t.Fatal(err)
+	}
+	assertVersion(0, false)
+
+	// tables already exist
+	if err := Up(dsn); err == nil {
+		t.Fatal("Up() expected error")
+	}
+	assertVersion(20221107120000, true)
+	if err := CheckVersion(dsn); !errors.Is(err, ErrVersionMismatch) {
+		t.Errorf("CheckVersion() err = %v, want %v", err, ErrVersionMismatch)
+	}
+
+	if err := Force(dsn, 20221107120000); err != nil {
+		t.Fatal(err)
+	}
+	if err := Down(dsn); err != nil {
+		t.Fatal(err)
+	}
+	if err := Up(dsn); err != nil {
+		t.Fatal(err)
+	}
+	assertVersion(20221109120000, false)
 }
@@ -0,0 +1,3 @@
+drop table daily_method_totals;
+drop table requests;
+drop table methods;
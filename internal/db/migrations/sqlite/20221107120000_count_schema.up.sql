@@ -0,0 +1,25 @@
+create table methods(
+  id integer primary key,
+  method text not null,
+  path text not null,
+
+  unique(method, path)
+);
+
+-- request_timestamp is stored as unix nanoseconds.
+create table requests(
+  method_id integer not null references methods(id),
+  request_timestamp integer not null
+);
+
+create index requests_date_idx
+on requests (request_timestamp);
+
+-- day is stored as YYYY-MM-DD.
+create table daily_method_totals(
+  day text not null,
+  method_id integer not null references methods(id),
+  total integer not null,
+
+  primary key(day, method_id)
+);
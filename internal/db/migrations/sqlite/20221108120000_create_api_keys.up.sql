@@ -0,0 +1,11 @@
+-- key_hash is the sha256 of the key, the key itself is not stored.
+-- scopes is a bit mask of count.v1.Scope values.
+-- Times are stored as unix nanoseconds.
+create table api_keys(
+  id integer primary key,
+  name text not null,
+  key_hash blob not null unique,
+  scopes integer not null,
+  create_time integer not null,
+  revoke_time integer
+);
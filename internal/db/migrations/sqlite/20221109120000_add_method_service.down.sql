@@ -0,0 +1,17 @@
+-- Fails when the same method and path is used by multiple services.
+alter table api_keys drop column service;
+
+create table methods_path(
+  id integer primary key,
+  method text not null,
+  path text not null,
+
+  unique(method, path)
+);
+
+insert into methods_path (id, method, path)
+  select id, method, path
+  from methods;
+
+drop table methods;
+alter table methods_path rename to methods;
@@ -0,0 +1,21 @@
+-- SQLite can not alter constraints, so methods is recreated
+-- with service as part of the unique key.
+-- Existing methods are assigned to the default service.
+create table methods_service(
+  id integer primary key,
+  service text not null default 'default',
+  method text not null,
+  path text not null,
+
+  unique(service, method, path)
+);
+
+insert into methods_service (id, method, path)
+  select id, method, path
+  from methods;
+
+drop table methods;
+alter table methods_service rename to methods;
+
+-- service the key is bound to, null for keys which can access all services.
+alter table api_keys add column service text;
@@ -0,0 +1,128 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/rs/zerolog"
+)
+
+// partitionName returns the name of the count.requests partition
+// for the day starting at start.
+func partitionName(start time.Time) string {
+	return "requests_p" + start.Format("20060102")
+}
+
+// partitionBounds returns the start of day of ts in loc
+// and the start of the next day.
+func partitionBounds(ts time.Time, loc *time.Location) (from, to time.Time) {
+	ts = ts.In(loc)
+	from = time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, loc)
+	return from, from.AddDate(0, 0, 1)
+}
+
+func (db *DB) requestsPartitionExists(ctx context.Context, name string) (exists bool, err error) {
+	err = db.pool.QueryRow(ctx, requestsPartitionExistsSQL, name).Scan(&exists)
+	return exists, err
+}
+
+// PartitionRequests creates partitions of count.requests for today and
+// the following days in loc. count.requests is partitioned by the migrations,
+// with a default partition which receives entries for days without a partition.
+// Partitions for days of which entries are already in the default partition
+// can't be created and are skipped.
+// The amount of created partitions is returned.
+//
+// Partitioning is only supported on PostgreSQL.
+// On other dialects this is a no-op and count.requests
+// is pruned with row deletion instead.
+func (db *DB) PartitionRequests(ctx context.Context, loc *time.Location, days int) (created int, err error) {
+	const errDesc = "partition requests"
+
+	dialect, err := db.Dialect(ctx)
+	if err != nil || dialect != PostgreSQL {
+		return 0, err
+	}
+
+	from, to := partitionBounds(time.Now(), loc)
+
+	for i := 0; i <= days; i++ {
+		name := partitionName(from)
+
+		exists, err := db.requestsPartitionExists(ctx, name)
+		if err != nil {
+			return created, statusError(err, errDesc)
+		}
+		if !exists {
+			_, err = db.pool.Exec(ctx, fmt.Sprintf(createRequestsPartitionSQL,
+				name, from.Format(time.RFC3339), to.Format(time.RFC3339),
+			))
+
+			pge := new(pgconn.PgError)
+			if errors.As(err, &pge) && pge.Code == pgerrcode.CheckViolation {
+				zerolog.Ctx(ctx).Warn().Err(err).Str("partition", name).Msg("entries in default partition, skipped")
+			} else if err != nil {
+				return created, statusError(err, errDesc)
+			} else {
+				created++
+			}
+		}
+
+		from, to = to, to.AddDate(0, 0, 1)
+	}
+
+	return created, nil
+}
+
+// countRequestsPartition counts the entries of a partition into
+// the daily, monthly and yearly totals and drops the partition afterwards.
+// The partition is locked during the transaction,
+// so that no entries can be inserted after counting.
+func (db *DB) countRequestsPartition(ctx context.Context, name string, start, end time.Time) (results []*countv1.MethodCount, err error) {
+	err = db.pool.BeginTxFunc(ctx, pgx.TxOptions{}, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, fmt.Sprintf(lockRequestsPartitionSQL, name)); err != nil {
+			return err
+		}
+
+		sketches, err := requestSketches(ctx, tx, start, end)
+		if err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, countDailyMethodTotalsPartitionSQL,
+			pgtype.Timestamptz{
+				Time:   start,
+				Status: pgtype.Present,
+			},
+			pgtype.Timestamptz{
+				Time:   end,
+				Status: pgtype.Present,
+			},
+			start.Location().String(),
+		)
+		if err != nil {
+			return err
+		}
+
+		results, err = scanMethodCountRows(rows)
+		rows.Close()
+		if err != nil {
+			return err
+		}
+		if err = mergeSketches(ctx, tx, sketches); err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, fmt.Sprintf(dropRequestsPartitionSQL, name))
+		return err
+	})
+
+	return results, err
+}
@@ -0,0 +1,118 @@
+package db
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+)
+
+func Test_partitionBounds(t *testing.T) {
+	zurich, err := time.LoadLocation("Europe/Zurich")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		ts       time.Time
+		wantFrom time.Time
+		wantTo   time.Time
+		wantName string
+	}{
+		{
+			name:     "regular day",
+			ts:       time.Date(2022, time.October, 16, 12, 0, 0, 0, time.UTC),
+			wantFrom: time.Date(2022, time.October, 15, 22, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2022, time.October, 16, 22, 0, 0, 0, time.UTC),
+			wantName: "requests_p20221016",
+		},
+		{
+			name:     "next day in zurich",
+			ts:       time.Date(2022, time.October, 16, 23, 0, 0, 0, time.UTC),
+			wantFrom: time.Date(2022, time.October, 16, 22, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2022, time.October, 17, 22, 0, 0, 0, time.UTC),
+			wantName: "requests_p20221017",
+		},
+		{
+			name:     "end of summer time",
+			ts:       time.Date(2022, time.October, 30, 12, 0, 0, 0, time.UTC),
+			wantFrom: time.Date(2022, time.October, 29, 22, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2022, time.October, 30, 23, 0, 0, 0, time.UTC),
+			wantName: "requests_p20221030",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotFrom, gotTo := partitionBounds(tt.ts, zurich)
+			if !gotFrom.Equal(tt.wantFrom) {
+				t.Errorf("partitionBounds() gotFrom = %v, want %v", gotFrom, tt.wantFrom)
+			}
+			if !gotTo.Equal(tt.wantTo) {
+				t.Errorf("partitionBounds() gotTo = %v, want %v", gotTo, tt.wantTo)
+			}
+			if got := partitionName(gotFrom); got != tt.wantName {
+				t.Errorf("partitionName() = %s, want %s", got, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestDB_PartitionRequests(t *testing.T) {
+	dialect, err := testDB.Dialect(R.CTX)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = testDB.PartitionRequests(R.CTX, time.UTC, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	// count.requests is partitioned by the migrations
+	var partitioned bool
+	err = R.Pool.QueryRow(R.CTX, `select c.relkind = 'p'
+		from pg_class as c
+		join pg_namespace as n on n.oid = c.relnamespace
+		where n.nspname = 'count'
+		and c.relname = 'requests'`,
+	).Scan(&partitioned)
+	if err != nil && dialect == PostgreSQL {
+		t.Fatal(err)
+	}
+	if partitioned != (dialect == PostgreSQL) {
+		t.Fatalf("requests partitioned = %t on %s", partitioned, dialect)
+	}
+	if dialect != PostgreSQL {
+		return
+	}
+
+	// idempotent
+	if _, err = testDB.PartitionRequests(R.CTX, time.UTC, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	start, end := partitionBounds(time.Now().AddDate(0, 0, 1), time.UTC)
+	name := partitionName(start)
+
+	if err = testDB.InsertMethodRequest(R.CTX, store.DefaultService, countv1.Method_GET, "/partition", start.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := testDB.CountDailyMethodTotals(R.CTX, start, end.Add(-1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].GetPath() != "/partition" || got[0].GetCount() != 1 || got[0].GetService() != store.DefaultService {
+		t.Errorf("DB.CountDailyMethodTotals() = %v", got)
+	}
+
+	exists, err := testDB.requestsPartitionExists(R.CTX, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exists {
+		t.Errorf("partition %s not dropped", name)
+	}
+}
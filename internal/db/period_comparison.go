@@ -0,0 +1,52 @@
+package db
+
+import (
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+)
+
+func periodPresence(inPeriod, inReference bool) countv1.PeriodPresence {
+	switch {
+	case inPeriod && inReference:
+		return countv1.PeriodPresence_PERIOD_PRESENCE_BOTH
+	case inPeriod:
+		return countv1.PeriodPresence_PERIOD_PRESENCE_PERIOD_ONLY
+	case inReference:
+		return countv1.PeriodPresence_PERIOD_PRESENCE_REFERENCE_ONLY
+	default:
+		return countv1.PeriodPresence_PERIOD_PRESENCE_UNSPECIFIED
+	}
+}
+
+// scanPeriodComparisonRows scans Rows into a slice of *countv1.PeriodComparison.
+func scanPeriodComparisonRows(rows pgx.Rows) (results []*countv1.PeriodComparison, err error) {
+	for rows.Next() {
+		var (
+			method         pgtype.Varchar
+			path           pgtype.Varchar
+			total          pgtype.Int8
+			referenceTotal pgtype.Int8
+			delta          pgtype.Int8
+			relative       pgtype.Float8
+			inPeriod       pgtype.Bool
+			inReference    pgtype.Bool
+		)
+
+		if err = rows.Scan(&method, &path, &total, &referenceTotal, &delta, &relative, &inPeriod, &inReference); err != nil {
+			return nil, err
+		}
+
+		results = append(results, &countv1.PeriodComparison{
+			Method:         countv1.Method(countv1.Method_value[method.String]),
+			Path:           path.String,
+			Count:          total.Int,
+			ReferenceCount: referenceTotal.Int,
+			Delta:          delta.Int,
+			RelativeChange: relative.Float,
+			Presence:       periodPresence(inPeriod.Bool, inReference.Bool),
+		})
+	}
+
+	return results, rows.Err()
+}
@@ -11,4 +11,64 @@ var (
 	listDailyTotalsSQL string
 	//go:embed queries/get_period_totals.sql
 	getPeriodTotalsSQL string
+	//go:embed queries/get_period_totals_monthly.sql
+	getPeriodTotalsMonthlySQL string
+	//go:embed queries/get_period_totals_yearly.sql
+	getPeriodTotalsYearlySQL string
+	//go:embed queries/compare_periods.sql
+	comparePeriodsSQL string
+	//go:embed queries/get_time_series.sql
+	getTimeSeriesSQL string
+	//go:embed queries/prune_requests.sql
+	pruneRequestsSQL string
+	//go:embed queries/prune_daily_totals.sql
+	pruneDailyTotalsSQL string
+	//go:embed queries/export_daily_totals.sql
+	exportDailyTotalsSQL string
+	//go:embed queries/import_daily_totals.sql
+	importDailyTotalsSQL string
+	//go:embed queries/create_api_key.sql
+	createAPIKeySQL string
+	//go:embed queries/list_api_keys.sql
+	listAPIKeysSQL string
+	//go:embed queries/revoke_api_key.sql
+	revokeAPIKeySQL string
+	//go:embed queries/lookup_api_key.sql
+	lookupAPIKeySQL string
+	//go:embed queries/version.sql
+	versionSQL string
+	//go:embed queries/follower_read.sql
+	followerReadSQL string
+	//go:embed queries/requests_partition_exists.sql
+	requestsPartitionExistsSQL string
+	//go:embed queries/create_requests_partition.sql
+	createRequestsPartitionSQL string
+	//go:embed queries/lock_requests_partition.sql
+	lockRequestsPartitionSQL string
+	//go:embed queries/drop_requests_partition.sql
+	dropRequestsPartitionSQL string
+	//go:embed queries/count_daily_method_totals_partition.sql
+	countDailyMethodTotalsPartitionSQL string
+	//go:embed queries/request_sketches.sql
+	requestSketchesSQL string
+	//go:embed queries/select_sketches.sql
+	selectSketchesSQL string
+	//go:embed queries/update_sketches.sql
+	updateSketchesSQL string
+	//go:embed queries/get_latency_stats.sql
+	getLatencyStatsSQL string
+	//go:embed queries/get_latency_stats_monthly.sql
+	getLatencyStatsMonthlySQL string
+	//go:embed queries/get_latency_stats_yearly.sql
+	getLatencyStatsYearlySQL string
+	//go:embed queries/insert_labeled_request.sql
+	insertLabeledRequestSQL string
+	//go:embed queries/list_labeled_totals.sql
+	listLabeledTotalsSQL string
+	//go:embed queries/get_labeled_period_totals.sql
+	getLabeledPeriodTotalsSQL string
+	//go:embed queries/get_labeled_period_totals_monthly.sql
+	getLabeledPeriodTotalsMonthlySQL string
+	//go:embed queries/get_labeled_period_totals_yearly.sql
+	getLabeledPeriodTotalsYearlySQL string
 )
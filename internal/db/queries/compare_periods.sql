@@ -0,0 +1,34 @@
+with period as (
+    select method, path, sum(total)::bigint as total
+    from count.daily_method_totals as dmt
+    join count.methods as m on m.id = dmt.method_id
+    where day
+        between $1::date
+        and $2::date
+    and service = $5
+    group by method, path
+), reference as (
+    select method, path, sum(total)::bigint as total
+    from count.daily_method_totals as dmt
+    join count.methods as m on m.id = dmt.method_id
+    where day
+        between $3::date
+        and $4::date
+    and service = $5
+    group by method, path
+)
+select coalesce(p.method, r.method) as method,
+    coalesce(p.path, r.path) as path,
+    coalesce(p.total, 0)::bigint,
+    coalesce(r.total, 0)::bigint,
+    (coalesce(p.total, 0) - coalesce(r.total, 0))::bigint,
+    case when coalesce(r.total, 0) = 0 then 0::float8
+        else (coalesce(p.total, 0) - r.total)::float8 / r.total::float8
+    end,
+    p.method is not null,
+    r.method is not null
+from period as p
+full outer join reference as r
+on r.method = p.method
+and r.path = p.path
+order by 2, 1;
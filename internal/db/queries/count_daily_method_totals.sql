@@ -4,15 +4,38 @@ with deleted as (
         between $1
         and $2
     returning request_timestamp, method_id
+), counted as (
+    select (request_timestamp at time zone $3::text)::date as day, method_id, count(*) as total
+    from deleted
+    group by (request_timestamp at time zone $3::text)::date, method_id
 ), inserted as (
     insert into count.daily_method_totals (day, method_id, total)
-        select request_timestamp::date, method_id, count(*)
-        from deleted
-        group by request_timestamp::date, method_id
+        select day, method_id, total
+        from counted
+    on conflict (day, method_id)
+    do update set total = daily_method_totals.total + excluded.total
     returning day, method_id, total
+), monthly as (
+    insert into count.monthly_method_totals (month, method_id, total)
+        select date_trunc('month', day::timestamp)::date, method_id, sum(total)::bigint
+        from counted
+        group by date_trunc('month', day::timestamp)::date, method_id
+    on conflict (month, method_id)
+    do update set total = monthly_method_totals.total + excluded.total
+    returning month
+), yearly as (
+    insert into count.yearly_method_totals (year, method_id, total)
+        select date_trunc('year', day::timestamp)::date, method_id, sum(total)::bigint
+        from counted
+        group by date_trunc('year', day::timestamp)::date, method_id
+    on conflict (year, method_id)
+    do update set total = yearly_method_totals.total + excluded.total
+    returning year
 )
-select day, method, path, total
+select day, method, path, total, service, labels
 from inserted
 left join count.methods
 on methods.id = inserted.method_id
-order by day, path, method;
+left join count.label_sets
+on label_sets.id = methods.label_set_id
+order by day, service, path, method, labels;
@@ -0,0 +1,3 @@
+insert into count.api_keys (name, key_hash, scopes, service)
+    values ($1, $2, $3, nullif($4, ''))
+    returning id, name, scopes, create_time, revoke_time, service;
@@ -0,0 +1,3 @@
+create table if not exists count.%[1]s
+partition of count.requests
+for values from ('%[2]s') to ('%[3]s');
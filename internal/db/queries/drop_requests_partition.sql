@@ -0,0 +1,2 @@
+alter table count.requests detach partition count.%[1]s;
+drop table count.%[1]s;
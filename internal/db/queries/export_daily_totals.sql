@@ -0,0 +1,14 @@
+select day, method, path, sum(total)::bigint
+from count.daily_method_totals as dmt
+join count.methods as m on m.id = dmt.method_id
+where day
+    between $1::date
+    and $2::date
+and service = $7
+and (
+    $3::date is null
+    or (day, path, method) > ($3::date, $4::varchar, $5::varchar)
+)
+group by day, method, path
+order by day, path, method
+limit $6;
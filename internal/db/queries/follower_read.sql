@@ -0,0 +1 @@
+set transaction as of system time follower_read_timestamp();
@@ -0,0 +1,13 @@
+select null, method, path, sum(total)::bigint,
+    array_agg(clients) filter (where clients is not null) as clients,
+    labels
+from count.yearly_method_totals as ymt
+join count.methods as m on m.id = ymt.method_id
+join count.label_sets as ls on ls.id = m.label_set_id
+where year
+    between $1::date
+    and $2::date
+and service = $3
+and labels::jsonb @> $4::jsonb
+group by method, path, labels
+order by path, method, labels;
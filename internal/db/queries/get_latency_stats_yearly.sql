@@ -0,0 +1,10 @@
+select method, path, array_agg(latencies)
+from count.yearly_method_totals as ymt
+join count.methods as m on m.id = ymt.method_id
+where year
+    between $1::date
+    and $2::date
+and service = $3
+and latencies is not null
+group by method, path
+order by path, method;
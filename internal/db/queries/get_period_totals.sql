@@ -1,8 +1,10 @@
-select null, method, path, sum(total)::bigint
+select null, method, path, sum(total)::bigint,
+    array_agg(clients) filter (where clients is not null) as clients
 from count.daily_method_totals as dmt
 join count.methods as m on m.id = dmt.method_id
 where day
     between $1::date
     and $2::date
+and service = $3
 group by method, path
 order by path, method;
@@ -0,0 +1,10 @@
+select null, method, path, sum(total)::bigint,
+    array_agg(clients) filter (where clients is not null) as clients
+from count.monthly_method_totals as mmt
+join count.methods as m on m.id = mmt.method_id
+where month
+    between $1::date
+    and $2::date
+and service = $3
+group by method, path
+order by path, method;
@@ -0,0 +1,10 @@
+select null, method, path, sum(total)::bigint,
+    array_agg(clients) filter (where clients is not null) as clients
+from count.yearly_method_totals as ymt
+join count.methods as m on m.id = ymt.method_id
+where year
+    between $1::date
+    and $2::date
+and service = $3
+group by method, path
+order by path, method;
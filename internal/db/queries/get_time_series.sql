@@ -0,0 +1,13 @@
+select date_trunc($3::text, day::timestamp)::date as bucket, method, path, sum(total)::bigint
+from count.daily_method_totals as dmt
+join count.methods as m on m.id = dmt.method_id
+where day
+    between $1::date
+    and $2::date
+and service = $5
+and (
+    array_length($4::varchar[], 1) is null
+    or path = any($4::varchar[])
+)
+group by bucket, method, path
+order by bucket, path, method;
@@ -0,0 +1,62 @@
+with input as (
+    select day, method, path, total
+    from unnest($1::date[], $2::varchar[], $3::varchar[], $4::bigint[])
+        as input(day, method, path, total)
+), new_methods as (
+    insert into count.methods (service, method, path)
+        select distinct $6::varchar, method, path
+        from input
+    on conflict (service, method, path, label_set_id) do nothing
+    returning id, method, path
+), input_methods as (
+    select id, method, path
+    from new_methods
+    union all
+    select id, method, path
+    from count.methods
+    where service = $6
+    and label_set_id = 0
+    and (method, path) in (select method, path from input)
+), totals as (
+    select day, id as method_id, total
+    from input
+    join input_methods
+    using (method, path)
+), changes as (
+    -- the amount each total changes, for the monthly and yearly totals.
+    -- When replacing, the existing total is subtracted.
+    select totals.day, totals.method_id,
+        totals.total - case when $5::bool then coalesce(existing.total, 0) else 0 end as total
+    from totals
+    left join count.daily_method_totals as existing
+    on existing.day = totals.day
+    and existing.method_id = totals.method_id
+), daily as (
+    insert into count.daily_method_totals (day, method_id, total)
+        select day, method_id, total
+        from totals
+    on conflict (day, method_id)
+    do update set total = case
+        when $5::bool then excluded.total
+        else daily_method_totals.total + excluded.total
+    end
+    returning day
+), monthly as (
+    insert into count.monthly_method_totals (month, method_id, total)
+        select date_trunc('month', day::timestamp)::date, method_id, sum(total)::bigint
+        from changes
+        group by date_trunc('month', day::timestamp)::date, method_id
+    on conflict (month, method_id)
+    do update set total = monthly_method_totals.total + excluded.total
+    returning month
+), yearly as (
+    insert into count.yearly_method_totals (year, method_id, total)
+        select date_trunc('year', day::timestamp)::date, method_id, sum(total)::bigint
+        from changes
+        group by date_trunc('year', day::timestamp)::date, method_id
+    on conflict (year, method_id)
+    do update set total = yearly_method_totals.total + excluded.total
+    returning year
+)
+select count(*)
+from daily;
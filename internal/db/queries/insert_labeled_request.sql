@@ -0,0 +1,32 @@
+with new_label_set as (
+    insert into count.label_sets (labels)
+        values ($7::varchar)
+        on conflict (labels) do nothing
+        returning id
+), label_set as (
+    select id
+        from new_label_set
+    union all
+    select id
+        from count.label_sets
+        where labels = $7::varchar
+        and not exists (select 1 from new_label_set)
+), result as (
+    insert into count.methods (service, method, path, label_set_id)
+        select $4::varchar, $1::varchar, $2::varchar, id
+        from label_set
+        on conflict (service, method, path, label_set_id) do nothing
+        returning id
+)
+insert into count.requests (method_id, request_timestamp, client_hash, duration_ns)
+    select id, $3::timestamptz, $5::int8, $6::int8
+        from result
+        where exists (select 1 from result)
+    union all
+    select m.id, $3::timestamptz, $5::int8, $6::int8
+        from count.methods as m
+        join label_set as ls on ls.id = m.label_set_id
+        where service = $4
+        and method = $1
+        and path = $2
+        and not exists (select 1 from result);
@@ -1,16 +1,18 @@
 with result as (
-    insert into count.methods (method, path)
-        values ($1, $2)
-        on conflict (method, path) do nothing
+    insert into count.methods (service, method, path)
+        values ($4, $1, $2)
+        on conflict (service, method, path, label_set_id) do nothing
         returning id
 )
-insert into count.requests (method_id, request_timestamp)
-    select id, $3::timestamptz
+insert into count.requests (method_id, request_timestamp, client_hash, duration_ns)
+    select id, $3::timestamptz, $5::int8, $6::int8
         from result
         where exists (select 1 from result)
     union all
-    select id, $3::timestamptz
+    select id, $3::timestamptz, $5::int8, $6::int8
         from count.methods
-        where method = $1
+        where service = $4
+        and method = $1
         and path = $2
+        and label_set_id = 0
         and not exists (select 1 from result);
@@ -1,7 +1,9 @@
-select day, method, path, total
+select day, method, path, sum(total)::bigint
 from count.daily_method_totals as dmt
 join count.methods as m on m.id = dmt.method_id
 where day
     between $1::date
     and $2::date
+and service = $3
+group by day, method, path
 order by day, path, method;
@@ -0,0 +1 @@
+lock table count.%s in access exclusive mode;
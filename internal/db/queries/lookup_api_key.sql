@@ -0,0 +1,4 @@
+select id, name, scopes, create_time, revoke_time, service
+from count.api_keys
+where key_hash = $1
+and revoke_time is null;
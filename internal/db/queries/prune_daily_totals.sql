@@ -0,0 +1,60 @@
+with deleted as (
+    delete from count.daily_method_totals
+    where (day, method_id) in (
+        select day, method_id
+        from count.daily_method_totals
+        where day < $1::date
+        order by day, method_id
+        limit $2
+    )
+    returning day, method_id, total
+), monthly_pruned as (
+    select date_trunc('month', day::timestamp)::date as month, method_id, sum(total)::bigint as total
+    from deleted
+    where not $3::bool
+    group by date_trunc('month', day::timestamp)::date, method_id
+), monthly_emptied as (
+    delete from count.monthly_method_totals as mmt
+    using monthly_pruned as d
+    where mmt.month = d.month
+    and mmt.method_id = d.method_id
+    and mmt.total <= d.total
+    returning mmt.month
+), monthly as (
+    update count.monthly_method_totals as mmt
+    set total = mmt.total - d.total
+    from monthly_pruned as d
+    where mmt.month = d.month
+    and mmt.method_id = d.method_id
+    and mmt.total > d.total
+    returning mmt.month
+), yearly_pruned as (
+    select date_trunc('year', day::timestamp)::date as year, method_id, sum(total)::bigint as total
+    from deleted
+    where not $3::bool
+    group by date_trunc('year', day::timestamp)::date, method_id
+), yearly_emptied as (
+    delete from count.yearly_method_totals as ymt
+    using yearly_pruned as d
+    where ymt.year = d.year
+    and ymt.method_id = d.method_id
+    and ymt.total <= d.total
+    returning ymt.year
+), yearly as (
+    update count.yearly_method_totals as ymt
+    set total = ymt.total - d.total
+    from yearly_pruned as d
+    where ymt.year = d.year
+    and ymt.method_id = d.method_id
+    and ymt.total > d.total
+    returning ymt.year
+)
+select
+    (select count(*) from deleted)::bigint,
+    (
+        select count(*) from (
+            select distinct date_trunc('month', day::timestamp), method_id
+            from deleted
+        ) as months
+        where $3::bool
+    )::bigint;
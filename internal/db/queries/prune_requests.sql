@@ -0,0 +1,43 @@
+with deleted as (
+    delete from count.requests
+    where request_timestamp < $1
+    and request_timestamp <= coalesce((
+        select request_timestamp
+        from count.requests
+        where request_timestamp < $1
+        order by request_timestamp
+        offset $2 limit 1
+    ), $1)
+    returning request_timestamp, method_id, client_hash, duration_ns
+), counted as (
+    select (request_timestamp at time zone $3::text)::date as day, method_id, count(*) as total,
+        array_agg(distinct client_hash) filter (where client_hash is not null) as client_hashes,
+        array_agg(duration_ns) filter (where duration_ns is not null) as durations
+    from deleted
+    group by (request_timestamp at time zone $3::text)::date, method_id
+), merged as (
+    insert into count.daily_method_totals (day, method_id, total)
+        select day, method_id, total
+        from counted
+    on conflict (day, method_id)
+    do update set total = daily_method_totals.total + excluded.total
+    returning day
+), monthly as (
+    insert into count.monthly_method_totals (month, method_id, total)
+        select date_trunc('month', day::timestamp)::date, method_id, sum(total)::bigint
+        from counted
+        group by date_trunc('month', day::timestamp)::date, method_id
+    on conflict (month, method_id)
+    do update set total = monthly_method_totals.total + excluded.total
+    returning month
+), yearly as (
+    insert into count.yearly_method_totals (year, method_id, total)
+        select date_trunc('year', day::timestamp)::date, method_id, sum(total)::bigint
+        from counted
+        group by date_trunc('year', day::timestamp)::date, method_id
+    on conflict (year, method_id)
+    do update set total = yearly_method_totals.total + excluded.total
+    returning year
+)
+select day, method_id, total, client_hashes, durations
+from counted;
@@ -0,0 +1,9 @@
+select (request_timestamp at time zone $3::text)::date, method_id,
+    array_agg(distinct client_hash) filter (where client_hash is not null),
+    array_agg(duration_ns) filter (where duration_ns is not null)
+from count.requests
+where request_timestamp
+    between $1
+    and $2
+and (client_hash is not null or duration_ns is not null)
+group by (request_timestamp at time zone $3::text)::date, method_id;
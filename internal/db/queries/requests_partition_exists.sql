@@ -0,0 +1,9 @@
+select exists (
+    select 1
+    from pg_inherits as i
+    join pg_class as c on c.oid = i.inhrelid
+    join pg_namespace as n on n.oid = c.relnamespace
+    where n.nspname = 'count'
+    and c.relname = $1
+    and i.inhparent = 'count.requests'::regclass
+);
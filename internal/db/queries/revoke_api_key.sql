@@ -0,0 +1,5 @@
+update count.api_keys
+set revoke_time = now()
+where id = $1
+and revoke_time is null
+returning id, name, scopes, create_time, revoke_time, service;
@@ -0,0 +1,6 @@
+select t.%[2]s, t.method_id, t.%[3]s
+from count.%[1]s as t
+join unnest($1::date[], $2::int8[]) as k(period_date, method_id)
+on t.%[2]s = k.period_date
+and t.method_id = k.method_id
+where t.%[3]s is not null;
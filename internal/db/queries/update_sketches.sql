@@ -0,0 +1,5 @@
+update count.%[1]s as t
+set %[3]s = k.sketch
+from unnest($1::date[], $2::int8[], $3::bytea[]) as k(period_date, method_id, sketch)
+where t.%[2]s = k.period_date
+and t.method_id = k.method_id;
@@ -0,0 +1 @@
+select version();
@@ -0,0 +1,147 @@
+package db
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/muhlemmer/count/internal/store"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+)
+
+// ReplicaRecovery is the time after which an unhealthy replica is used again.
+const ReplicaRecovery = 30 * time.Second
+
+// Option configures a DB created by New.
+type Option func(*options)
+
+type options struct {
+	replicaDSN    string
+	followerReads bool
+}
+
+// WithReplica sets the DSN of a read-only replica,
+// which serves queries that allow stale results.
+// The replica is connected lazily, so it does not need
+// to be available when the DB is created.
+func WithReplica(dsn string) Option {
+	return func(o *options) {
+		o.replicaDSN = dsn
+	}
+}
+
+// WithFollowerReads runs queries which allow stale results as follower reads,
+// with AS OF SYSTEM TIME follower_read_timestamp().
+// Any node, including the nearest, can serve follower reads
+// without coordinating with the leaseholder.
+// It only applies to CockroachDB and is ignored on PostgreSQL.
+func WithFollowerReads() Option {
+	return func(o *options) {
+		o.followerReads = true
+	}
+}
+
+// connectReplica configures a lazy connection pool to the replica.
+func connectReplica(ctx context.Context, dsn string) (*pgxpool.Pool, error) {
+	conf, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+	conf.LazyConnect = true
+
+	return pgxpool.ConnectConfig(ctx, conf)
+}
+
+// replicaHealth tracks failures of the replica.
+// The zero value is healthy.
+type replicaHealth struct {
+	// downUntil is the Unix time in nanoseconds
+	// until which the replica is not used.
+	downUntil atomic.Int64
+}
+
+func (h *replicaHealth) healthy(now time.Time) bool {
+	return now.UnixNano() >= h.downUntil.Load()
+}
+
+// fail marks the replica unhealthy for ReplicaRecovery.
+func (h *replicaHealth) fail(now time.Time) {
+	h.downUntil.Store(now.Add(ReplicaRecovery).UnixNano())
+}
+
+// replicaFailure reports whether err is caused by the replica being unavailable,
+// such as connection failures, instead of the query or ctx.
+func replicaFailure(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	switch classify(err).code {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+// readQuery runs the read-only query sql with args and calls scan with the rows.
+// Unless Strong freshness is set on ctx, the query is run on the replica
+// when configured and healthy, and as a follower read when enabled on CockroachDB.
+// When the replica fails, the query is run on the primary instead and
+// the replica is not used for ReplicaRecovery.
+func (db *DB) readQuery(ctx context.Context, scan func(pgx.Rows) error, sql string, args ...interface{}) error {
+	if store.FreshnessFromContext(ctx) == store.Strong {
+		return query(ctx, db.pool, false, scan, sql, args...)
+	}
+
+	follower := false
+	if db.followerReads {
+		dialect, err := db.Dialect(ctx)
+		if err != nil {
+			return err
+		}
+		follower = dialect == CockroachDB
+	}
+
+	if db.replica != nil && db.replicaHealth.healthy(time.Now()) {
+		err := query(ctx, db.replica, follower, scan, sql, args...)
+		if !replicaFailure(ctx, err) {
+			return err
+		}
+
+		zerolog.Ctx(ctx).Warn().Err(err).Dur("recovery", ReplicaRecovery).Msg("db replica unhealthy, reading from primary")
+		db.replicaHealth.fail(time.Now())
+	}
+
+	return query(ctx, db.pool, follower, scan, sql, args...)
+}
+
+// query runs sql with args on pool and calls scan with the rows.
+// A follower query runs in a transaction as of follower_read_timestamp().
+func query(ctx context.Context, pool *pgxpool.Pool, follower bool, scan func(pgx.Rows) error, sql string, args ...interface{}) error {
+	if !follower {
+		rows, err := pool.Query(ctx, sql, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		return scan(rows)
+	}
+
+	return pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, followerReadSQL); err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, sql, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		return scan(rows)
+	})
+}
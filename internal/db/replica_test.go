@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/muhlemmer/count/internal/store"
+)
+
+func Test_replicaHealth(t *testing.T) {
+	var h replicaHealth
+	now := time.Unix(1000, 0)
+
+	if !h.healthy(now) {
+		t.Fatal("zero replicaHealth not healthy")
+	}
+	h.fail(now)
+	if h.healthy(now.Add(ReplicaRecovery - 1)) {
+		t.Error("replicaHealth healthy before recovery")
+	}
+	if !h.healthy(now.Add(ReplicaRecovery)) {
+		t.Error("replicaHealth not healthy after recovery")
+	}
+}
+
+func Test_replicaFailure(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want bool
+	}{
+		{"nil", context.Background(), nil, false},
+		{"network", context.Background(), &net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+		{"timeout", context.Background(), context.DeadlineExceeded, true},
+		{"too many connections", context.Background(), &pgconn.PgError{Code: pgerrcode.TooManyConnections}, true},
+		{"admin shutdown", context.Background(), &pgconn.PgError{Code: pgerrcode.AdminShutdown}, true},
+		{"undefined table", context.Background(), &pgconn.PgError{Code: pgerrcode.UndefinedTable}, false},
+		{"context done", canceled, &net.OpError{Op: "dial", Err: errors.New("connection refused")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replicaFailure(tt.ctx, tt.err); got != tt.want {
+				t.Errorf("replicaFailure() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew_replica(t *testing.T) {
+	if _, err := New(R.CTX, R.DSN, WithReplica("foo")); err == nil {
+		t.Error("New() with replica dsn error: err = nil")
+	}
+
+	db, err := New(R.CTX, R.DSN, WithReplica(R.DSN), WithFollowerReads())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	start, end := time.Date(2022, time.October, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, time.November, 1, 0, 0, 0, -1, time.UTC)
+	want, err := testDB.ListDailyTotals(R.CTX, store.DefaultService, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := db.ListDailyTotals(R.CTX, store.DefaultService, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compareMethodCounts(t, "DB.ListDailyTotals()", got, want)
+}
+
+func TestDB_readQuery_fallback(t *testing.T) {
+	replica, err := connectReplica(R.CTX, "postgres://count@127.0.0.1:1/count?connect_timeout=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer replica.Close()
+
+	db := &DB{pool: R.Pool, replica: replica}
+	start, end := time.Date(2022, time.October, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, time.November, 1, 0, 0, 0, -1, time.UTC)
+	want, err := testDB.ListDailyTotals(R.CTX, store.DefaultService, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := db.ListDailyTotals(store.WithFreshness(R.CTX, store.Strong), store.DefaultService, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compareMethodCounts(t, "DB.ListDailyTotals() strong", got, want)
+	if !db.replicaHealth.healthy(time.Now()) {
+		t.Error("replica used for strong read")
+	}
+
+	got, err = db.ListDailyTotals(R.CTX, store.DefaultService, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compareMethodCounts(t, "DB.ListDailyTotals() fallback", got, want)
+	if db.replicaHealth.healthy(time.Now()) {
+		t.Error("unavailable replica still healthy")
+	}
+}
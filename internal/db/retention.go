@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PruneRequests deletes all entries from count.requests with a timestamp before the passed time.
+// Deleted entries are counted for each method and path pair and merged into the
+// count.daily_method_totals, count.monthly_method_totals and count.yearly_method_totals
+// tables, adding to existing totals. Their client hashes and durations
+// are merged into the clients and latencies sketches of the totals.
+// Entries are counted against their date in the location of before,
+// which must be a loaded IANA time zone or UTC.
+//
+// Requests are processed in batches of batchSize rows, oldest first, each in its own transaction.
+// A batch also includes the requests sharing the timestamp of its last row,
+// so it may exceed batchSize when timestamps are equal.
+// The total amount of deleted requests is returned,
+// also when an error occurs after some batches succeeded.
+func (db *DB) PruneRequests(ctx context.Context, before time.Time, batchSize int) (deleted int64, err error) {
+	const errDesc = "prune requests"
+	logger := zerolog.Ctx(ctx)
+
+	if batchSize <= 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "%s: batch size must be positive", errDesc)
+	}
+
+	for {
+		var n int64
+
+		err = db.retryTx(ctx, func(ctx context.Context) error {
+			return db.pool.BeginTxFunc(ctx, pgx.TxOptions{}, func(tx pgx.Tx) error {
+				var sketches daySketches
+				n, sketches, err = pruneRequestsBatch(ctx, tx, before, batchSize)
+				if err != nil {
+					return err
+				}
+				return mergeSketches(ctx, tx, sketches)
+			})
+		})
+		if err != nil {
+			return deleted, statusError(err, errDesc)
+		}
+
+		deleted += n
+		logger.Debug().Int64("deleted", n).Msg(errDesc)
+
+		if n < int64(batchSize) {
+			return deleted, nil
+		}
+	}
+}
+
+// pruneRequestsBatch deletes the batchSize oldest requests before the passed time
+// and counts them into the totals. The batch is bounded by the timestamp of its last row,
+// instead of a system column, which is not available on CockroachDB. The amount of deleted requests is returned,
+// with their sketches for mergeSketches.
+func pruneRequestsBatch(ctx context.Context, tx pgx.Tx, before time.Time, batchSize int) (n int64, sketches daySketches, err error) {
+	sketches = newDaySketches()
+
+	rows, err := tx.Query(ctx, pruneRequestsSQL,
+		pgtype.Timestamptz{Time: before, Status: pgtype.Present},
+		batchSize-1,
+		before.Location().String(),
+	)
+	if err != nil {
+		return 0, sketches, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			day       pgtype.Date
+			k         sketchKey
+			total     int64
+			hashes    []int64
+			durations []int64
+		)
+		if err = rows.Scan(&day, &k.methodID, &total, &hashes, &durations); err != nil {
+			return n, sketches, err
+		}
+		k.period = civilDay(day.Time)
+		n += total
+		sketches.add(k, hashes, durations)
+	}
+	return n, sketches, rows.Err()
+}
+
+// PruneDailyTotals deletes all entries from count.daily_method_totals before the passed date.
+// When keepPeriodTotals is true, count.monthly_method_totals and count.yearly_method_totals
+// are retained, down-sampling the deleted dates to monthly and yearly totals.
+// Otherwise the deleted totals are subtracted from them,
+// and monthly and yearly totals which reach zero are deleted.
+//
+// Entries are processed in batches of batchSize rows, each in its own transaction.
+// The total amount of deleted daily totals and retained monthly totals is returned,
+// also when an error occurs after some batches succeeded.
+func (db *DB) PruneDailyTotals(ctx context.Context, before time.Time, keepPeriodTotals bool, batchSize int) (deleted, kept int64, err error) {
+	const errDesc = "prune daily totals"
+	logger := zerolog.Ctx(ctx)
+
+	if batchSize <= 0 {
+		return 0, 0, status.Errorf(codes.InvalidArgument, "%s: batch size must be positive", errDesc)
+	}
+
+	for {
+		var n, m pgtype.Int8
+
+		err = db.retryTx(ctx, func(ctx context.Context) error {
+			return db.pool.QueryRow(ctx, pruneDailyTotalsSQL,
+				pgtype.Date{Time: before, Status: pgtype.Present},
+				batchSize,
+				keepPeriodTotals,
+			).Scan(&n, &m)
+		})
+		if err != nil {
+			return deleted, kept, statusError(err, errDesc)
+		}
+
+		deleted += n.Int
+		kept += m.Int
+		logger.Debug().Int64("deleted", n.Int).Int64("kept", m.Int).Msg(errDesc)
+
+		if n.Int < int64(batchSize) {
+			return deleted, kept, nil
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+)
+
+func TestDB_PruneRequests(t *testing.T) {
+	var (
+		day    = time.Date(1975, time.January, 1, 0, 0, 0, 0, time.UTC)
+		before = day.AddDate(0, 0, 1)
+	)
+
+	type args struct {
+		ctx       context.Context
+		batchSize int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		insert      []time.Time
+		wantDeleted int64
+		wantTotal   int64
+		wantErr     bool
+	}{
+		{
+			name:    "batch size error",
+			args:    args{R.CTX, 0},
+			wantErr: true,
+		},
+		{
+			name:    "context error",
+			args:    args{R.ErrCTX, 2},
+			wantErr: true,
+		},
+		{
+			name: "rollup",
+			args: args{R.CTX, 2},
+			insert: []time.Time{
+				day.Add(10 * time.Hour),
+				day.Add(10*time.Hour + 30*time.Minute),
+				day.Add(23 * time.Hour),
+				before.Add(time.Hour), // kept
+			},
+			wantDeleted: 3,
+			wantTotal:   3,
+		},
+		{
+			name: "merge",
+			args: args{R.CTX, 2},
+			insert: []time.Time{
+				day.Add(12 * time.Hour),
+			},
+			wantDeleted: 1,
+			wantTotal:   4,
+		},
+		{
+			name: "equal timestamps",
+			args: args{R.CTX, 1},
+			insert: []time.Time{
+				day.Add(13 * time.Hour),
+				day.Add(13 * time.Hour),
+			},
+			wantDeleted: 2,
+			wantTotal:   6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, ts := range tt.insert {
+				if err := testDB.InsertMethodRequest(R.CTX, store.DefaultService, countv1.Method_GET, "/retention", ts); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			gotDeleted, err := testDB.PruneRequests(tt.args.ctx, before, tt.args.batchSize)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DB.PruneRequests() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotDeleted != tt.wantDeleted {
+				t.Errorf("DB.PruneRequests() = %v, want %v", gotDeleted, tt.wantDeleted)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			compareMethodCounts(t, "DB.ListDailyTotals()", mustListDailyTotals(t, day, day), []*countv1.MethodCount{
+				{Date: datepb.Date(day), Path: "/retention", Method: countv1.Method_GET, Count: tt.wantTotal},
+			})
+		})
+	}
+}
+
+func mustListDailyTotals(t *testing.T, start, end time.Time) []*countv1.MethodCount {
+	got, err := testDB.ListDailyTotals(R.CTX, store.DefaultService, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func TestDB_PruneDailyTotals(t *testing.T) {
+	month := time.Date(1974, time.December, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		err := testDB.InsertMethodRequest(R.CTX, store.DefaultService, countv1.Method_POST, "/retention", month.AddDate(0, 0, i))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := testDB.PruneRequests(R.CTX, month.AddDate(0, 1, 0), 2); err != nil {
+		t.Fatal(err)
+	}
+
+	type args struct {
+		ctx       context.Context
+		before    time.Time
+		keep      bool
+		batchSize int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantDeleted int64
+		wantKept    int64
+		wantErr     bool
+	}{
+		{
+			name:    "batch size error",
+			args:    args{R.CTX, month.AddDate(0, 1, 0), true, 0},
+			wantErr: true,
+		},
+		{
+			name:    "context error",
+			args:    args{R.ErrCTX, month.AddDate(0, 1, 0), true, 2},
+			wantErr: true,
+		},
+		{
+			name:        "nothing to prune",
+			args:        args{R.CTX, month, true, 2},
+			wantDeleted: 0,
+			wantKept:    0,
+		},
+		{
+			name:        "keep period totals",
+			args:        args{R.CTX, month.AddDate(0, 1, 0), true, 2},
+			wantDeleted: 3,
+			wantKept:    2, // two batches keep the same month
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotDeleted, gotKept, err := testDB.PruneDailyTotals(tt.args.ctx, tt.args.before, tt.args.keep, tt.args.batchSize)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DB.PruneDailyTotals() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotDeleted != tt.wantDeleted {
+				t.Errorf("DB.PruneDailyTotals() gotDeleted = %v, want %v", gotDeleted, tt.wantDeleted)
+			}
+			if gotKept != tt.wantKept {
+				t.Errorf("DB.PruneDailyTotals() gotKept = %v, want %v", gotKept, tt.wantKept)
+			}
+		})
+	}
+
+	var total pgtype.Int8
+	err := R.Pool.QueryRow(R.CTX, `select total from count.monthly_method_totals
+		join count.methods on methods.id = method_id
+		where month = $1 and path = '/retention'`, pgtype.Date{Time: month, Status: pgtype.Present},
+	).Scan(&total)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total.Int != 3 {
+		t.Errorf("monthly total = %d, want 3", total.Int)
+	}
+}
@@ -0,0 +1,232 @@
+package db
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	"github.com/muhlemmer/count/internal/ddsketch"
+	"github.com/muhlemmer/count/internal/hll"
+)
+
+// sketch is a mergeable sketch, which is stored
+// in a bytea column of the totals tables.
+type sketch[S any] interface {
+	*S
+	Merge(*S)
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+// sketchKey identifies the sketch of a method
+// on a day, or in a month or year.
+type sketchKey struct {
+	period   time.Time
+	methodID int64
+}
+
+// addSketch merges s into the sketch of k.
+func addSketch[S any, P sketch[S]](sketches map[sketchKey]P, k sketchKey, s P) {
+	merged, ok := sketches[k]
+	if !ok {
+		merged = P(new(S))
+		sketches[k] = merged
+	}
+	merged.Merge(s)
+}
+
+// civilDay returns the date of t as UTC midnight,
+// which is how dates are scanned from the database.
+func civilDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// sketchTables are the totals tables with sketch columns.
+// period truncates a day to the period of the table.
+var sketchTables = []struct {
+	table, column string
+	period        func(day time.Time) time.Time
+}{
+	{"daily_method_totals", "day", civilDay},
+	{"monthly_method_totals", "month", func(day time.Time) time.Time {
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}},
+	{"yearly_method_totals", "year", func(day time.Time) time.Time {
+		return time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	}},
+}
+
+// daySketches are the sketches of the requests of each method and day.
+type daySketches struct {
+	clients   map[sketchKey]*hll.Sketch
+	latencies map[sketchKey]*ddsketch.Sketch
+}
+
+// newDaySketches returns empty daySketches.
+func newDaySketches() daySketches {
+	return daySketches{
+		clients:   make(map[sketchKey]*hll.Sketch),
+		latencies: make(map[sketchKey]*ddsketch.Sketch),
+	}
+}
+
+// add sketches the client hashes and durations
+// of the requests of a method on a day.
+func (s daySketches) add(k sketchKey, hashes, durations []int64) {
+	if len(hashes) > 0 {
+		c := new(hll.Sketch)
+		for _, h := range hashes {
+			c.Add(uint64(h))
+		}
+		addSketch(s.clients, k, c)
+	}
+	if len(durations) > 0 {
+		l := new(ddsketch.Sketch)
+		for _, d := range durations {
+			l.Add(time.Duration(d))
+		}
+		addSketch(s.latencies, k, l)
+	}
+}
+
+// requestSketches returns sketches of the client hashes and durations of the requests
+// between start and end inclusive, for each method and date in the location of start.
+func requestSketches(ctx context.Context, tx pgx.Tx, start, end time.Time) (daySketches, error) {
+	sketches := newDaySketches()
+
+	rows, err := tx.Query(ctx, requestSketchesSQL,
+		pgtype.Timestamptz{Time: start, Status: pgtype.Present},
+		pgtype.Timestamptz{Time: end, Status: pgtype.Present},
+		start.Location().String(),
+	)
+	if err != nil {
+		return sketches, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			day       pgtype.Date
+			k         sketchKey
+			hashes    []int64
+			durations []int64
+		)
+		if err = rows.Scan(&day, &k.methodID, &hashes, &durations); err != nil {
+			return sketches, err
+		}
+		k.period = civilDay(day.Time)
+		sketches.add(k, hashes, durations)
+	}
+	return sketches, rows.Err()
+}
+
+// unmarshalSketch decodes a sketch column.
+func unmarshalSketch[S any, P sketch[S]](column string, data []byte) (P, error) {
+	s := P(new(S))
+	if err := s.UnmarshalBinary(data); err != nil {
+		return nil, fmt.Errorf("%s: %w", column, err)
+	}
+	return s, nil
+}
+
+// mergeSketches merges the daily sketches into the sketch columns of the daily,
+// monthly and yearly totals. The totals must be inserted or updated by tx,
+// which locks them until tx is committed.
+func mergeSketches(ctx context.Context, tx pgx.Tx, daily daySketches) error {
+	if err := mergeColumn(ctx, tx, "clients", daily.clients); err != nil {
+		return err
+	}
+	return mergeColumn(ctx, tx, "latencies", daily.latencies)
+}
+
+// mergeColumn merges the daily sketches into column of the totals tables.
+func mergeColumn[S any, P sketch[S]](ctx context.Context, tx pgx.Tx, column string, daily map[sketchKey]P) error {
+	if len(daily) == 0 {
+		return nil
+	}
+
+	for _, t := range sketchTables {
+		sketches := make(map[sketchKey]P)
+		for k, s := range daily {
+			addSketch(sketches, sketchKey{t.period(k.period), k.methodID}, s)
+		}
+
+		var (
+			periods   = make([]time.Time, 0, len(sketches))
+			methodIDs = make([]int64, 0, len(sketches))
+		)
+		for k := range sketches {
+			periods = append(periods, k.period)
+			methodIDs = append(methodIDs, k.methodID)
+		}
+
+		rows, err := tx.Query(ctx, fmt.Sprintf(selectSketchesSQL, t.table, t.column, column), periods, methodIDs)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var (
+				period pgtype.Date
+				k      sketchKey
+				data   []byte
+			)
+			if err = rows.Scan(&period, &k.methodID, &data); err != nil {
+				break
+			}
+			k.period = civilDay(period.Time)
+
+			var existing P
+			if existing, err = unmarshalSketch[S, P](column, data); err != nil {
+				break
+			}
+			addSketch(sketches, k, existing)
+		}
+		rows.Close()
+		if err == nil {
+			err = rows.Err()
+		}
+		if err != nil {
+			return err
+		}
+
+		data := make([][]byte, len(periods))
+		for i := range periods {
+			if data[i], err = sketches[sketchKey{periods[i], methodIDs[i]}].MarshalBinary(); err != nil {
+				return err
+			}
+		}
+		if _, err = tx.Exec(ctx, fmt.Sprintf(updateSketchesSQL, t.table, t.column, column), periods, methodIDs, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergedSketch merges a sketch column, aggregated by a query.
+func mergedSketch[S any, P sketch[S]](column string, sketches pgtype.ByteaArray) (P, error) {
+	merged := P(new(S))
+	for _, data := range sketches.Elements {
+		if data.Status != pgtype.Present {
+			continue
+		}
+		s, err := unmarshalSketch[S, P](column, data.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		merged.Merge(s)
+	}
+	return merged, nil
+}
+
+// distinctClients merges the clients column of a totals query
+// and returns the estimated amount of distinct clients.
+func distinctClients(clients pgtype.ByteaArray) (int64, error) {
+	merged, err := mergedSketch[hll.Sketch]("clients", clients)
+	if err != nil {
+		return 0, err
+	}
+	return merged.Estimate(), nil
+}
@@ -0,0 +1,128 @@
+package db
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/muhlemmer/count/internal/ddsketch"
+	"github.com/muhlemmer/count/internal/hll"
+)
+
+func Test_sketchTables(t *testing.T) {
+	day := time.Date(2022, 11, 10, 0, 0, 0, 0, time.UTC)
+	want := []time.Time{
+		day,
+		time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for i, ct := range sketchTables {
+		if got := ct.period(day); !got.Equal(want[i]) {
+			t.Errorf("%s period = %v, want %v", ct.table, got, want[i])
+		}
+	}
+}
+
+func Test_distinctClients(t *testing.T) {
+	sketch := func(from, to int) pgtype.Bytea {
+		var s hll.Sketch
+		for i := from; i < to; i++ {
+			s.Add(hll.Hash(strconv.Itoa(i)))
+		}
+		data, err := s.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pgtype.Bytea{Bytes: data, Status: pgtype.Present}
+	}
+
+	tests := []struct {
+		name    string
+		clients pgtype.ByteaArray
+		want    int64
+		wantErr bool
+	}{
+		{"null", pgtype.ByteaArray{Status: pgtype.Null}, 0, false},
+		{"merged", pgtype.ByteaArray{
+			Elements: []pgtype.Bytea{sketch(0, 3), {Status: pgtype.Null}, sketch(2, 5)},
+			Status:   pgtype.Present,
+		}, 5, false},
+		{"invalid", pgtype.ByteaArray{
+			Elements: []pgtype.Bytea{{Bytes: []byte{1}, Status: pgtype.Present}},
+			Status:   pgtype.Present,
+		}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := distinctClients(tt.clients)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("distinctClients() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("distinctClients() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_mergedSketch_latencies(t *testing.T) {
+	sketch := func(durations ...time.Duration) pgtype.Bytea {
+		var s ddsketch.Sketch
+		for _, d := range durations {
+			s.Add(d)
+		}
+		data, err := s.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pgtype.Bytea{Bytes: data, Status: pgtype.Present}
+	}
+
+	got, err := mergedSketch[ddsketch.Sketch]("latencies", pgtype.ByteaArray{
+		Elements: []pgtype.Bytea{sketch(time.Millisecond), sketch(2*time.Millisecond, 3*time.Millisecond)},
+		Status:   pgtype.Present,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Count() != 3 {
+		t.Errorf("mergedSketch() count = %d, want 3", got.Count())
+	}
+	if q := got.Quantile(1); q < 2970*time.Microsecond || q > 3030*time.Microsecond {
+		t.Errorf("mergedSketch() quantile 1 = %v, want 3ms", q)
+	}
+
+	_, err = mergedSketch[ddsketch.Sketch]("latencies", pgtype.ByteaArray{
+		Elements: []pgtype.Bytea{{Bytes: []byte{1}, Status: pgtype.Present}},
+		Status:   pgtype.Present,
+	})
+	if err == nil {
+		t.Error("mergedSketch() err = nil")
+	}
+}
+
+func Test_latencyStatsQuery(t *testing.T) {
+	tests := []struct {
+		start, end time.Time
+		want       string
+	}{
+		{
+			time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 11, 10, 0, 0, 0, 0, time.UTC),
+			getLatencyStatsSQL,
+		},
+		{
+			time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 12, 1, 0, 0, 0, -1, time.UTC),
+			getLatencyStatsMonthlySQL,
+		},
+		{
+			time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 1, 1, 0, 0, 0, -1, time.UTC),
+			getLatencyStatsYearlySQL,
+		},
+	}
+	for _, tt := range tests {
+		if got := latencyStatsQuery(tt.start, tt.end); got != tt.want {
+			t.Errorf("latencyStatsQuery(%v, %v) =\n%s\nwant\n%s", tt.start, tt.end, got, tt.want)
+		}
+	}
+}
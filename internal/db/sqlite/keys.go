@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var _ store.KeyStore = &DB{}
+
+// timestamp converts unix nanoseconds to a Timestamp,
+// which is nil for NULL.
+func timestamp(ns sql.NullInt64) *timestamppb.Timestamp {
+	if !ns.Valid {
+		return nil
+	}
+	return timestamppb.New(time.Unix(0, ns.Int64))
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAPIKey(row rowScanner) (*countv1.ApiKey, error) {
+	var (
+		key                    countv1.ApiKey
+		scopes                 int64
+		createTime, revokeTime sql.NullInt64
+		service                sql.NullString
+	)
+	if err := row.Scan(&key.Id, &key.Name, &scopes, &createTime, &revokeTime, &service); err != nil {
+		return nil, err
+	}
+	key.Service = service.String
+	key.Scopes = store.MaskScopes(scopes)
+	key.CreateTime = timestamp(createTime)
+	key.RevokeTime = timestamp(revokeTime)
+	return &key, nil
+}
+
+// keyError returns NotFound for sql.ErrNoRows
+// and AlreadyExists for a duplicate key hash.
+func keyError(err error, desc string) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return status.Errorf(codes.NotFound, "%s: key not found", desc)
+	case err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed"):
+		return status.Errorf(codes.AlreadyExists, "%s: key already exists", desc)
+	default:
+		return statusError(err, desc)
+	}
+}
+
+// CreateAPIKey inserts an API key into api_keys.
+func (db *DB) CreateAPIKey(ctx context.Context, name, service string, scopes []countv1.Scope, hash []byte) (*countv1.ApiKey, error) {
+	now := time.Now()
+	key := &countv1.ApiKey{
+		Name:       name,
+		Scopes:     store.MaskScopes(store.ScopeMask(scopes)),
+		CreateTime: timestamppb.New(now),
+		Service:    service,
+	}
+
+	err := db.db.QueryRowContext(ctx, createAPIKeySQL, name, hash, store.ScopeMask(scopes), now.UnixNano(), service).Scan(&key.Id)
+	if err != nil {
+		return nil, keyError(err, "create api key")
+	}
+	return key, nil
+}
+
+// ListAPIKeys selects all API keys from api_keys.
+func (db *DB) ListAPIKeys(ctx context.Context) (keys []*countv1.ApiKey, err error) {
+	const errDesc = "list api keys"
+
+	rows, err := db.db.QueryContext(ctx, listAPIKeysSQL)
+	if err != nil {
+		return nil, statusError(err, errDesc)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, statusError(err, errDesc)
+		}
+		keys = append(keys, key)
+	}
+	return keys, statusError(rows.Err(), errDesc)
+}
+
+// RevokeAPIKey sets the revoke time of an API key in api_keys.
+func (db *DB) RevokeAPIKey(ctx context.Context, id int64) (*countv1.ApiKey, error) {
+	key, err := scanAPIKey(db.db.QueryRowContext(ctx, revokeAPIKeySQL, id, time.Now().UnixNano()))
+	return key, keyError(err, "revoke api key")
+}
+
+// LookupAPIKey selects an API key which is not revoked by its hash from api_keys.
+func (db *DB) LookupAPIKey(ctx context.Context, hash []byte) (*countv1.ApiKey, error) {
+	key, err := scanAPIKey(db.db.QueryRowContext(ctx, lookupAPIKeySQL, hash))
+	return key, keyError(err, "lookup api key")
+}
@@ -0,0 +1,40 @@
+package sqlite
+
+import _ "embed"
+
+var (
+	//go:embed queries/insert_method.sql
+	insertMethodSQL string
+	//go:embed queries/insert_request.sql
+	insertRequestSQL string
+	//go:embed queries/select_requests_interval.sql
+	selectRequestsIntervalSQL string
+	//go:embed queries/delete_requests_interval.sql
+	deleteRequestsIntervalSQL string
+	//go:embed queries/list_daily_totals_interval.sql
+	listDailyTotalsSQL string
+	//go:embed queries/get_period_totals.sql
+	getPeriodTotalsSQL string
+	//go:embed queries/compare_periods.sql
+	comparePeriodsSQL string
+	//go:embed queries/prune_requests.sql
+	pruneRequestsSQL string
+	//go:embed queries/merge_daily_method_total.sql
+	mergeDailyMethodTotalSQL string
+	//go:embed queries/prune_daily_totals.sql
+	pruneDailyTotalsSQL string
+	//go:embed queries/export_daily_totals.sql
+	exportDailyTotalsSQL string
+	//go:embed queries/merge_daily_total.sql
+	mergeDailyTotalSQL string
+	//go:embed queries/replace_daily_total.sql
+	replaceDailyTotalSQL string
+	//go:embed queries/create_api_key.sql
+	createAPIKeySQL string
+	//go:embed queries/list_api_keys.sql
+	listAPIKeysSQL string
+	//go:embed queries/revoke_api_key.sql
+	revokeAPIKeySQL string
+	//go:embed queries/lookup_api_key.sql
+	lookupAPIKeySQL string
+)
@@ -0,0 +1,40 @@
+with period as (
+    select method, path, sum(total) as total
+    from daily_method_totals as dmt
+    join methods as m on m.id = dmt.method_id
+    where day
+        between ?1
+        and ?2
+    and service = ?5
+    group by method, path
+), reference as (
+    select method, path, sum(total) as total
+    from daily_method_totals as dmt
+    join methods as m on m.id = dmt.method_id
+    where day
+        between ?3
+        and ?4
+    and service = ?5
+    group by method, path
+), pairs as (
+    select method, path from period
+    union
+    select method, path from reference
+)
+select pr.method, pr.path,
+    coalesce(p.total, 0),
+    coalesce(r.total, 0),
+    coalesce(p.total, 0) - coalesce(r.total, 0),
+    case when coalesce(r.total, 0) = 0 then 0.0
+        else cast(coalesce(p.total, 0) - r.total as real) / r.total
+    end,
+    p.method is not null,
+    r.method is not null
+from pairs as pr
+left join period as p
+on p.method = pr.method
+and p.path = pr.path
+left join reference as r
+on r.method = pr.method
+and r.path = pr.path
+order by pr.path, pr.method;
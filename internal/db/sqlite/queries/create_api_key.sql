@@ -0,0 +1,3 @@
+insert into api_keys (name, key_hash, scopes, create_time, service)
+    values (?, ?, ?, ?, nullif(?, ''))
+    returning id;
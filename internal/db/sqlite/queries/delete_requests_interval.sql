@@ -0,0 +1,4 @@
+delete from requests
+where request_timestamp
+    between ?
+    and ?;
@@ -0,0 +1,13 @@
+select day, method, path, total
+from daily_method_totals as dmt
+join methods as m on m.id = dmt.method_id
+where day
+    between ?1
+    and ?2
+and service = ?3
+and (
+    ?4 is null
+    or (day, path, method) > (?4, ?5, ?6)
+)
+order by day, path, method
+limit ?7;
@@ -0,0 +1,9 @@
+select null, method, path, sum(total)
+from daily_method_totals as dmt
+join methods as m on m.id = dmt.method_id
+where day
+    between ?1
+    and ?2
+and service = ?3
+group by method, path
+order by path, method;
@@ -0,0 +1,3 @@
+insert into methods (service, method, path)
+    values (?, ?, ?)
+    on conflict (service, method, path) do nothing;
@@ -0,0 +1,6 @@
+insert into requests (method_id, request_timestamp)
+    select id, ?
+    from methods
+    where service = ?
+    and method = ?
+    and path = ?;
@@ -0,0 +1,3 @@
+select id, name, scopes, create_time, revoke_time, service
+from api_keys
+order by id;
@@ -0,0 +1,5 @@
+insert into daily_method_totals (day, method_id, total)
+    values (?, ?, ?)
+    on conflict (day, method_id)
+    do update set total = daily_method_totals.total + excluded.total
+    returning total;
@@ -0,0 +1,8 @@
+insert into daily_method_totals (day, method_id, total)
+    select ?1, id, ?5
+    from methods
+    where service = ?2
+    and method = ?3
+    and path = ?4
+    on conflict (day, method_id)
+    do update set total = daily_method_totals.total + excluded.total;
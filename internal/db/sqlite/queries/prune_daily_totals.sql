@@ -0,0 +1,8 @@
+delete from daily_method_totals
+where rowid in (
+    select rowid
+    from daily_method_totals
+    where day < ?1
+    order by day, method_id
+    limit ?2
+);
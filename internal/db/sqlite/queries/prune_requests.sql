@@ -0,0 +1,9 @@
+delete from requests
+where rowid in (
+    select rowid
+    from requests
+    where request_timestamp < ?1
+    order by request_timestamp
+    limit ?2
+)
+returning method_id, request_timestamp;
@@ -0,0 +1,6 @@
+select method_id, service, method, path, request_timestamp
+from requests as r
+join methods as m on m.id = r.method_id
+where request_timestamp
+    between ?
+    and ?;
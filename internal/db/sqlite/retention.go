@@ -0,0 +1,116 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PruneRequests deletes all entries from the requests table with a timestamp before the passed time.
+// Deleted entries are counted for each method and path pair and merged into
+// the daily_method_totals table, adding to existing totals.
+// Entries are counted against their date in the location of before.
+//
+// Requests are processed in batches of batchSize rows, oldest first, each in its own transaction.
+// The total amount of deleted requests is returned,
+// also when an error occurs after some batches succeeded.
+func (db *DB) PruneRequests(ctx context.Context, before time.Time, batchSize int) (deleted int64, err error) {
+	const errDesc = "prune requests"
+
+	if batchSize <= 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "%s: batch size must be positive", errDesc)
+	}
+
+	for {
+		n, err := db.pruneRequestsBatch(ctx, before, batchSize)
+		if err != nil {
+			return deleted, statusError(err, errDesc)
+		}
+		deleted += n
+
+		if n < int64(batchSize) {
+			return deleted, nil
+		}
+	}
+}
+
+// pruneRequestsBatch deletes the batchSize oldest requests before the passed time
+// and merges their counts into daily_method_totals, in a single transaction.
+// The amount of deleted requests is returned.
+func (db *DB) pruneRequestsBatch(ctx context.Context, before time.Time, batchSize int) (n int64, err error) {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, pruneRequestsSQL, before.UnixNano(), batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	counts := make(map[dailyKey]int64)
+	for rows.Next() {
+		var (
+			key dailyKey
+			ts  int64
+		)
+		if err = rows.Scan(&key.methodID, &ts); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		key.day = time.Unix(0, ts).In(before.Location()).Format(dateLayout)
+		counts[key]++
+		n++
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for key, total := range counts {
+		if _, err = tx.ExecContext(ctx, mergeDailyMethodTotalSQL, key.day, key.methodID, total); err != nil {
+			return 0, err
+		}
+	}
+
+	return n, tx.Commit()
+}
+
+// PruneDailyTotals deletes all entries from daily_method_totals before the passed date.
+// SQLite does not keep monthly totals, so the period totals of the
+// deleted dates are lost. An Unimplemented error is returned when
+// keepPeriodTotals is true.
+//
+// Entries are processed in batches of batchSize rows, each in its own transaction.
+// The total amount of deleted daily totals is returned,
+// also when an error occurs after some batches succeeded.
+// The amount of kept monthly totals is always zero.
+func (db *DB) PruneDailyTotals(ctx context.Context, before time.Time, keepPeriodTotals bool, batchSize int) (deleted, kept int64, err error) {
+	const errDesc = "prune daily totals"
+
+	if keepPeriodTotals {
+		return 0, 0, status.Errorf(codes.Unimplemented, "%s: keeping monthly and yearly totals is not supported by SQLite", errDesc)
+	}
+	if batchSize <= 0 {
+		return 0, 0, status.Errorf(codes.InvalidArgument, "%s: batch size must be positive", errDesc)
+	}
+
+	for {
+		res, err := db.db.ExecContext(ctx, pruneDailyTotalsSQL, before.Format(dateLayout), batchSize)
+		if err != nil {
+			return deleted, 0, statusError(err, errDesc)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return deleted, 0, statusError(err, errDesc)
+		}
+		deleted += n
+
+		if n < int64(batchSize) {
+			return deleted, 0, nil
+		}
+	}
+}
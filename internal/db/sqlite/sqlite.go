@@ -0,0 +1,434 @@
+// Package sqlite provides a store.Store backed by a SQLite database file,
+// using a pure Go driver.
+// It is meant for development and small deployments,
+// where running PostgreSQL is overkill.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	_ "modernc.org/sqlite"
+)
+
+const dateLayout = "2006-01-02"
+
+func statusError(err error, desc string) error {
+	if err == nil {
+		return nil
+	}
+	return status.Errorf(codes.Internal, "%s: %v", desc, err)
+}
+
+// DB provides the count queries over a SQLite database.
+type DB struct {
+	db *sql.DB
+}
+
+var (
+	_ store.Store            = &DB{}
+	_ store.PeriodComparer   = &DB{}
+	_ store.TimeSeriesReader = &DB{}
+	_ store.Pruner           = &DB{}
+	_ store.Exporter         = &DB{}
+	_ store.Importer         = &DB{}
+)
+
+// filename returns the database file from a sqlite:// DSN.
+// Query parameters starting with x- are used by the
+// migrations and removed.
+func filename(dsn string) (string, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	for k := range q {
+		if strings.HasPrefix(k, "x-") {
+			q.Del(k)
+		}
+	}
+	u.RawQuery = q.Encode()
+
+	return strings.TrimPrefix(u.String(), "sqlite://"), nil
+}
+
+// Open the SQLite database at dsn, in the form of sqlite://path/to/file.db.
+// The schema must be created by the migrations first.
+// A single connection is used, as SQLite only allows one writer at a time.
+//
+// Besides store.Store, the DB implements store.PeriodComparer,
+// store.TimeSeriesReader, store.Pruner, store.Exporter, store.Importer
+// and store.KeyStore. Request details, labels and partitioning
+// are only supported on PostgreSQL.
+func Open(ctx context.Context, dsn string) (*DB, error) {
+	name, err := filename(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite", name)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+
+	return &DB{db}, db.PingContext(ctx)
+}
+
+func (db *DB) Close() {
+	db.db.Close()
+}
+
+// InsertMethodRequest inserts a request for a certain method and path of service.
+func (db *DB) InsertMethodRequest(ctx context.Context, service string, method countv1.Method, path string, requestTS time.Time) error {
+	const errDesc = "insert method request"
+
+	if _, err := db.db.ExecContext(ctx, insertMethodSQL, service, method.String(), path); err != nil {
+		return statusError(err, errDesc)
+	}
+
+	_, err := db.db.ExecContext(ctx, insertRequestSQL, requestTS.UnixNano(), service, method.String(), path)
+	return statusError(err, errDesc)
+}
+
+type dailyKey struct {
+	day      string
+	methodID int64
+}
+
+// CountDailyMethodTotals deletes entries from the requests table between start and end,
+// inclusive. Deleted entries are counted for each service, method and path against their date
+// in the location of start, and added to the daily_method_totals table.
+// The resulting count entries are returned, including previously stored totals.
+func (db *DB) CountDailyMethodTotals(ctx context.Context, start, end time.Time) (results []*countv1.MethodCount, err error) {
+	const errDesc = "count daily method totals"
+
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, statusError(err, errDesc)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, selectRequestsIntervalSQL, start.UnixNano(), end.UnixNano())
+	if err != nil {
+		return nil, statusError(err, errDesc)
+	}
+
+	counts := make(map[dailyKey]*countv1.MethodCount)
+	for rows.Next() {
+		var (
+			key                   dailyKey
+			service, method, path string
+			ts                    int64
+		)
+		if err = rows.Scan(&key.methodID, &service, &method, &path, &ts); err != nil {
+			rows.Close()
+			return nil, statusError(err, errDesc)
+		}
+		key.day = time.Unix(0, ts).In(start.Location()).Format(dateLayout)
+
+		mc, ok := counts[key]
+		if !ok {
+			mc, err = methodCount(key.day, method, path, 0)
+			if err != nil {
+				rows.Close()
+				return nil, statusError(err, errDesc)
+			}
+			mc.Service = service
+			counts[key] = mc
+		}
+		mc.Count++
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, statusError(err, errDesc)
+	}
+
+	for key, mc := range counts {
+		var total int64
+		if err = tx.QueryRowContext(ctx, mergeDailyMethodTotalSQL, key.day, key.methodID, mc.GetCount()).Scan(&total); err != nil {
+			return nil, statusError(err, errDesc)
+		}
+		mc.Count = total
+		results = append(results, mc)
+	}
+
+	if _, err = tx.ExecContext(ctx, deleteRequestsIntervalSQL, start.UnixNano(), end.UnixNano()); err != nil {
+		return nil, statusError(err, errDesc)
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, statusError(err, errDesc)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if ad, bd := datepb.Time(a.GetDate()), datepb.Time(b.GetDate()); !ad.Equal(bd) {
+			return ad.Before(bd)
+		}
+		if a.GetService() != b.GetService() {
+			return a.GetService() < b.GetService()
+		}
+		if a.GetPath() != b.GetPath() {
+			return a.GetPath() < b.GetPath()
+		}
+		return a.GetMethod().String() < b.GetMethod().String()
+	})
+
+	return results, nil
+}
+
+// ListDailyTotals selects entries of service from daily_method_totals
+// between the dates of start and end inclusive.
+func (db *DB) ListDailyTotals(ctx context.Context, service string, start, end time.Time) ([]*countv1.MethodCount, error) {
+	results, err := db.dateIntervalQuery(ctx, listDailyTotalsSQL, start, end, service)
+	return results, statusError(err, "list daily totals")
+}
+
+// GetPeriodTotals sums the totals of service in daily_method_totals,
+// grouped by method and path.
+// The dates of start and end are inclusive.
+func (db *DB) GetPeriodTotals(ctx context.Context, service string, start, end time.Time) ([]*countv1.MethodCount, error) {
+	results, err := db.dateIntervalQuery(ctx, getPeriodTotalsSQL, start, end, service)
+	return results, statusError(err, "get period totals")
+}
+
+// ComparePeriods sums the totals of service from daily_method_totals
+// for two periods and compares them for each method and path pair.
+// The dates of start, end, refStart and refEnd are inclusive.
+func (db *DB) ComparePeriods(ctx context.Context, service string, start, end, refStart, refEnd time.Time) (results []*countv1.PeriodComparison, err error) {
+	const errDesc = "compare periods"
+
+	rows, err := db.db.QueryContext(ctx, comparePeriodsSQL,
+		start.Format(dateLayout), end.Format(dateLayout),
+		refStart.Format(dateLayout), refEnd.Format(dateLayout),
+		service,
+	)
+	if err != nil {
+		return nil, statusError(err, errDesc)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			method, path          string
+			total, referenceTotal int64
+			delta                 int64
+			relative              float64
+			inPeriod, inReference bool
+		)
+		if err = rows.Scan(&method, &path, &total, &referenceTotal, &delta, &relative, &inPeriod, &inReference); err != nil {
+			return nil, statusError(err, errDesc)
+		}
+
+		results = append(results, &countv1.PeriodComparison{
+			Method:         countv1.Method(countv1.Method_value[method]),
+			Path:           path,
+			Count:          total,
+			ReferenceCount: referenceTotal,
+			Delta:          delta,
+			RelativeChange: relative,
+			Presence:       periodPresence(inPeriod, inReference),
+		})
+	}
+
+	return results, statusError(rows.Err(), errDesc)
+}
+
+func periodPresence(inPeriod, inReference bool) countv1.PeriodPresence {
+	switch {
+	case inPeriod && inReference:
+		return countv1.PeriodPresence_PERIOD_PRESENCE_BOTH
+	case inPeriod:
+		return countv1.PeriodPresence_PERIOD_PRESENCE_PERIOD_ONLY
+	case inReference:
+		return countv1.PeriodPresence_PERIOD_PRESENCE_REFERENCE_ONLY
+	default:
+		return countv1.PeriodPresence_PERIOD_PRESENCE_UNSPECIFIED
+	}
+}
+
+// GetTimeSeries selects entries of service from daily_method_totals and
+// sums the totals, grouped by bucket, method and path.
+// Each bucket has the length of precision and its date is set to the
+// first day of the bucket. Results are limited to paths, unless empty.
+// The dates of start and end are inclusive.
+func (db *DB) GetTimeSeries(ctx context.Context, service string, start, end time.Time, precision datepb.Precision, paths []string) ([]*countv1.MethodCount, error) {
+	daily, err := db.dateIntervalQuery(ctx, listDailyTotalsSQL, start, end, service)
+	if err != nil {
+		return nil, statusError(err, "get time series")
+	}
+
+	include := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		include[path] = true
+	}
+
+	type bucketKey struct {
+		bucket time.Time
+		method countv1.Method
+		path   string
+	}
+	var (
+		results []*countv1.MethodCount
+		buckets = make(map[bucketKey]*countv1.MethodCount)
+	)
+	for _, mc := range daily {
+		if len(paths) > 0 && !include[mc.GetPath()] {
+			continue
+		}
+
+		k := bucketKey{datepb.Truncate(datepb.Time(mc.GetDate()), precision), mc.GetMethod(), mc.GetPath()}
+		bucket, ok := buckets[k]
+		if !ok {
+			bucket = &countv1.MethodCount{
+				Date:   datepb.Date(k.bucket),
+				Method: k.method,
+				Path:   k.path,
+			}
+			buckets[k] = bucket
+			results = append(results, bucket)
+		}
+		bucket.Count += mc.GetCount()
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if ad, bd := datepb.Time(a.GetDate()), datepb.Time(b.GetDate()); !ad.Equal(bd) {
+			return ad.Before(bd)
+		}
+		if a.GetPath() != b.GetPath() {
+			return a.GetPath() < b.GetPath()
+		}
+		return a.GetMethod().String() < b.GetMethod().String()
+	})
+
+	return results, nil
+}
+
+// ExportDailyTotals reads daily_method_totals of service between the dates
+// of start and end inclusive, in pages of at most pageSize entries,
+// using keyset pagination on day, path and method.
+// fn is called for each page, until there are no more entries
+// or fn returns an error.
+func (db *DB) ExportDailyTotals(ctx context.Context, service string, start, end time.Time, pageSize int, fn func(page []*countv1.MethodCount) error) error {
+	const errDesc = "export daily totals"
+
+	var cursorDay, cursorPath, cursorMethod sql.NullString
+
+	for {
+		page, err := db.dateIntervalQuery(ctx, exportDailyTotalsSQL, start, end,
+			service, cursorDay, cursorPath, cursorMethod, pageSize,
+		)
+		if err != nil {
+			return statusError(err, errDesc)
+		}
+		if len(page) == 0 {
+			return nil
+		}
+		if err = fn(page); err != nil {
+			return err
+		}
+		if len(page) < pageSize {
+			return nil
+		}
+
+		last := page[len(page)-1]
+		cursorDay = sql.NullString{String: datepb.Time(last.GetDate()).Format(dateLayout), Valid: true}
+		cursorPath = sql.NullString{String: last.GetPath(), Valid: true}
+		cursorMethod = sql.NullString{String: last.GetMethod().String(), Valid: true}
+	}
+}
+
+// ImportDailyTotals upserts totals of service into daily_method_totals in a single transaction.
+// Existing totals for the same day, service, method and path are incremented
+// or replaced, depending on mode.
+func (db *DB) ImportDailyTotals(ctx context.Context, service string, totals []*countv1.MethodCount, mode store.ImportMode) error {
+	const errDesc = "import daily totals"
+
+	query := mergeDailyTotalSQL
+	if mode == store.Replace {
+		query = replaceDailyTotalSQL
+	}
+
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return statusError(err, errDesc)
+	}
+	defer tx.Rollback()
+
+	for _, mc := range totals {
+		method, path := mc.GetMethod().String(), mc.GetPath()
+		if _, err = tx.ExecContext(ctx, insertMethodSQL, service, method, path); err != nil {
+			return statusError(err, errDesc)
+		}
+
+		day := datepb.Time(mc.GetDate()).Format(dateLayout)
+		if _, err = tx.ExecContext(ctx, query, day, service, method, path, mc.GetCount()); err != nil {
+			return statusError(err, errDesc)
+		}
+	}
+
+	return statusError(tx.Commit(), errDesc)
+}
+
+// dateIntervalQuery executes query with the dates of start and end,
+// followed by args, as arguments and scans the resulting method counts.
+func (db *DB) dateIntervalQuery(ctx context.Context, query string, start, end time.Time, args ...interface{}) (results []*countv1.MethodCount, err error) {
+	args = append([]interface{}{start.Format(dateLayout), end.Format(dateLayout)}, args...)
+
+	rows, err := db.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			day          sql.NullString
+			method, path string
+			total        int64
+		)
+		if err = rows.Scan(&day, &method, &path, &total); err != nil {
+			return nil, err
+		}
+
+		mc, err := methodCount(day.String, method, path, total)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, mc)
+	}
+
+	return results, rows.Err()
+}
+
+// methodCount builds a MethodCount.
+// The date is not set when day is empty.
+func methodCount(day, method, path string, total int64) (*countv1.MethodCount, error) {
+	mc := &countv1.MethodCount{
+		Method: countv1.Method(countv1.Method_value[method]),
+		Path:   path,
+		Count:  total,
+	}
+
+	if day != "" {
+		t, err := time.Parse(dateLayout, day)
+		if err != nil {
+			return nil, err
+		}
+		mc.Date = datepb.Date(t)
+	}
+
+	return mc, nil
+}
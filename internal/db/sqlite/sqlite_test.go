@@ -0,0 +1,56 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/muhlemmer/count/internal/db/migrations"
+	"github.com/muhlemmer/count/internal/store/storetest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_filename(t *testing.T) {
+	tests := []struct {
+		dsn  string
+		want string
+	}{
+		{"sqlite://count.db", "count.db"},
+		{"sqlite:///var/lib/count/count.db", "/var/lib/count/count.db"},
+		{"sqlite://count.db?x-migrations-table=foo", "count.db"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dsn, func(t *testing.T) {
+			got, err := filename(tt.dsn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("filename() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDB_store(t *testing.T) {
+	ctx := context.Background()
+	dsn := "sqlite://" + filepath.Join(t.TempDir(), "count.db")
+
+	if err := migrations.Up(dsn); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := Open(ctx, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	storetest.Run(t, ctx, db)
+
+	if _, _, err = db.PruneDailyTotals(ctx, time.Now(), true, 1); status.Code(err) != codes.Unimplemented {
+		t.Errorf("PruneDailyTotals() keep period totals err = %v, want code %s", err, codes.Unimplemented)
+	}
+}
@@ -0,0 +1,21 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/muhlemmer/count/internal/store"
+	"github.com/muhlemmer/count/internal/store/storetest"
+)
+
+var (
+	_ store.Store         = &DB{}
+	_ store.Exporter      = &DB{}
+	_ store.Importer      = &DB{}
+	_ store.KeyStore      = &DB{}
+	_ store.DetailCounter = &DB{}
+	_ store.Labeler       = &DB{}
+)
+
+func TestDB_store(t *testing.T) {
+	storetest.Run(t, R.CTX, testDB)
+}
@@ -0,0 +1,231 @@
+// Package ddsketch implements DDSketch quantile sketches of durations.
+// Durations are counted in logarithmic buckets, so that each quantile
+// is estimated within a relative error of RelativeAccuracy,
+// with a memory usage which depends on the range of the durations
+// and not on their amount.
+// Sketches are merged by adding the counts of each bucket,
+// so that sketches of days can be merged into sketches of longer periods.
+// See https://arxiv.org/abs/1908.10693.
+package ddsketch
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// RelativeAccuracy of the estimated quantiles.
+const RelativeAccuracy = 0.01
+
+const (
+	version = 1
+
+	// accuracy is RelativeAccuracy in basis points,
+	// which is stored in the header.
+	accuracy   = 100
+	headerSize = 2
+)
+
+var (
+	gamma    = (1 + RelativeAccuracy) / (1 - RelativeAccuracy)
+	logGamma = math.Log(gamma)
+)
+
+// Sketch is a DDSketch of durations.
+// The zero value is an empty sketch.
+type Sketch struct {
+	// zero counts durations below a nanosecond.
+	zero uint64
+
+	// bins counts the durations d with gamma^(i-1) < d <= gamma^i
+	// in nanoseconds, by index i. Nil until the first duration is added.
+	bins map[int32]uint64
+
+	count uint64
+}
+
+// index of the bin of d, which must be positive.
+func index(d time.Duration) int32 {
+	return int32(math.Ceil(math.Log(float64(d)) / logGamma))
+}
+
+// value is the estimate of the durations in the bin of i,
+// with a relative error of at most RelativeAccuracy.
+func value(i int32) time.Duration {
+	return time.Duration(math.Round(2 * math.Pow(gamma, float64(i)) / (gamma + 1)))
+}
+
+// Add a duration. Negative durations are counted as zero.
+func (s *Sketch) Add(d time.Duration) {
+	s.count++
+	if d <= 0 {
+		s.zero++
+		return
+	}
+	if s.bins == nil {
+		s.bins = make(map[int32]uint64)
+	}
+	s.bins[index(d)]++
+}
+
+// Merge o into s, so that s estimates the quantiles of both sets.
+func (s *Sketch) Merge(o *Sketch) {
+	if o.Empty() {
+		return
+	}
+	s.count += o.count
+	s.zero += o.zero
+	if len(o.bins) > 0 && s.bins == nil {
+		s.bins = make(map[int32]uint64, len(o.bins))
+	}
+	for i, n := range o.bins {
+		s.bins[i] += n
+	}
+}
+
+// Empty reports whether no durations were added.
+func (s *Sketch) Empty() bool {
+	return s == nil || s.count == 0
+}
+
+// Count returns the amount of added durations.
+func (s *Sketch) Count() int64 {
+	if s == nil {
+		return 0
+	}
+	return int64(s.count)
+}
+
+// sortedIndexes returns the indexes of the bins in ascending order.
+func (s *Sketch) sortedIndexes() []int32 {
+	indexes := make([]int32, 0, len(s.bins))
+	for i := range s.bins {
+		indexes = append(indexes, i)
+	}
+	sort.Slice(indexes, func(a, b int) bool { return indexes[a] < indexes[b] })
+	return indexes
+}
+
+// Quantile returns the estimated duration of quantile q, between 0 and 1.
+// Zero is returned for an empty sketch.
+func (s *Sketch) Quantile(q float64) time.Duration {
+	if s.Empty() {
+		return 0
+	}
+	q = math.Max(0, math.Min(1, q))
+
+	rank := uint64(q * float64(s.count-1))
+	if rank < s.zero {
+		return 0
+	}
+	cumulative := s.zero
+	indexes := s.sortedIndexes()
+	for _, i := range indexes {
+		cumulative += s.bins[i]
+		if cumulative > rank {
+			return value(i)
+		}
+	}
+	return value(indexes[len(indexes)-1])
+}
+
+// MarshalBinary encodes the sketch as a header of version and accuracy,
+// followed by the zero count, the amount of bins and each bin
+// in ascending order: the index as delta to the previous index and its count.
+func (s *Sketch) MarshalBinary() ([]byte, error) {
+	data := []byte{version, accuracy}
+	if s == nil {
+		s = new(Sketch)
+	}
+	data = binary.AppendUvarint(data, s.zero)
+	data = binary.AppendUvarint(data, uint64(len(s.bins)))
+
+	var prev int32
+	for _, i := range s.sortedIndexes() {
+		data = binary.AppendVarint(data, int64(i-prev))
+		data = binary.AppendUvarint(data, s.bins[i])
+		prev = i
+	}
+	return data, nil
+}
+
+var errInvalid = errors.New("ddsketch: invalid sketch")
+
+// UnmarshalBinary decodes a sketch encoded by MarshalBinary.
+func (s *Sketch) UnmarshalBinary(data []byte) error {
+	if len(data) < headerSize || data[0] != version {
+		return errInvalid
+	}
+	if data[1] != accuracy {
+		return fmt.Errorf("ddsketch: accuracy %d basis points, want %d", data[1], accuracy)
+	}
+	r := &reader{data: data[headerSize:]}
+
+	decoded := Sketch{zero: r.uvarint()}
+	decoded.count = decoded.zero
+	n := r.uvarint()
+	if r.err == nil && n > uint64(len(r.data)) {
+		return errInvalid
+	}
+	if n > 0 {
+		decoded.bins = make(map[int32]uint64, n)
+	}
+
+	var i int64
+	for j := uint64(0); j < n && r.err == nil; j++ {
+		delta := r.varint()
+		if j > 0 && delta <= 0 {
+			return errInvalid
+		}
+		if i += delta; i < math.MinInt32 || i > math.MaxInt32 {
+			return errInvalid
+		}
+		count := r.uvarint()
+		if count == 0 {
+			return errInvalid
+		}
+		decoded.bins[int32(i)] = count
+		decoded.count += count
+	}
+	if r.err != nil || len(r.data) > 0 {
+		return errInvalid
+	}
+
+	*s = decoded
+	return nil
+}
+
+// reader decodes varints, until the first error.
+type reader struct {
+	data []byte
+	err  error
+}
+
+func (r *reader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	x, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err = errInvalid
+		return 0
+	}
+	r.data = r.data[n:]
+	return x
+}
+
+func (r *reader) varint() int64 {
+	if r.err != nil {
+		return 0
+	}
+	x, n := binary.Varint(r.data)
+	if n <= 0 {
+		r.err = errInvalid
+		return 0
+	}
+	r.data = r.data[n:]
+	return x
+}
@@ -0,0 +1,139 @@
+package ddsketch
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// sketchOf adds the durations from-to exclusive, in microseconds.
+func sketchOf(from, to int) *Sketch {
+	s := new(Sketch)
+	for i := from; i < to; i++ {
+		s.Add(time.Duration(i) * time.Microsecond)
+	}
+	return s
+}
+
+func TestSketch_Quantile(t *testing.T) {
+	s := sketchOf(1, 100001)
+	for _, q := range []float64{0, 0.01, 0.5, 0.95, 0.99, 1} {
+		t.Run(strconv.FormatFloat(q, 'f', -1, 64), func(t *testing.T) {
+			want := float64(time.Duration(1+math.Floor(q*99999)) * time.Microsecond)
+			got := float64(s.Quantile(q))
+			if math.Abs(got-want) > RelativeAccuracy*want {
+				t.Errorf("Sketch.Quantile() = %v, want %v", time.Duration(got), time.Duration(want))
+			}
+		})
+	}
+}
+
+func TestSketch_Quantile_zero(t *testing.T) {
+	var s Sketch
+	if got := s.Quantile(0.5); got != 0 {
+		t.Errorf("Sketch.Quantile() of empty sketch = %v, want 0", got)
+	}
+	s.Add(0)
+	s.Add(-time.Second)
+	s.Add(time.Second)
+	if got := s.Quantile(0.5); got != 0 {
+		t.Errorf("Sketch.Quantile(0.5) = %v, want 0", got)
+	}
+	if got := s.Quantile(1); math.Abs(float64(got-time.Second)) > RelativeAccuracy*float64(time.Second) {
+		t.Errorf("Sketch.Quantile(1) = %v, want %v", got, time.Second)
+	}
+	if got := s.Count(); got != 3 {
+		t.Errorf("Sketch.Count() = %d, want 3", got)
+	}
+}
+
+func TestSketch_Merge(t *testing.T) {
+	a, b := sketchOf(0, 6000), sketchOf(4000, 10000)
+	a.Merge(b)
+	a.Merge(new(Sketch))
+
+	want := sketchOf(0, 6000)
+	for i := 4000; i < 10000; i++ {
+		want.Add(time.Duration(i) * time.Microsecond)
+	}
+	got, _ := a.MarshalBinary()
+	wantData, _ := want.MarshalBinary()
+	if !bytes.Equal(got, wantData) {
+		t.Error("merged sketch differs from sketch of both sets")
+	}
+
+	var empty Sketch
+	empty.Merge(b)
+	got, _ = empty.MarshalBinary()
+	wantData, _ = b.MarshalBinary()
+	if !bytes.Equal(got, wantData) {
+		t.Error("merge into empty sketch differs from merged sketch")
+	}
+}
+
+func TestSketch_MarshalBinary(t *testing.T) {
+	one := new(Sketch)
+	one.Add(0)
+	one.Add(time.Nanosecond)
+	one.Add(2 * time.Nanosecond)
+
+	tests := []struct {
+		name   string
+		sketch *Sketch
+		want   []byte
+	}{
+		{"empty", new(Sketch), []byte{version, accuracy, 0, 0}},
+		// bins 0 and 35, which is the delta 35 zig-zag encoded as 70.
+		{"golden", one, []byte{version, accuracy, 1, 2, 0, 1, 70, 1}},
+		{"large", sketchOf(0, 100000), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.sketch.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want != nil && !bytes.Equal(data, tt.want) {
+				t.Errorf("Sketch.MarshalBinary() = %v, want %v", data, tt.want)
+			}
+
+			var got Sketch
+			if err = got.UnmarshalBinary(data); err != nil {
+				t.Fatal(err)
+			}
+			if got.Count() != tt.sketch.Count() {
+				t.Errorf("Sketch.UnmarshalBinary() count = %d, want %d", got.Count(), tt.sketch.Count())
+			}
+			for _, q := range []float64{0, 0.5, 0.99} {
+				if got.Quantile(q) != tt.sketch.Quantile(q) {
+					t.Errorf("Sketch.UnmarshalBinary() quantile %v = %v, want %v", q, got.Quantile(q), tt.sketch.Quantile(q))
+				}
+			}
+		})
+	}
+}
+
+func TestSketch_UnmarshalBinary_error(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"version", []byte{2, accuracy, 0, 0}},
+		{"accuracy", []byte{version, 200, 0, 0}},
+		{"truncated", []byte{version, accuracy, 0}},
+		{"bins length", []byte{version, accuracy, 0, 2, 0, 1}},
+		{"zero count", []byte{version, accuracy, 0, 1, 0, 0}},
+		{"unordered", []byte{version, accuracy, 0, 2, 2, 1, 0, 1}},
+		{"trailing", []byte{version, accuracy, 0, 0, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := new(Sketch).UnmarshalBinary(tt.data); err == nil {
+				t.Error("Sketch.UnmarshalBinary() err = nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+// Package hll implements HyperLogLog sketches, which estimate the
+// amount of distinct items in a set with a fixed amount of memory.
+// Sketches are merged by taking the maximum of each register,
+// so that sketches of days can be merged into sketches of longer periods.
+// The implementation and encoding do not depend on the database,
+// so sketches are the same on PostgreSQL and CockroachDB.
+package hll
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+// Precision is the amount of hash bits which select a register.
+// The 2^Precision registers give a standard error of
+// 1.04/sqrt(2^Precision), about 1.6%.
+const Precision = 12
+
+const (
+	registers = 1 << Precision
+
+	// maxRank of the remaining 64-Precision bits.
+	maxRank = 64 - Precision + 1
+
+	version     = 1
+	headerSize  = 3
+	sparseEntry = 3
+)
+
+// Sketch formats
+const (
+	sparse = iota
+	dense
+)
+
+// Hash returns the 64-bit hash of an item, such as a client ID.
+// The hash is stable across processes and versions.
+func Hash(item string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(item))
+
+	// FNV does not spread short inputs over the high bits,
+	// which select the register. Mix them with the
+	// finalizer of MurmurHash3.
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+// Sketch is a HyperLogLog sketch.
+// The zero value is an empty sketch.
+type Sketch struct {
+	// registers is nil until the first item is added.
+	registers []uint8
+}
+
+func (s *Sketch) init() {
+	if s.registers == nil {
+		s.registers = make([]uint8, registers)
+	}
+}
+
+// Add the hash of an item, as returned by Hash.
+func (s *Sketch) Add(hash uint64) {
+	s.init()
+
+	idx := hash >> (64 - Precision)
+	rank := uint8(bits.LeadingZeros64(hash<<Precision|1<<(Precision-1))) + 1
+	if rank > s.registers[idx] {
+		s.registers[idx] = rank
+	}
+}
+
+// Merge o into s, so that s estimates the union of both sets.
+func (s *Sketch) Merge(o *Sketch) {
+	if o.Empty() {
+		return
+	}
+	s.init()
+
+	for i, r := range o.registers {
+		if r > s.registers[i] {
+			s.registers[i] = r
+		}
+	}
+}
+
+// Empty reports whether no items were added.
+func (s *Sketch) Empty() bool {
+	return s == nil || s.registers == nil
+}
+
+// Estimate returns the approximate amount of distinct items.
+func (s *Sketch) Estimate() int64 {
+	if s.Empty() {
+		return 0
+	}
+
+	var (
+		sum   float64
+		zeros int
+	)
+	for _, r := range s.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	const m = float64(registers)
+	alpha := 0.7213 / (1 + 1.079/m)
+	estimate := alpha * m * m / sum
+
+	// linear counting is more accurate for small sets.
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return int64(math.Round(estimate))
+}
+
+// MarshalBinary encodes the sketch.
+// Sketches with few items use a sparse encoding
+// of the non-zero registers.
+func (s *Sketch) MarshalBinary() ([]byte, error) {
+	var set int
+	if !s.Empty() {
+		for _, r := range s.registers {
+			if r != 0 {
+				set++
+			}
+		}
+	}
+
+	if set*sparseEntry >= registers {
+		data := make([]byte, headerSize, headerSize+registers)
+		data[0], data[1], data[2] = version, Precision, dense
+		return append(data, s.registers...), nil
+	}
+
+	data := make([]byte, headerSize, headerSize+set*sparseEntry)
+	data[0], data[1], data[2] = version, Precision, sparse
+	for i := 0; set > 0; i++ {
+		if r := s.registers[i]; r != 0 {
+			data = binary.BigEndian.AppendUint16(data, uint16(i))
+			data = append(data, r)
+			set--
+		}
+	}
+	return data, nil
+}
+
+var errInvalid = errors.New("hll: invalid sketch")
+
+// UnmarshalBinary decodes a sketch encoded by MarshalBinary.
+func (s *Sketch) UnmarshalBinary(data []byte) error {
+	if len(data) < headerSize || data[0] != version {
+		return errInvalid
+	}
+	if data[1] != Precision {
+		return fmt.Errorf("hll: precision %d, want %d", data[1], Precision)
+	}
+	body := data[headerSize:]
+
+	var regs []uint8
+	switch data[2] {
+	case dense:
+		if len(body) != registers {
+			return errInvalid
+		}
+		regs = make([]uint8, registers)
+		copy(regs, body)
+	case sparse:
+		if len(body)%sparseEntry != 0 {
+			return errInvalid
+		}
+		if len(body) > 0 {
+			regs = make([]uint8, registers)
+		}
+		for ; len(body) > 0; body = body[sparseEntry:] {
+			idx := binary.BigEndian.Uint16(body)
+			if idx >= registers {
+				return errInvalid
+			}
+			regs[idx] = body[2]
+		}
+	default:
+		return errInvalid
+	}
+
+	for _, r := range regs {
+		if r > maxRank {
+			return errInvalid
+		}
+	}
+	s.registers = regs
+	return nil
+}
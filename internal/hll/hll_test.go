@@ -0,0 +1,118 @@
+package hll
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	if Hash("10.0.0.1") != Hash("10.0.0.1") {
+		t.Error("Hash() not stable")
+	}
+	if Hash("10.0.0.1") == Hash("10.0.0.2") {
+		t.Error("Hash() collision")
+	}
+	// sketches stored in the database depend on the hash.
+	const want = uint64(0xaf85ea5569581d4c)
+	if got := Hash("foo"); got != want {
+		t.Errorf("Hash(foo) = %#016x, want %#016x", got, want)
+	}
+}
+
+func sketchOf(from, to int) *Sketch {
+	s := new(Sketch)
+	for i := from; i < to; i++ {
+		s.Add(Hash(strconv.Itoa(i)))
+	}
+	return s
+}
+
+func TestSketch_Estimate(t *testing.T) {
+	for _, n := range []int{0, 1, 10, 100, 1000, 10000, 100000, 1000000} {
+		t.Run(strconv.Itoa(n), func(t *testing.T) {
+			got := sketchOf(0, n).Estimate()
+
+			// 4 times the standard error of 1.6%,
+			// which is exceeded with a very small chance.
+			if diff := math.Abs(float64(got - int64(n))); diff > 0.065*float64(n) {
+				t.Errorf("Sketch.Estimate() = %d, want %d", got, n)
+			}
+		})
+	}
+}
+
+func TestSketch_Merge(t *testing.T) {
+	a, b := sketchOf(0, 6000), sketchOf(4000, 10000)
+	a.Merge(b)
+	a.Merge(new(Sketch))
+
+	if want := sketchOf(0, 10000); !bytes.Equal(a.registers, want.registers) {
+		t.Error("merged sketch differs from sketch of the union")
+	}
+
+	var empty Sketch
+	empty.Merge(b)
+	if !bytes.Equal(empty.registers, b.registers) {
+		t.Error("merge into empty sketch differs from merged sketch")
+	}
+}
+
+func TestSketch_MarshalBinary(t *testing.T) {
+	tests := []struct {
+		name       string
+		sketch     *Sketch
+		wantFormat byte
+		wantLen    int
+	}{
+		{"empty", new(Sketch), sparse, headerSize},
+		{"sparse", sketchOf(0, 100), sparse, 0},
+		{"dense", sketchOf(0, 100000), dense, headerSize + registers},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.sketch.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if data[2] != tt.wantFormat {
+				t.Errorf("Sketch.MarshalBinary() format = %d, want %d", data[2], tt.wantFormat)
+			}
+			if tt.wantLen > 0 && len(data) != tt.wantLen {
+				t.Errorf("Sketch.MarshalBinary() len = %d, want %d", len(data), tt.wantLen)
+			}
+
+			var got Sketch
+			if err = got.UnmarshalBinary(data); err != nil {
+				t.Fatal(err)
+			}
+			if got.Estimate() != tt.sketch.Estimate() {
+				t.Errorf("Sketch.UnmarshalBinary() estimate = %d, want %d", got.Estimate(), tt.sketch.Estimate())
+			}
+		})
+	}
+}
+
+func TestSketch_UnmarshalBinary_error(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"version", []byte{2, Precision, sparse}},
+		{"precision", []byte{version, 14, sparse}},
+		{"format", []byte{version, Precision, 2}},
+		{"sparse length", []byte{version, Precision, sparse, 0, 1}},
+		{"sparse index", []byte{version, Precision, sparse, 0xff, 0xff, 1}},
+		{"rank", []byte{version, Precision, sparse, 0, 1, maxRank + 1}},
+		{"dense length", []byte{version, Precision, dense, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := new(Sketch).UnmarshalBinary(tt.data); err == nil {
+				t.Error("Sketch.UnmarshalBinary() err = nil")
+			}
+		})
+	}
+}
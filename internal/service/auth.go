@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// APIKeyPrefix is the prefix of generated API keys,
+// which makes them recognizable, for example by secret scanners.
+const APIKeyPrefix = "count_"
+
+// HashAPIKey returns the hash by which key is stored.
+// Keys are random and long, so a fast hash is sufficient.
+func HashAPIKey(key string) []byte {
+	sum := sha256.Sum256([]byte(key))
+	return sum[:]
+}
+
+// checkScopes returns an InvalidArgument error
+// when scopes is empty or contains an invalid scope.
+func checkScopes(scopes []countv1.Scope) error {
+	if len(scopes) == 0 {
+		return status.Error(codes.InvalidArgument, "scopes required")
+	}
+	for _, s := range scopes {
+		if _, ok := countv1.Scope_name[int32(s)]; !ok || s == countv1.Scope_SCOPE_UNSPECIFIED {
+			return status.Errorf(codes.InvalidArgument, "invalid scope %s", s)
+		}
+	}
+	return nil
+}
+
+// NewAPIKey generates a key and stores its hash in keys.
+// The key is bound to service, unless it is empty.
+// The key is returned, as it can not be retrieved later.
+func NewAPIKey(ctx context.Context, keys store.KeyStore, name, service string, scopes []countv1.Scope) (string, *countv1.ApiKey, error) {
+	if name == "" {
+		return "", nil, status.Error(codes.InvalidArgument, "name required")
+	}
+	if err := checkScopes(scopes); err != nil {
+		return "", nil, err
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, status.Errorf(codes.Internal, "generate api key: %v", err)
+	}
+	key := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	apiKey, err := keys.CreateAPIKey(ctx, name, service, scopes, HashAPIKey(key))
+	if err != nil {
+		return "", nil, err
+	}
+	return key, apiKey, nil
+}
+
+const servicePrefix = "/count.v1.CountService/"
+
+// methodScopes defines the scope required for each RPC.
+// RPCs which are not listed require SCOPE_ADMIN.
+var methodScopes = map[string]countv1.Scope{
+	servicePrefix + "Add":               countv1.Scope_SCOPE_INGEST,
+	servicePrefix + "ImportDailyTotals": countv1.Scope_SCOPE_INGEST,
+	servicePrefix + "ListDailyTotals":   countv1.Scope_SCOPE_READ,
+	servicePrefix + "GetPeriodTotals":   countv1.Scope_SCOPE_READ,
+	servicePrefix + "GetLatencyStats":   countv1.Scope_SCOPE_READ,
+	servicePrefix + "ComparePeriods":    countv1.Scope_SCOPE_READ,
+	servicePrefix + "GetTimeSeries":     countv1.Scope_SCOPE_READ,
+	servicePrefix + "ExportTotals":      countv1.Scope_SCOPE_READ,
+	servicePrefix + "GetQuota":          countv1.Scope_SCOPE_READ,
+}
+
+// requiredScope returns the scope required to call fullMethod.
+func requiredScope(fullMethod string) countv1.Scope {
+	if scope, ok := methodScopes[fullMethod]; ok {
+		return scope
+	}
+	return countv1.Scope_SCOPE_ADMIN
+}
+
+// hasScope reports whether key grants scope.
+// The admin scope grants all scopes.
+func hasScope(key *countv1.ApiKey, scope countv1.Scope) bool {
+	for _, s := range key.GetScopes() {
+		if s == scope || s == countv1.Scope_SCOPE_ADMIN {
+			return true
+		}
+	}
+	return false
+}
+
+type apiKeyCtxKey struct{}
+
+// APIKeyFromContext returns the API key which authenticated the RPC of ctx.
+func APIKeyFromContext(ctx context.Context) (*countv1.ApiKey, bool) {
+	key, ok := ctx.Value(apiKeyCtxKey{}).(*countv1.ApiKey)
+	return key, ok
+}
+
+// bearerToken returns the token from the authorization metadata.
+func bearerToken(ctx context.Context) (string, bool) {
+	for _, v := range metadata.ValueFromIncomingContext(ctx, "authorization") {
+		scheme, token, ok := strings.Cut(v, " ")
+		if ok && strings.EqualFold(scheme, "bearer") && token != "" {
+			return token, true
+		}
+	}
+	return "", false
+}
+
+// Authenticator provides interceptors which authenticate
+// RPCs by an API key, sent as bearer token in the
+// authorization metadata, and check its scopes.
+type Authenticator struct {
+	keys store.KeyStore
+}
+
+// NewAuthenticator returns an Authenticator which looks up API keys in keys.
+func NewAuthenticator(keys store.KeyStore) *Authenticator {
+	return &Authenticator{keys: keys}
+}
+
+// authenticate the RPC of fullMethod and return a context
+// with the API key and a logger with its id.
+func (a *Authenticator) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	token, ok := bearerToken(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "api key required in authorization metadata")
+	}
+
+	key, err := a.keys.LookupAPIKey(ctx, HashAPIKey(token))
+	if status.Code(err) == codes.NotFound {
+		return nil, status.Error(codes.Unauthenticated, "invalid or revoked api key")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	scope := requiredScope(fullMethod)
+	if !hasScope(key, scope) {
+		return nil, status.Errorf(codes.PermissionDenied, "api key %d lacks scope %s", key.GetId(), scope)
+	}
+	// admin RPCs span all services, which a bound key may not access.
+	if scope == countv1.Scope_SCOPE_ADMIN && key.GetService() != "" {
+		return nil, status.Errorf(codes.PermissionDenied, "api key %d is bound to service %q", key.GetId(), key.GetService())
+	}
+
+	logger := zerolog.Ctx(ctx).With().Int64("api_key_id", key.GetId()).Logger()
+	ctx = logger.WithContext(ctx)
+	return context.WithValue(ctx, apiKeyCtxKey{}, key), nil
+}
+
+// UnaryInterceptor authenticates unary RPCs.
+func (a *Authenticator) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor authenticates streaming RPCs.
+func (a *Authenticator) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStreamCtx{
+			ServerStream: ss,
+			ctx:          ctx,
+		})
+	}
+}
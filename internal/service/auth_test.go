@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	ctx := context.Background()
+	mem := store.NewMemory()
+
+	tests := []struct {
+		name     string
+		keyName  string
+		scopes   []countv1.Scope
+		wantCode codes.Code
+	}{
+		{"no name", "", []countv1.Scope{countv1.Scope_SCOPE_READ}, codes.InvalidArgument},
+		{"no scopes", "foo", nil, codes.InvalidArgument},
+		{"unspecified scope", "foo", []countv1.Scope{countv1.Scope_SCOPE_UNSPECIFIED}, codes.InvalidArgument},
+		{"unknown scope", "foo", []countv1.Scope{99}, codes.InvalidArgument},
+		{"ok", "foo", []countv1.Scope{countv1.Scope_SCOPE_READ}, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, apiKey, err := NewAPIKey(ctx, mem, tt.keyName, "", tt.scopes)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("NewAPIKey() err = %v, want code %s", err, tt.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			if !strings.HasPrefix(key, APIKeyPrefix) || len(key) != len(APIKeyPrefix)+43 {
+				t.Errorf("NewAPIKey() key = %q", key)
+			}
+			got, err := mem.LookupAPIKey(ctx, HashAPIKey(key))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.GetId() != apiKey.GetId() {
+				t.Errorf("LookupAPIKey() = %v, want %v", got, apiKey)
+			}
+		})
+	}
+}
+
+func TestAuthenticator(t *testing.T) {
+	ctx := context.Background()
+	mem := store.NewMemory()
+
+	newKey := func(service string, scopes ...countv1.Scope) string {
+		key, _, err := NewAPIKey(ctx, mem, "test", service, scopes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return key
+	}
+	var (
+		ingest  = newKey("", countv1.Scope_SCOPE_INGEST)
+		read    = newKey("", countv1.Scope_SCOPE_READ)
+		admin   = newKey("", countv1.Scope_SCOPE_ADMIN)
+		revoked = newKey("", countv1.Scope_SCOPE_ADMIN)
+		bound   = newKey("shop", countv1.Scope_SCOPE_ADMIN)
+	)
+	if _, err := mem.RevokeAPIKey(ctx, 4); err != nil {
+		t.Fatal(err)
+	}
+
+	a := NewAuthenticator(mem)
+	unary := a.UnaryInterceptor()
+	stream := a.StreamInterceptor()
+
+	tests := []struct {
+		name     string
+		auth     string
+		method   string
+		wantCode codes.Code
+	}{
+		{"no key", "", "ListDailyTotals", codes.Unauthenticated},
+		{"basic auth", "Basic " + read, "ListDailyTotals", codes.Unauthenticated},
+		{"unknown key", "Bearer foo", "ListDailyTotals", codes.Unauthenticated},
+		{"revoked key", "Bearer " + revoked, "ListDailyTotals", codes.Unauthenticated},
+		{"read", "Bearer " + read, "ListDailyTotals", codes.OK},
+		{"read lowercase scheme", "bearer " + read, "GetPeriodTotals", codes.OK},
+		{"read count", "Bearer " + read, "CountDailyTotals", codes.PermissionDenied},
+		{"read add", "Bearer " + read, "Add", codes.PermissionDenied},
+		{"ingest add", "Bearer " + ingest, "Add", codes.OK},
+		{"ingest import", "Bearer " + ingest, "ImportDailyTotals", codes.OK},
+		{"ingest export", "Bearer " + ingest, "ExportTotals", codes.PermissionDenied},
+		{"admin export", "Bearer " + admin, "ExportTotals", codes.OK},
+		{"admin create key", "Bearer " + admin, "CreateApiKey", codes.OK},
+		{"read create key", "Bearer " + read, "CreateApiKey", codes.PermissionDenied},
+		{"bound read", "Bearer " + bound, "ListDailyTotals", codes.OK},
+		{"bound count", "Bearer " + bound, "CountDailyTotals", codes.PermissionDenied},
+		{"bound create key", "Bearer " + bound, "CreateApiKey", codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := ctx
+			if tt.auth != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.auth))
+			}
+			fullMethod := servicePrefix + tt.method
+
+			// key in the handler's context
+			checkKey := func(ctx context.Context) error {
+				if _, ok := APIKeyFromContext(ctx); !ok {
+					t.Error("APIKeyFromContext() no key")
+				}
+				return nil
+			}
+
+			_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: fullMethod}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, checkKey(ctx)
+			})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("UnaryInterceptor() err = %v, want code %s", err, tt.wantCode)
+			}
+
+			err = stream(nil, &mockExportServer{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: fullMethod}, func(srv interface{}, ss grpc.ServerStream) error {
+				return checkKey(ss.Context())
+			})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("StreamInterceptor() err = %v, want code %s", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_requestService(t *testing.T) {
+	unbound := context.WithValue(context.Background(), apiKeyCtxKey{}, &countv1.ApiKey{Id: 1})
+	bound := context.WithValue(context.Background(), apiKeyCtxKey{}, &countv1.ApiKey{Id: 2, Service: "shop"})
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		service  string
+		want     string
+		wantCode codes.Code
+	}{
+		{"no key default", context.Background(), "", store.DefaultService, codes.OK},
+		{"no key", context.Background(), "blog", "blog", codes.OK},
+		{"unbound default", unbound, "", store.DefaultService, codes.OK},
+		{"unbound", unbound, "blog", "blog", codes.OK},
+		{"bound empty", bound, "", "shop", codes.OK},
+		{"bound same", bound, "shop", "shop", codes.OK},
+		{"bound other", bound, "blog", "", codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := requestService(tt.ctx, tt.service)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("requestService() err = %v, want code %s", err, tt.wantCode)
+			}
+			if got != tt.want {
+				t.Errorf("requestService() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package service
+
+import (
+	"container/list"
+	"context"
+	"expvar"
+	"sync"
+	"time"
+
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Cache metrics, published under "count_service".
+var (
+	cacheHits      = new(expvar.Int)
+	cacheMisses    = new(expvar.Int)
+	cacheEvictions = new(expvar.Int)
+	cacheBytes     = new(expvar.Int)
+)
+
+func init() {
+	metrics.Set("cache_hits", cacheHits)
+	metrics.Set("cache_misses", cacheMisses)
+	metrics.Set("cache_evictions", cacheEvictions)
+	metrics.Set("cache_bytes", cacheBytes)
+}
+
+// DefaultCacheTTL is the default CachePolicy.TTL.
+const DefaultCacheTTL = 10 * time.Minute
+
+// CachePolicy configures the cache of ListDailyTotals and GetPeriodTotals results.
+// Only results of intervals which end before today are cached.
+// Entries are invalidated when CountDailyTotals, ImportDailyTotals or Prune
+// of this server write daily totals in their interval.
+// Writes by other servers or by the count import command are not seen by
+// the cache: their results are only visible after cached entries expire,
+// so their staleness is bounded by the TTL.
+// Requests with strong freshness bypass the cache, but update it.
+// Misses of intervals which were invalidated less than TTL ago
+// are read with strong freshness, as a lagging replica
+// may not have the written totals yet.
+type CachePolicy struct {
+	// MaxBytes is the estimated size of all cached results,
+	// after which the least recently used results are evicted.
+	// Zero disables the cache.
+	MaxBytes int64
+
+	// TTL of cached results. Defaults to DefaultCacheTTL.
+	TTL time.Duration
+}
+
+// WithCache sets the cache policy. The cache is disabled by default.
+func WithCache(policy CachePolicy) Option {
+	return func(s *CountServer) {
+		s.cache = newResponseCache(policy)
+	}
+}
+
+// dayLayout formats dates of cache keys,
+// which sort in the order of the dates.
+const dayLayout = "2006-01-02"
+
+type cacheKey struct {
+	rpc         string
+	service     string
+	labels      string
+	first, last string
+}
+
+// invalidation is an interval of dates, as in cacheKey,
+// of which the daily totals were written at a time.
+// service is empty for all services and first for all dates up to last.
+type invalidation struct {
+	service     string
+	first, last string
+	at          time.Time
+}
+
+type cacheEntry struct {
+	key     cacheKey
+	counts  []*countv1.MethodCount
+	size    int64
+	expires time.Time
+}
+
+// responseCache is a LRU cache of query results.
+// A nil cache is disabled.
+type responseCache struct {
+	policy CachePolicy
+
+	mu      sync.Mutex
+	bytes   int64
+	entries map[cacheKey]*list.Element
+	lru     *list.List // of *cacheEntry, most recently used first.
+
+	// gen is incremented by invalidate, so that results
+	// read before an invalidation are not stored afterwards.
+	gen uint64
+
+	// invalidations of the last TTL, oldest first.
+	invalidations []invalidation
+}
+
+func newResponseCache(policy CachePolicy) *responseCache {
+	if policy.TTL <= 0 {
+		policy.TTL = DefaultCacheTTL
+	}
+	return &responseCache{
+		policy:  policy,
+		entries: make(map[cacheKey]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+func (c *responseCache) enabled() bool {
+	return c != nil && c.policy.MaxBytes > 0
+}
+
+// entrySize estimates the memory used by an entry.
+func entrySize(key cacheKey, counts []*countv1.MethodCount) int64 {
+	const overhead = 64 // pointers and headers per item
+	size := int64(overhead + len(key.rpc) + len(key.service) + len(key.labels) + len(key.first) + len(key.last))
+	for _, mc := range counts {
+		size += overhead + int64(proto.Size(mc))
+	}
+	return size
+}
+
+// generation returns the current generation, to be passed to put.
+func (c *responseCache) generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.gen
+}
+
+// get returns a copy of the cached result of key.
+func (c *responseCache) get(key cacheKey, now time.Time) ([]*countv1.MethodCount, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if ok {
+		if entry := elem.Value.(*cacheEntry); !now.Before(entry.expires) {
+			c.remove(elem)
+			ok = false
+		}
+	}
+	if !ok {
+		cacheMisses.Add(1)
+		return nil, false
+	}
+	cacheHits.Add(1)
+	c.lru.MoveToFront(elem)
+
+	cached := elem.Value.(*cacheEntry).counts
+	counts := make([]*countv1.MethodCount, len(cached))
+	for i, mc := range cached {
+		counts[i] = proto.Clone(mc).(*countv1.MethodCount)
+	}
+	return counts, true
+}
+
+// put stores a copy of counts for key, unless the cache was
+// invalidated since gen was obtained.
+// Least recently used entries are evicted to stay within MaxBytes.
+func (c *responseCache) put(key cacheKey, counts []*countv1.MethodCount, gen uint64, now time.Time) {
+	size := entrySize(key, counts)
+	if size > c.policy.MaxBytes {
+		return
+	}
+
+	entry := &cacheEntry{
+		key:     key,
+		counts:  make([]*countv1.MethodCount, len(counts)),
+		size:    size,
+		expires: now.Add(c.policy.TTL),
+	}
+	for i, mc := range counts {
+		entry.counts[i] = proto.Clone(mc).(*countv1.MethodCount)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if gen != c.gen {
+		return
+	}
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	c.bytes += size
+	cacheBytes.Add(size)
+
+	for c.bytes > c.policy.MaxBytes {
+		c.remove(c.lru.Back())
+		cacheEvictions.Add(1)
+	}
+}
+
+// remove elem, the caller must hold mu.
+func (c *responseCache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.bytes -= entry.size
+	cacheBytes.Add(-entry.size)
+}
+
+// invalidate removes the entries of service which overlap with
+// the dates first and last inclusive. Entries of all services
+// are removed when service is empty, and first may be zero
+// to remove all entries up to last.
+func (c *responseCache) invalidate(service string, first, last time.Time) {
+	if !c.enabled() {
+		return
+	}
+	var from string
+	if !first.IsZero() {
+		from = first.Format(dayLayout)
+	}
+	to := last.Format(dayLayout)
+
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	c.expireInvalidations(now)
+	c.invalidations = append(c.invalidations, invalidation{service, from, to, now})
+	for key, elem := range c.entries {
+		if (service == "" || key.service == service) && key.first <= to && key.last >= from {
+			c.remove(elem)
+		}
+	}
+}
+
+// expireInvalidations removes invalidations older than TTL,
+// the caller must hold mu.
+func (c *responseCache) expireInvalidations(now time.Time) {
+	var i int
+	for i < len(c.invalidations) && !now.Before(c.invalidations[i].at.Add(c.policy.TTL)) {
+		i++
+	}
+	c.invalidations = c.invalidations[i:]
+}
+
+// invalidated reports whether the interval of key was
+// invalidated less than TTL before now.
+func (c *responseCache) invalidated(key cacheKey, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expireInvalidations(now)
+	for _, inv := range c.invalidations {
+		if (inv.service == "" || inv.service == key.service) && key.first <= inv.last && key.last >= inv.first {
+			return true
+		}
+	}
+	return false
+}
+
+// cachedTotals returns the result of query for service between the dates
+// of start and end inclusive from the cache, when the interval ends before today.
+// Otherwise, and on a cache miss, query is called and its result is cached.
+// Misses of recently invalidated intervals are read with strong freshness,
+// so that the cache is not filled from a lagging replica.
+// Results are cached separately for each label query q.
+func (s *CountServer) cachedTotals(ctx context.Context, rpc, service string, q store.LabelQuery, start, end time.Time, query func(ctx context.Context, service string, start, end time.Time) ([]*countv1.MethodCount, error)) ([]*countv1.MethodCount, error) {
+	now := time.Now()
+	today := now.In(s.loc).Format(dayLayout)
+	key := cacheKey{
+		rpc:     rpc,
+		service: service,
+		labels:  q.String(),
+		first:   start.Format(dayLayout),
+		last:    end.Format(dayLayout),
+	}
+	if !s.cache.enabled() || key.last >= today {
+		return query(ctx, service, start, end)
+	}
+
+	if store.FreshnessFromContext(ctx) != store.Strong {
+		if counts, ok := s.cache.get(key, now); ok {
+			return counts, nil
+		}
+		if s.cache.invalidated(key, now) {
+			ctx = store.WithFreshness(ctx, store.Strong)
+		}
+	}
+
+	gen := s.cache.generation()
+	counts, err := query(ctx, service, start, end)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.put(key, counts, gen, now)
+	return counts, nil
+}
@@ -0,0 +1,331 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/protobuf/proto"
+)
+
+func testCacheKey(service, first, last string) cacheKey {
+	return cacheKey{rpc: "ListDailyTotals", service: service, first: first, last: last}
+}
+
+func Test_responseCache(t *testing.T) {
+	now := time.Date(2022, 10, 17, 12, 0, 0, 0, time.UTC)
+	counts := []*countv1.MethodCount{{Method: countv1.Method_GET, Path: "/foo", Count: 1}}
+	key := testCacheKey("default", "2022-10-01", "2022-10-16")
+
+	c := newResponseCache(CachePolicy{MaxBytes: 2 * entrySize(key, counts), TTL: time.Hour})
+	if _, ok := c.get(key, now); ok {
+		t.Fatal("responseCache.get() hit on empty cache")
+	}
+
+	c.put(key, counts, c.generation(), now)
+	counts[0].Count = 2
+	got, ok := c.get(key, now)
+	if !ok {
+		t.Fatal("responseCache.get() miss")
+	}
+	if got[0].GetCount() != 1 {
+		t.Errorf("responseCache.get() = %v, want a copy of the put counts", got)
+	}
+	got[0].Count = 3
+	if got, _ = c.get(key, now); got[0].GetCount() != 1 {
+		t.Errorf("responseCache.get() = %v, want a copy of the cached counts", got)
+	}
+
+	if _, ok = c.get(key, now.Add(time.Hour)); ok {
+		t.Error("responseCache.get() hit after TTL")
+	}
+	if c.bytes != 0 || len(c.entries) != 0 {
+		t.Errorf("responseCache bytes = %d, entries = %d after expiry", c.bytes, len(c.entries))
+	}
+
+	gen := c.generation()
+	c.invalidate("", time.Time{}, now)
+	c.put(key, counts, gen, now)
+	if _, ok = c.get(key, now); ok {
+		t.Error("responseCache.get() hit for a result read before invalidation")
+	}
+}
+
+func Test_responseCache_evict(t *testing.T) {
+	now := time.Date(2022, 10, 17, 12, 0, 0, 0, time.UTC)
+	counts := []*countv1.MethodCount{{Method: countv1.Method_GET, Path: "/foo", Count: 1}}
+	var (
+		a = testCacheKey("default", "2022-10-01", "2022-10-01")
+		b = testCacheKey("default", "2022-10-02", "2022-10-02")
+		d = testCacheKey("default", "2022-10-03", "2022-10-03")
+	)
+
+	c := newResponseCache(CachePolicy{MaxBytes: 2 * entrySize(a, counts)})
+	if c.policy.TTL != DefaultCacheTTL {
+		t.Errorf("responseCache TTL = %v, want %v", c.policy.TTL, DefaultCacheTTL)
+	}
+	c.put(a, counts, c.generation(), now)
+	c.put(b, counts, c.generation(), now)
+	c.get(a, now) // b is now least recently used
+	c.put(d, counts, c.generation(), now)
+
+	for key, want := range map[cacheKey]bool{a: true, b: false, d: true} {
+		if _, ok := c.get(key, now); ok != want {
+			t.Errorf("responseCache.get(%v) = %v, want %v", key, ok, want)
+		}
+	}
+	if c.bytes > c.policy.MaxBytes {
+		t.Errorf("responseCache bytes = %d, exceeds %d", c.bytes, c.policy.MaxBytes)
+	}
+
+	large := make([]*countv1.MethodCount, 10)
+	for i := range large {
+		large[i] = counts[0]
+	}
+	c.put(b, large, c.generation(), now)
+	if _, ok := c.get(b, now); ok {
+		t.Error("responseCache.get() hit for a result larger than MaxBytes")
+	}
+}
+
+func Test_responseCache_invalidate(t *testing.T) {
+	now := time.Date(2022, 10, 17, 12, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2022, 10, d, 0, 0, 0, 0, time.UTC) }
+
+	var (
+		october = testCacheKey("default", "2022-10-01", "2022-10-31")
+		week    = testCacheKey("default", "2022-10-10", "2022-10-16")
+		shop    = testCacheKey("shop", "2022-10-10", "2022-10-16")
+		first   = testCacheKey("default", "2022-10-01", "2022-10-01")
+	)
+
+	tests := []struct {
+		name        string
+		service     string
+		first, last time.Time
+		want        map[cacheKey]bool
+	}{
+		{"day", "default", day(5), day(5), map[cacheKey]bool{october: false, week: true, shop: true, first: true}},
+		{"all services", "", day(16), day(16), map[cacheKey]bool{october: false, week: false, shop: false, first: true}},
+		{"before", "", time.Time{}, day(1), map[cacheKey]bool{october: false, week: true, shop: true, first: false}},
+		{"other month", "", day(1).AddDate(0, 1, 0), day(1).AddDate(0, 1, 0), map[cacheKey]bool{october: true, week: true, shop: true, first: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newResponseCache(CachePolicy{MaxBytes: 1 << 20})
+			for key := range tt.want {
+				c.put(key, nil, c.generation(), now)
+			}
+			c.invalidate(tt.service, tt.first, tt.last)
+			for key, want := range tt.want {
+				if _, ok := c.entries[key]; ok != want {
+					t.Errorf("entry %v cached = %v, want %v", key, ok, want)
+				}
+			}
+		})
+	}
+}
+
+func Test_responseCache_invalidated(t *testing.T) {
+	c := newResponseCache(CachePolicy{MaxBytes: 1 << 20, TTL: time.Hour})
+	day := time.Date(2022, 10, 5, 0, 0, 0, 0, time.UTC)
+	c.invalidate("default", day, day)
+
+	now := time.Now()
+	tests := []struct {
+		name string
+		key  cacheKey
+		now  time.Time
+		want bool
+	}{
+		{"overlap", testCacheKey("default", "2022-10-01", "2022-10-31"), now, true},
+		{"other service", testCacheKey("shop", "2022-10-01", "2022-10-31"), now, false},
+		{"other interval", testCacheKey("default", "2022-10-06", "2022-10-31"), now, false},
+		{"expired", testCacheKey("default", "2022-10-01", "2022-10-31"), now.Add(time.Hour), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.invalidated(tt.key, tt.now); got != tt.want {
+				t.Errorf("responseCache.invalidated() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if len(c.invalidations) != 0 {
+		t.Errorf("responseCache invalidations = %v, want expired", c.invalidations)
+	}
+}
+
+// countingStore counts the reads of daily totals,
+// and those with strong freshness.
+type countingStore struct {
+	store.Store
+	reads, strong int
+}
+
+func (s *countingStore) read(ctx context.Context) {
+	s.reads++
+	if store.FreshnessFromContext(ctx) == store.Strong {
+		s.strong++
+	}
+}
+
+func (s *countingStore) ListDailyTotals(ctx context.Context, service string, start, end time.Time) ([]*countv1.MethodCount, error) {
+	s.read(ctx)
+	return s.Store.ListDailyTotals(ctx, service, start, end)
+}
+
+func (s *countingStore) GetPeriodTotals(ctx context.Context, service string, start, end time.Time) ([]*countv1.MethodCount, error) {
+	s.read(ctx)
+	return s.Store.GetPeriodTotals(ctx, service, start, end)
+}
+
+func (s *countingStore) ImportDailyTotals(ctx context.Context, service string, totals []*countv1.MethodCount, mode store.ImportMode) error {
+	return s.Store.(store.Importer).ImportDailyTotals(ctx, service, totals, mode)
+}
+
+func TestCountServer_cache(t *testing.T) {
+	ctx := context.Background()
+	day := &date.Date{Year: 2022, Month: 10, Day: 16}
+
+	cs := &countingStore{Store: store.NewMemory()}
+	s := &CountServer{store: cs, loc: time.UTC}
+	WithCache(CachePolicy{MaxBytes: 1 << 20})(s)
+
+	importReq := &countv1.ImportDailyTotalsRequest{
+		MethodCounts: []*countv1.MethodCount{{Date: day, Method: countv1.Method_GET, Path: "/foo", Count: 1}},
+	}
+	if _, err := s.ImportDailyTotals(ctx, importReq); err != nil {
+		t.Fatal(err)
+	}
+
+	listReq := &countv1.ListDailyTotalsRequest{StartDate: &date.Date{Year: 2022, Month: 10, Day: 1}, EndDate: day}
+	periodReq := &countv1.GetPeriodTotalsRequest{
+		PeriodType: &countv1.GetPeriodTotalsRequest_Period{Period: &date.Date{Year: 2022, Month: 10}},
+	}
+	strongReq := proto.Clone(listReq).(*countv1.ListDailyTotalsRequest)
+	strongReq.Freshness = countv1.Freshness_FRESHNESS_STRONG
+	today := datepb.Today()
+	todayReq := &countv1.ListDailyTotalsRequest{StartDate: day, EndDate: today}
+
+	wantCount := func(t *testing.T, name string, counts []*countv1.MethodCount, want int64) {
+		t.Helper()
+		if len(counts) != 1 || counts[0].GetCount() != want {
+			t.Errorf("%s = %v, want count %d", name, counts, want)
+		}
+	}
+
+	// misses are read with strong freshness, as the import invalidated the interval.
+	tests := []struct {
+		name       string
+		call       func() ([]*countv1.MethodCount, error)
+		wantReads  int
+		wantStrong int
+		wantCount  int64
+	}{
+		{
+			name: "list miss",
+			call: func() ([]*countv1.MethodCount, error) {
+				resp, err := s.ListDailyTotals(ctx, listReq)
+				return resp.GetMethodCounts(), err
+			},
+			wantStrong: 1,
+			wantReads:  1,
+			wantCount:  1,
+		},
+		{
+			name: "list hit",
+			call: func() ([]*countv1.MethodCount, error) {
+				resp, err := s.ListDailyTotals(ctx, listReq)
+				return resp.GetMethodCounts(), err
+			},
+			wantStrong: 1,
+			wantReads:  1,
+			wantCount:  1,
+		},
+		{
+			name: "period miss",
+			call: func() ([]*countv1.MethodCount, error) {
+				resp, err := s.GetPeriodTotals(ctx, periodReq)
+				return resp.GetMethodCounts(), err
+			},
+			wantStrong: 2,
+			wantReads:  2,
+			wantCount:  1,
+		},
+		{
+			name: "strong",
+			call: func() ([]*countv1.MethodCount, error) {
+				resp, err := s.ListDailyTotals(ctx, strongReq)
+				return resp.GetMethodCounts(), err
+			},
+			wantStrong: 3,
+			wantReads:  3,
+			wantCount:  1,
+		},
+		{
+			name: "today",
+			call: func() ([]*countv1.MethodCount, error) {
+				resp, err := s.ListDailyTotals(ctx, todayReq)
+				return resp.GetMethodCounts(), err
+			},
+			wantStrong: 3,
+			wantReads:  4,
+			wantCount:  1,
+		},
+		{
+			name: "invalidated by import",
+			call: func() ([]*countv1.MethodCount, error) {
+				if _, err := s.ImportDailyTotals(ctx, importReq); err != nil {
+					return nil, err
+				}
+				resp, err := s.ListDailyTotals(ctx, listReq)
+				return resp.GetMethodCounts(), err
+			},
+			wantStrong: 4,
+			wantReads:  5,
+			wantCount:  2,
+		},
+		{
+			name: "period invalidated by import",
+			call: func() ([]*countv1.MethodCount, error) {
+				resp, err := s.GetPeriodTotals(ctx, periodReq)
+				return resp.GetMethodCounts(), err
+			},
+			wantStrong: 5,
+			wantReads:  6,
+			wantCount:  2,
+		},
+		{
+			name: "invalidated by count",
+			call: func() ([]*countv1.MethodCount, error) {
+				if _, err := s.CountDailyTotals(ctx, &countv1.CountDailyTotalsRequest{Date: day}); err != nil {
+					return nil, err
+				}
+				resp, err := s.ListDailyTotals(ctx, listReq)
+				return resp.GetMethodCounts(), err
+			},
+			wantStrong: 6,
+			wantReads:  7,
+			wantCount:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counts, err := tt.call()
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantCount(t, tt.name, counts, tt.wantCount)
+			if cs.reads != tt.wantReads {
+				t.Errorf("store reads = %d, want %d", cs.reads, tt.wantReads)
+			}
+			if cs.strong != tt.wantStrong {
+				t.Errorf("strong store reads = %d, want %d", cs.strong, tt.wantStrong)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/muhlemmer/count/internal/hll"
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// requestDetails returns the optional details of req:
+// the hash of the client ID and the duration.
+func requestDetails(req *countv1.AddRequest) (details store.RequestDetails, err error) {
+	if id := req.GetClientId(); id != "" {
+		hash := hll.Hash(id)
+		details.Client = &hash
+	}
+	if pb := req.GetDuration(); pb != nil {
+		if err = pb.CheckValid(); err != nil {
+			return details, status.Errorf(codes.InvalidArgument, "duration: %v", err)
+		}
+		duration := pb.AsDuration()
+		if duration < 0 {
+			return details, status.Errorf(codes.InvalidArgument, "duration %s must not be negative", duration)
+		}
+		details.Duration = &duration
+	}
+	return details, nil
+}
+
+// insertRequest inserts a request with its details,
+// when set and the store keeps sketches of them.
+// Labels must be checked by requestLabels.
+func (s *CountServer) insertRequest(ctx context.Context, service string, method countv1.Method, path string, requestTS time.Time, details store.RequestDetails) error {
+	if dc, ok := s.store.(store.DetailCounter); ok && (details.Client != nil || details.Duration != nil || details.Labels != nil) {
+		return dc.InsertRequestDetails(ctx, service, method, path, requestTS, details)
+	}
+	return s.store.InsertMethodRequest(ctx, service, method, path, requestTS)
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/muhlemmer/count/internal/ddsketch"
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_requestDetails(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          *countv1.AddRequest
+		wantClient   bool
+		wantDuration time.Duration
+		wantCode     codes.Code
+	}{
+		{"empty", &countv1.AddRequest{}, false, -1, codes.OK},
+		{"client", &countv1.AddRequest{ClientId: "alice"}, true, -1, codes.OK},
+		{"duration", &countv1.AddRequest{Duration: durationpb.New(time.Second)}, false, time.Second, codes.OK},
+		{"zero duration", &countv1.AddRequest{Duration: durationpb.New(0)}, false, 0, codes.OK},
+		{"negative duration", &countv1.AddRequest{Duration: durationpb.New(-time.Second)}, false, -1, codes.InvalidArgument},
+		{"invalid duration", &countv1.AddRequest{Duration: &durationpb.Duration{Seconds: 1, Nanos: -1}}, false, -1, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := requestDetails(tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("requestDetails() code = %s, want %s", code, tt.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			if (got.Client != nil) != tt.wantClient {
+				t.Errorf("requestDetails() client = %v, want %v", got.Client, tt.wantClient)
+			}
+			if tt.wantDuration < 0 {
+				if got.Duration != nil {
+					t.Errorf("requestDetails() duration = %v, want nil", *got.Duration)
+				}
+			} else if got.Duration == nil || *got.Duration != tt.wantDuration {
+				t.Errorf("requestDetails() duration = %v, want %v", got.Duration, tt.wantDuration)
+			}
+		})
+	}
+}
+
+func TestCountServer_Add_details(t *testing.T) {
+	ctx := context.Background()
+	day := &date.Date{Year: 2022, Month: 11, Day: 10}
+	ts := timestamppb.New(time.Date(2022, 11, 10, 12, 0, 0, 0, time.UTC))
+
+	var stream []*countv1.AddRequest
+	for i, client := range []string{"alice", "bob", "alice", ""} {
+		stream = append(stream, &countv1.AddRequest{
+			Method:           countv1.Method_GET,
+			Path:             "/foo",
+			RequestTimestamp: ts,
+			ClientId:         client,
+			Duration:         durationpb.New(time.Duration(i+1) * 10 * time.Millisecond),
+		})
+	}
+
+	s := NewCountService(grpc.NewServer(), store.NewMemory())
+	if err := s.Add(&mockAddServer{ctx: ctx, stream: stream}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.CountDailyTotals(ctx, &countv1.CountDailyTotalsRequest{Date: day}); err != nil {
+		t.Fatal(err)
+	}
+
+	month := &date.Date{Year: 2022, Month: 11}
+	resp, err := s.GetPeriodTotals(ctx, &countv1.GetPeriodTotalsRequest{
+		PeriodType: &countv1.GetPeriodTotalsRequest_Period{Period: month},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := resp.GetMethodCounts()
+	if len(counts) != 1 || counts[0].GetCount() != 4 || counts[0].GetDistinctClients() != 2 {
+		t.Errorf("CountServer.GetPeriodTotals() = %v, want count 4 and 2 distinct clients", counts)
+	}
+
+	latencyResp, err := s.GetLatencyStats(ctx, &countv1.GetLatencyStatsRequest{
+		PeriodType: &countv1.GetLatencyStatsRequest_Period{Period: month},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats := latencyResp.GetLatencyStats()
+	if len(stats) != 1 || stats[0].GetCount() != 4 || len(stats[0].GetQuantiles()) != len(defaultQuantiles) {
+		t.Fatalf("CountServer.GetLatencyStats() = %v, want count 4 with default quantiles", stats)
+	}
+	// p50 of 10, 20, 30 and 40ms is the second duration.
+	p50 := stats[0].GetQuantiles()[0]
+	if d := p50.GetDuration().AsDuration(); p50.GetQuantile() != 0.5 || math.Abs(float64(d-20*time.Millisecond)) > ddsketch.RelativeAccuracy*float64(20*time.Millisecond) {
+		t.Errorf("CountServer.GetLatencyStats() p50 = %v, want 20ms", p50)
+	}
+}
+
+func TestCountServer_GetLatencyStats_error(t *testing.T) {
+	ctx := context.Background()
+	period := &countv1.GetLatencyStatsRequest_Period{Period: &date.Date{Year: 2022, Month: 11}}
+
+	tests := []struct {
+		name  string
+		store store.Store
+		req   *countv1.GetLatencyStatsRequest
+		want  codes.Code
+	}{
+		{"period", store.NewMemory(), &countv1.GetLatencyStatsRequest{}, codes.InvalidArgument},
+		{"quantile", store.NewMemory(), &countv1.GetLatencyStatsRequest{PeriodType: period, Quantiles: []float64{1.5}}, codes.InvalidArgument},
+		{"NaN quantile", store.NewMemory(), &countv1.GetLatencyStatsRequest{PeriodType: period, Quantiles: []float64{math.NaN()}}, codes.InvalidArgument},
+		{"not found", store.NewMemory(), &countv1.GetLatencyStatsRequest{PeriodType: period}, codes.NotFound},
+		{"unsupported", struct{ store.Store }{store.NewMemory()}, &countv1.GetLatencyStatsRequest{PeriodType: period}, codes.Unimplemented},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewCountService(grpc.NewServer(), tt.store)
+			_, err := s.GetLatencyStats(ctx, tt.req)
+			if code := status.Code(err); code != tt.want {
+				t.Errorf("CountServer.GetLatencyStats() code = %s, want %s", code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Export chunk sizes
+const (
+	DefaultExportChunkSize = 1000
+	MaxExportChunkSize     = 10000
+)
+
+// exportChunker collects method counts into chunks of whole days.
+type exportChunker struct {
+	chunkSize int
+	send      func(*countv1.ExportTotalsResponse) error
+	pending   []*countv1.MethodCount
+}
+
+// add counts, which must be ordered by date.
+// Pending counts are sent when they reach the chunk size
+// and the next count is on a later date.
+func (c *exportChunker) add(counts []*countv1.MethodCount) error {
+	for _, mc := range counts {
+		if n := len(c.pending); n >= c.chunkSize && !datepb.Time(c.pending[n-1].GetDate()).Equal(datepb.Time(mc.GetDate())) {
+			if err := c.flush(); err != nil {
+				return err
+			}
+		}
+		c.pending = append(c.pending, mc)
+	}
+	return nil
+}
+
+// flush sends the pending counts, if any.
+func (c *exportChunker) flush() error {
+	if len(c.pending) == 0 {
+		return nil
+	}
+
+	err := c.send(&countv1.ExportTotalsResponse{
+		MethodCounts: c.pending,
+		LastDate:     c.pending[len(c.pending)-1].GetDate(),
+	})
+	c.pending = nil
+	return err
+}
+
+func (s *CountServer) ExportTotals(req *countv1.ExportTotalsRequest, stream countv1.CountService_ExportTotalsServer) error {
+	startDate := req.GetStartDate()
+	if startDate == nil {
+		return status.Errorf(codes.InvalidArgument, "start_date required")
+	}
+	endDate := req.GetEndDate()
+	if endDate == nil {
+		return status.Errorf(codes.InvalidArgument, "end_date required")
+	}
+	if err := s.checkTimeZone(req.GetTimeZone()); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	service, err := requestService(ctx, req.GetService())
+	if err != nil {
+		return err
+	}
+
+	chunkSize := int(req.GetChunkSize())
+	switch {
+	case chunkSize == 0:
+		chunkSize = DefaultExportChunkSize
+	case chunkSize < 0 || chunkSize > MaxExportChunkSize:
+		return status.Errorf(codes.InvalidArgument, "chunk_size must be between 1 and %d", MaxExportChunkSize)
+	}
+
+	exporter, ok := s.store.(store.Exporter)
+	if !ok {
+		return unsupported("ExportTotals")
+	}
+
+	chunker := &exportChunker{
+		chunkSize: chunkSize,
+		send:      stream.Send,
+	}
+
+	err = exporter.ExportDailyTotals(ctx, service, datepb.Time(startDate), datepb.Time(endDate), chunkSize, chunker.add)
+	if err != nil {
+		return err
+	}
+	return chunker.flush()
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockExportServer struct {
+	grpc.ServerStream
+
+	ctx       context.Context
+	responses []*countv1.ExportTotalsResponse
+	sendErr   error
+}
+
+func (s *mockExportServer) Send(resp *countv1.ExportTotalsResponse) error {
+	s.responses = append(s.responses, resp)
+	return s.sendErr
+}
+
+func (s *mockExportServer) Context() context.Context {
+	return s.ctx
+}
+
+// newExportStore returns a memory store with 3 daily totals
+// on 2022-10-16, 1 on 2022-10-17 and 2 on 2022-10-18.
+func newExportStore(t *testing.T) store.Store {
+	ctx := context.Background()
+	mem := store.NewMemory()
+
+	days := map[int][]string{
+		16: {"/a", "/b", "/c"},
+		17: {"/a"},
+		18: {"/a", "/b"},
+	}
+	for day, paths := range days {
+		ts := time.Date(2022, time.October, day, 12, 0, 0, 0, time.UTC)
+		for _, path := range paths {
+			if err := mem.InsertMethodRequest(ctx, store.DefaultService, countv1.Method_GET, path, ts); err != nil {
+				t.Fatal(err)
+			}
+		}
+		start, end := datepb.Interval(datepb.Date(ts))
+		if _, err := mem.CountDailyMethodTotals(ctx, start, end); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return mem
+}
+
+func TestCountServer_ExportTotals(t *testing.T) {
+	s := &CountServer{
+		store: newExportStore(t),
+		loc:   time.UTC,
+	}
+	start := &date.Date{Year: 2022, Month: 10, Day: 1}
+	end := &date.Date{Year: 2022, Month: 10, Day: 31}
+
+	tests := []struct {
+		name          string
+		req           *countv1.ExportTotalsRequest
+		sendErr       error
+		wantChunks    []int
+		wantLastDates []int32
+		wantCode      codes.Code
+	}{
+		{
+			name:     "missing start",
+			req:      &countv1.ExportTotalsRequest{EndDate: end},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "missing end",
+			req:      &countv1.ExportTotalsRequest{StartDate: start},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "chunk size",
+			req:      &countv1.ExportTotalsRequest{StartDate: start, EndDate: end, ChunkSize: MaxExportChunkSize + 1},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:          "default chunk size",
+			req:           &countv1.ExportTotalsRequest{StartDate: start, EndDate: end},
+			wantChunks:    []int{6},
+			wantLastDates: []int32{18},
+		},
+		{
+			name:          "whole days",
+			req:           &countv1.ExportTotalsRequest{StartDate: start, EndDate: end, ChunkSize: 2},
+			wantChunks:    []int{3, 3},
+			wantLastDates: []int32{16, 18},
+		},
+		{
+			name:          "chunk per day",
+			req:           &countv1.ExportTotalsRequest{StartDate: start, EndDate: end, ChunkSize: 1},
+			wantChunks:    []int{3, 1, 2},
+			wantLastDates: []int32{16, 17, 18},
+		},
+		{
+			name: "resume",
+			req: &countv1.ExportTotalsRequest{
+				StartDate: &date.Date{Year: 2022, Month: 10, Day: 17},
+				EndDate:   end,
+				ChunkSize: 1,
+			},
+			wantChunks:    []int{1, 2},
+			wantLastDates: []int32{17, 18},
+		},
+		{
+			name: "empty",
+			req: &countv1.ExportTotalsRequest{
+				StartDate: &date.Date{Year: 2022, Month: 11, Day: 1},
+				EndDate:   &date.Date{Year: 2022, Month: 11, Day: 30},
+			},
+		},
+		{
+			name:       "send error",
+			req:        &countv1.ExportTotalsRequest{StartDate: start, EndDate: end, ChunkSize: 1},
+			sendErr:    errors.New("foo"),
+			wantChunks: []int{3},
+			wantCode:   codes.Unknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := &mockExportServer{
+				ctx:     context.Background(),
+				sendErr: tt.sendErr,
+			}
+			err := s.ExportTotals(tt.req, stream)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("CountServer.ExportTotals() err = %v, want code %s", err, tt.wantCode)
+			}
+
+			if len(stream.responses) != len(tt.wantChunks) {
+				t.Fatalf("CountServer.ExportTotals() responses = %d, want %d", len(stream.responses), len(tt.wantChunks))
+			}
+			for i, resp := range stream.responses {
+				if n := len(resp.GetMethodCounts()); n != tt.wantChunks[i] {
+					t.Errorf("response %d: method counts = %d, want %d", i, n, tt.wantChunks[i])
+				}
+				if tt.wantLastDates == nil {
+					continue
+				}
+				if day := resp.GetLastDate().GetDay(); day != tt.wantLastDates[i] {
+					t.Errorf("response %d: last date day = %d, want %d", i, day, tt.wantLastDates[i])
+				}
+			}
+		})
+	}
+}
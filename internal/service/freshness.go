@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var freshnesses = map[countv1.Freshness]store.Freshness{
+	countv1.Freshness_FRESHNESS_UNSPECIFIED: store.Stale,
+	countv1.Freshness_FRESHNESS_STRONG:      store.Strong,
+	countv1.Freshness_FRESHNESS_STALE:       store.Stale,
+}
+
+// withFreshness returns ctx with the requested freshness set for the store.
+func withFreshness(ctx context.Context, f countv1.Freshness) (context.Context, error) {
+	freshness, ok := freshnesses[f]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown freshness %d", f)
+	}
+	return store.WithFreshness(ctx, freshness), nil
+}
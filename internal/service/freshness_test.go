@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// freshnessStore records the freshness of the last read.
+type freshnessStore struct {
+	store.Store
+	freshness store.Freshness
+}
+
+func (s *freshnessStore) ListDailyTotals(ctx context.Context, service string, start, end time.Time) ([]*countv1.MethodCount, error) {
+	s.freshness = store.FreshnessFromContext(ctx)
+	return s.Store.ListDailyTotals(ctx, service, start, end)
+}
+
+func (s *freshnessStore) GetPeriodTotals(ctx context.Context, service string, start, end time.Time) ([]*countv1.MethodCount, error) {
+	s.freshness = store.FreshnessFromContext(ctx)
+	return s.Store.GetPeriodTotals(ctx, service, start, end)
+}
+
+func TestCountServer_freshness(t *testing.T) {
+	ctx := context.Background()
+	day := &date.Date{Year: 2022, Month: 10, Day: 16}
+
+	mem := store.NewMemory()
+	err := mem.ImportDailyTotals(ctx, store.DefaultService, []*countv1.MethodCount{
+		{Date: day, Method: countv1.Method_GET, Path: "/foo", Count: 1},
+	}, store.Merge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs := &freshnessStore{Store: mem}
+	s := &CountServer{store: fs, loc: time.UTC}
+
+	tests := []struct {
+		name      string
+		freshness countv1.Freshness
+		want      store.Freshness
+		wantCode  codes.Code
+	}{
+		{"unspecified", countv1.Freshness_FRESHNESS_UNSPECIFIED, store.Stale, codes.OK},
+		{"strong", countv1.Freshness_FRESHNESS_STRONG, store.Strong, codes.OK},
+		{"stale", countv1.Freshness_FRESHNESS_STALE, store.Stale, codes.OK},
+		{"unknown", countv1.Freshness(99), store.Stale, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs.freshness = store.Stale
+			_, err := s.ListDailyTotals(ctx, &countv1.ListDailyTotalsRequest{
+				StartDate: day,
+				EndDate:   day,
+				Freshness: tt.freshness,
+			})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("CountServer.ListDailyTotals() err = %v, want %s", err, tt.wantCode)
+			}
+			if fs.freshness != tt.want {
+				t.Errorf("CountServer.ListDailyTotals() freshness = %s, want %s", fs.freshness, tt.want)
+			}
+
+			fs.freshness = store.Stale
+			_, err = s.GetPeriodTotals(ctx, &countv1.GetPeriodTotalsRequest{
+				PeriodType: &countv1.GetPeriodTotalsRequest_Period{Period: datepb.Date(datepb.Time(day))},
+				Freshness:  tt.freshness,
+			})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("CountServer.GetPeriodTotals() err = %v, want %s", err, tt.wantCode)
+			}
+			if fs.freshness != tt.want {
+				t.Errorf("CountServer.GetPeriodTotals() freshness = %s, want %s", fs.freshness, tt.want)
+			}
+		})
+	}
+}
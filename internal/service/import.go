@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MaxImportBatchSize is the maximum amount of
+// method counts in an ImportDailyTotals request.
+const MaxImportBatchSize = 10000
+
+var importModes = map[countv1.ImportMode]store.ImportMode{
+	countv1.ImportMode_IMPORT_MODE_UNSPECIFIED: store.Merge,
+	countv1.ImportMode_IMPORT_MODE_MERGE:       store.Merge,
+	countv1.ImportMode_IMPORT_MODE_REPLACE:     store.Replace,
+}
+
+// checkImportCounts returns an InvalidArgument error for the first
+// method count without a complete date or path, with a negative count,
+// or with the same date, method and path as an earlier method count.
+func checkImportCounts(counts []*countv1.MethodCount) error {
+	type importKey struct {
+		methodPath
+		year, month, day int32
+	}
+	seen := make(map[importKey]bool, len(counts))
+
+	for i, mc := range counts {
+		d := mc.GetDate()
+		if d.GetYear() == 0 || d.GetMonth() == 0 || d.GetDay() == 0 {
+			return status.Errorf(codes.InvalidArgument, "method_counts[%d]: complete date required", i)
+		}
+		if norm := datepb.Date(datepb.Time(d)); norm.GetMonth() != d.GetMonth() || norm.GetDay() != d.GetDay() {
+			return status.Errorf(codes.InvalidArgument, "method_counts[%d]: invalid date %d-%02d-%02d", i, d.GetYear(), d.GetMonth(), d.GetDay())
+		}
+		if mc.GetPath() == "" {
+			return status.Errorf(codes.InvalidArgument, "method_counts[%d]: path required", i)
+		}
+		if mc.GetCount() < 0 {
+			return status.Errorf(codes.InvalidArgument, "method_counts[%d]: negative count", i)
+		}
+
+		k := importKey{methodPath{mc.GetMethod(), mc.GetPath()}, d.GetYear(), d.GetMonth(), d.GetDay()}
+		if seen[k] {
+			return status.Errorf(codes.InvalidArgument, "method_counts[%d]: duplicate %s %s on %d-%02d-%02d",
+				i, mc.GetMethod(), mc.GetPath(), d.GetYear(), d.GetMonth(), d.GetDay(),
+			)
+		}
+		seen[k] = true
+	}
+
+	return nil
+}
+
+// importInterval returns the first and last date of counts.
+func importInterval(counts []*countv1.MethodCount) (first, last time.Time) {
+	for i, mc := range counts {
+		day := datepb.Time(mc.GetDate())
+		if i == 0 || day.Before(first) {
+			first = day
+		}
+		if i == 0 || day.After(last) {
+			last = day
+		}
+	}
+	return first, last
+}
+
+func (s *CountServer) ImportDailyTotals(ctx context.Context, req *countv1.ImportDailyTotalsRequest) (*countv1.ImportDailyTotalsResponse, error) {
+	counts := req.GetMethodCounts()
+	if len(counts) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "method_counts required")
+	}
+	if len(counts) > MaxImportBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "method_counts exceeds the maximum of %d", MaxImportBatchSize)
+	}
+	mode, ok := importModes[req.GetMode()]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid mode %s", req.GetMode())
+	}
+	if err := checkImportCounts(counts); err != nil {
+		return nil, err
+	}
+	service, err := requestService(ctx, req.GetService())
+	if err != nil {
+		return nil, err
+	}
+
+	importer, ok := s.store.(store.Importer)
+	if !ok {
+		return nil, unsupported("ImportDailyTotals")
+	}
+
+	err = importer.ImportDailyTotals(ctx, service, counts, mode)
+	first, last := importInterval(counts)
+	s.cache.invalidate(service, first, last)
+	if err != nil {
+		return nil, err
+	}
+
+	return &countv1.ImportDailyTotalsResponse{
+		Imported: int64(len(counts)),
+	}, nil
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCountServer_ImportDailyTotals(t *testing.T) {
+	ctx := context.Background()
+	mem := store.NewMemory()
+	s := &CountServer{
+		store: mem,
+		loc:   time.UTC,
+	}
+	day := &date.Date{Year: 2022, Month: 10, Day: 16}
+
+	tests := []struct {
+		name         string
+		req          *countv1.ImportDailyTotalsRequest
+		wantCode     codes.Code
+		wantImported int64
+		wantCount    int64
+	}{
+		{
+			name:     "empty",
+			req:      &countv1.ImportDailyTotalsRequest{},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "invalid mode",
+			req: &countv1.ImportDailyTotalsRequest{
+				MethodCounts: []*countv1.MethodCount{{Method: countv1.Method_GET, Path: "/foo", Count: 1, Date: day}},
+				Mode:         99,
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "missing date",
+			req: &countv1.ImportDailyTotalsRequest{
+				MethodCounts: []*countv1.MethodCount{{Method: countv1.Method_GET, Path: "/foo", Count: 1}},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "month date",
+			req: &countv1.ImportDailyTotalsRequest{
+				MethodCounts: []*countv1.MethodCount{{Method: countv1.Method_GET, Path: "/foo", Count: 1, Date: &date.Date{Year: 2022, Month: 10}}},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "invalid date",
+			req: &countv1.ImportDailyTotalsRequest{
+				MethodCounts: []*countv1.MethodCount{{Method: countv1.Method_GET, Path: "/foo", Count: 1, Date: &date.Date{Year: 2022, Month: 2, Day: 30}}},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "missing path",
+			req: &countv1.ImportDailyTotalsRequest{
+				MethodCounts: []*countv1.MethodCount{{Method: countv1.Method_GET, Count: 1, Date: day}},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "negative count",
+			req: &countv1.ImportDailyTotalsRequest{
+				MethodCounts: []*countv1.MethodCount{{Method: countv1.Method_GET, Path: "/foo", Count: -1, Date: day}},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "duplicate",
+			req: &countv1.ImportDailyTotalsRequest{
+				MethodCounts: []*countv1.MethodCount{
+					{Method: countv1.Method_GET, Path: "/foo", Count: 1, Date: day},
+					{Method: countv1.Method_GET, Path: "/foo", Count: 2, Date: day},
+				},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "merge",
+			req: &countv1.ImportDailyTotalsRequest{
+				MethodCounts: []*countv1.MethodCount{{Method: countv1.Method_GET, Path: "/foo", Count: 2, Date: day}},
+			},
+			wantImported: 1,
+			wantCount:    2,
+		},
+		{
+			name: "merge again",
+			req: &countv1.ImportDailyTotalsRequest{
+				MethodCounts: []*countv1.MethodCount{{Method: countv1.Method_GET, Path: "/foo", Count: 3, Date: day}},
+				Mode:         countv1.ImportMode_IMPORT_MODE_MERGE,
+			},
+			wantImported: 1,
+			wantCount:    5,
+		},
+		{
+			name: "replace",
+			req: &countv1.ImportDailyTotalsRequest{
+				MethodCounts: []*countv1.MethodCount{{Method: countv1.Method_GET, Path: "/foo", Count: 1, Date: day}},
+				Mode:         countv1.ImportMode_IMPORT_MODE_REPLACE,
+			},
+			wantImported: 1,
+			wantCount:    1,
+		},
+		{
+			name: "other service",
+			req: &countv1.ImportDailyTotalsRequest{
+				MethodCounts: []*countv1.MethodCount{{Method: countv1.Method_GET, Path: "/foo", Count: 7, Date: day}},
+				Service:      "shop",
+			},
+			wantImported: 1,
+			wantCount:    1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.ImportDailyTotals(ctx, tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("CountServer.ImportDailyTotals() err = %v, want code %s", err, tt.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			if got.GetImported() != tt.wantImported {
+				t.Errorf("CountServer.ImportDailyTotals() imported = %d, want %d", got.GetImported(), tt.wantImported)
+			}
+
+			counts, err := mem.ListDailyTotals(ctx, store.DefaultService, datepb.Time(day), datepb.Time(day))
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := &countv1.MethodCount{Method: countv1.Method_GET, Path: "/foo", Count: tt.wantCount, Date: day}
+			if len(counts) != 1 || !proto.Equal(counts[0], want) {
+				t.Errorf("daily totals = %v, want %v", counts, want)
+			}
+		})
+	}
+}
+
+func TestCountServer_ImportDailyTotals_unsupported(t *testing.T) {
+	s := &CountServer{
+		// hide the Importer implementation of Memory.
+		store: struct{ store.Store }{store.NewMemory()},
+		loc:   time.UTC,
+	}
+
+	_, err := s.ImportDailyTotals(context.Background(), &countv1.ImportDailyTotalsRequest{
+		MethodCounts: []*countv1.MethodCount{{
+			Method: countv1.Method_GET,
+			Path:   "/foo",
+			Count:  1,
+			Date:   &date.Date{Year: 2022, Month: 10, Day: 16},
+		}},
+	})
+	if code := status.Code(err); code != codes.Unimplemented {
+		t.Errorf("CountServer.ImportDailyTotals() code = %s, want %s", code, codes.Unimplemented)
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+)
+
+func (s *CountServer) keyStore(rpc string) (store.KeyStore, error) {
+	keys, ok := s.store.(store.KeyStore)
+	if !ok {
+		return nil, unsupported(rpc)
+	}
+	return keys, nil
+}
+
+func (s *CountServer) CreateApiKey(ctx context.Context, req *countv1.CreateApiKeyRequest) (*countv1.CreateApiKeyResponse, error) {
+	keys, err := s.keyStore("CreateApiKey")
+	if err != nil {
+		return nil, err
+	}
+
+	key, apiKey, err := NewAPIKey(ctx, keys, req.GetName(), req.GetService(), req.GetScopes())
+	if err != nil {
+		return nil, err
+	}
+
+	return &countv1.CreateApiKeyResponse{
+		ApiKey: apiKey,
+		Key:    key,
+	}, nil
+}
+
+func (s *CountServer) ListApiKeys(ctx context.Context, req *countv1.ListApiKeysRequest) (*countv1.ListApiKeysResponse, error) {
+	keys, err := s.keyStore("ListApiKeys")
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeys, err := keys.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &countv1.ListApiKeysResponse{
+		ApiKeys: apiKeys,
+	}, nil
+}
+
+func (s *CountServer) RevokeApiKey(ctx context.Context, req *countv1.RevokeApiKeyRequest) (*countv1.RevokeApiKeyResponse, error) {
+	keys, err := s.keyStore("RevokeApiKey")
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, err := keys.RevokeAPIKey(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &countv1.RevokeApiKeyResponse{
+		ApiKey: apiKey,
+	}, nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCountServer_ApiKeys(t *testing.T) {
+	ctx := context.Background()
+	s := &CountServer{
+		store: store.NewMemory(),
+		loc:   time.UTC,
+	}
+
+	_, err := s.CreateApiKey(ctx, &countv1.CreateApiKeyRequest{Name: "foo"})
+	if code := status.Code(err); code != codes.InvalidArgument {
+		t.Errorf("CountServer.CreateApiKey() err = %v, want code %s", err, codes.InvalidArgument)
+	}
+
+	created, err := s.CreateApiKey(ctx, &countv1.CreateApiKeyRequest{
+		Name:   "foo",
+		Scopes: []countv1.Scope{countv1.Scope_SCOPE_INGEST},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.GetKey() == "" || created.GetApiKey().GetId() == 0 {
+		t.Errorf("CountServer.CreateApiKey() = %v", created)
+	}
+
+	list, err := s.ListApiKeys(ctx, &countv1.ListApiKeysRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.GetApiKeys()) != 1 || !proto.Equal(list.GetApiKeys()[0], created.GetApiKey()) {
+		t.Errorf("CountServer.ListApiKeys() = %v", list)
+	}
+
+	revoked, err := s.RevokeApiKey(ctx, &countv1.RevokeApiKeyRequest{Id: created.GetApiKey().GetId()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revoked.GetApiKey().GetRevokeTime() == nil {
+		t.Errorf("CountServer.RevokeApiKey() = %v", revoked)
+	}
+
+	_, err = s.RevokeApiKey(ctx, &countv1.RevokeApiKeyRequest{Id: 99})
+	if code := status.Code(err); code != codes.NotFound {
+		t.Errorf("CountServer.RevokeApiKey() err = %v, want code %s", err, codes.NotFound)
+	}
+}
+
+func TestCountServer_ApiKeys_unsupported(t *testing.T) {
+	ctx := context.Background()
+	s := &CountServer{
+		store: struct{ store.Store }{store.NewMemory()},
+		loc:   time.UTC,
+	}
+
+	_, err := s.CreateApiKey(ctx, &countv1.CreateApiKeyRequest{})
+	if code := status.Code(err); code != codes.Unimplemented {
+		t.Errorf("CountServer.CreateApiKey() code = %s, want %s", code, codes.Unimplemented)
+	}
+	_, err = s.ListApiKeys(ctx, &countv1.ListApiKeysRequest{})
+	if code := status.Code(err); code != codes.Unimplemented {
+		t.Errorf("CountServer.ListApiKeys() code = %s, want %s", code, codes.Unimplemented)
+	}
+	_, err = s.RevokeApiKey(ctx, &countv1.RevokeApiKeyRequest{})
+	if code := status.Code(err); code != codes.Unimplemented {
+		t.Errorf("CountServer.RevokeApiKey() code = %s, want %s", code, codes.Unimplemented)
+	}
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"expvar"
+	"sync"
+
+	"github.com/muhlemmer/count/internal/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// MaxLabels is the maximum amount of labels of a datapoint.
+	MaxLabels = 16
+
+	// MaxLabelValueLength is the maximum length of a label value, in bytes.
+	MaxLabelValueLength = 256
+
+	// DefaultMaxLabelValues is the default amount of distinct values of a label key.
+	DefaultMaxLabelValues = 100
+
+	// DefaultMaxLabelSets is the default amount of distinct label sets of a service.
+	DefaultMaxLabelSets = 1000
+
+	// OverflowLabelValue replaces label values over the cardinality cap.
+	OverflowLabelValue = "__overflow__"
+
+	// OverflowLabelKey is the key of the single label of the set
+	// which replaces label sets over the cardinality cap.
+	OverflowLabelKey = "__overflow__"
+)
+
+// labelsOverflowed counts label values replaced by OverflowLabelValue,
+// and labelSetsOverflowed label sets replaced by the overflow set,
+// published under "count_service".
+var (
+	labelsOverflowed    = new(expvar.Int)
+	labelSetsOverflowed = new(expvar.Int)
+)
+
+func init() {
+	metrics.Set("labels_overflowed", labelsOverflowed)
+	metrics.Set("label_sets_overflowed", labelSetsOverflowed)
+}
+
+// LabelPolicy limits the labels of datapoints added by the Add RPC.
+type LabelPolicy struct {
+	// Keys which datapoints may use as label.
+	// Datapoints with other keys are rejected.
+	// Labels are disabled when empty.
+	Keys []string
+
+	// MaxValues is the amount of distinct values of each key and service.
+	// Further values are counted as OverflowLabelValue.
+	// Values are tracked by each server since it started,
+	// so the cap is not exact over restarts or multiple servers.
+	// Defaults to DefaultMaxLabelValues.
+	MaxValues int
+
+	// MaxSets is the amount of distinct label sets of each service,
+	// after capping their values. Each label set of a method and path
+	// is stored separately, so this bounds the stored rows of a method and path.
+	// Further label sets are replaced as a whole by a set with only
+	// OverflowLabelKey, with OverflowLabelValue.
+	// Label sets are tracked like values, see MaxValues.
+	// Defaults to DefaultMaxLabelSets.
+	MaxSets int
+}
+
+// WithLabelPolicy sets the allowed label keys and their cardinality cap.
+// Labels are rejected by default.
+func WithLabelPolicy(policy LabelPolicy) Option {
+	return func(s *CountServer) {
+		s.labels.policy = policy
+	}
+}
+
+// allowed reports whether key is in Keys.
+func (p LabelPolicy) allowed(key string) bool {
+	for _, k := range p.Keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+type labelKey struct {
+	service, key string
+}
+
+// labelLimiter enforces a LabelPolicy.
+// The zero value rejects all labels.
+type labelLimiter struct {
+	policy LabelPolicy
+
+	mu     sync.Mutex
+	values map[labelKey]map[string]struct{}
+	sets   map[string]map[string]struct{}
+}
+
+// check returns the labels of a datapoint of service, with values
+// over the cardinality cap replaced by OverflowLabelValue.
+// The labels are replaced by the overflow set
+// when the label set is over the cardinality cap.
+// An InvalidArgument error is returned for labels which are not allowed.
+func (l *labelLimiter) check(service string, labels map[string]string) (map[string]string, error) {
+	if len(labels) == 0 {
+		return nil, nil
+	}
+	if len(labels) > MaxLabels {
+		return nil, status.Errorf(codes.InvalidArgument, "%d labels exceed maximum of %d", len(labels), MaxLabels)
+	}
+	for k, v := range labels {
+		if !l.policy.allowed(k) {
+			return nil, status.Errorf(codes.InvalidArgument, "label key %q not allowed", k)
+		}
+		if v == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "label %q: empty value", k)
+		}
+		if len(v) > MaxLabelValueLength {
+			return nil, status.Errorf(codes.InvalidArgument, "label %q: value exceeds maximum length of %d", k, MaxLabelValueLength)
+		}
+	}
+
+	maxValues := l.policy.MaxValues
+	if maxValues <= 0 {
+		maxValues = DefaultMaxLabelValues
+	}
+	maxSets := l.policy.MaxSets
+	if maxSets <= 0 {
+		maxSets = DefaultMaxLabelSets
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.values == nil {
+		l.values = make(map[labelKey]map[string]struct{})
+		l.sets = make(map[string]map[string]struct{})
+	}
+	checked := make(map[string]string, len(labels))
+	for k, v := range labels {
+		lk := labelKey{service, k}
+		values, ok := l.values[lk]
+		if !ok {
+			values = make(map[string]struct{})
+			l.values[lk] = values
+		}
+		if _, ok := values[v]; !ok {
+			if len(values) >= maxValues {
+				labelsOverflowed.Add(1)
+				v = OverflowLabelValue
+			} else {
+				values[v] = struct{}{}
+			}
+		}
+		checked[k] = v
+	}
+
+	sets, ok := l.sets[service]
+	if !ok {
+		sets = make(map[string]struct{})
+		l.sets[service] = sets
+	}
+	set := store.EncodeLabels(checked)
+	if _, ok := sets[set]; !ok {
+		if len(sets) >= maxSets {
+			labelSetsOverflowed.Add(1)
+			return map[string]string{OverflowLabelKey: OverflowLabelValue}, nil
+		}
+		sets[set] = struct{}{}
+	}
+	return checked, nil
+}
+
+// requestLabels returns the checked labels of a datapoint of service.
+// Unimplemented is returned when labels are set
+// and the store does not keep them.
+func (s *CountServer) requestLabels(service string, labels map[string]string) (map[string]string, error) {
+	if len(labels) == 0 {
+		return nil, nil
+	}
+	_, labeler := s.store.(store.Labeler)
+	_, detailCounter := s.store.(store.DetailCounter)
+	if !labeler || !detailCounter {
+		return nil, unsupported("labels")
+	}
+	return s.labels.check(service, labels)
+}
+
+// labelQuery returns the label query of a read RPC.
+// Unimplemented is returned for a non-empty query
+// when the store does not keep labels.
+func (s *CountServer) labelQuery(rpc string, filter map[string]string, groupBy []string) (q store.LabelQuery, err error) {
+	for k := range filter {
+		if k == "" {
+			return q, status.Error(codes.InvalidArgument, "label_filter: empty key")
+		}
+	}
+	seen := make(map[string]bool, len(groupBy))
+	for _, k := range groupBy {
+		if k == "" {
+			return q, status.Error(codes.InvalidArgument, "group_by_labels: empty key")
+		}
+		if seen[k] {
+			return q, status.Errorf(codes.InvalidArgument, "group_by_labels: duplicate key %q", k)
+		}
+		seen[k] = true
+	}
+
+	q = store.LabelQuery{Filter: filter, GroupBy: groupBy}
+	if _, ok := s.store.(store.Labeler); !ok && !q.Empty() {
+		return q, unsupported(rpc + " with labels")
+	}
+	return q, nil
+}
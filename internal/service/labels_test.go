@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_labelLimiter_check(t *testing.T) {
+	tooMany := make(map[string]string)
+	for i := 0; i <= MaxLabels; i++ {
+		tooMany[strconv.Itoa(i)] = "v"
+	}
+	long := string(make([]byte, MaxLabelValueLength+1))
+
+	tests := []struct {
+		name     string
+		labels   map[string]string
+		want     map[string]string
+		wantCode codes.Code
+	}{
+		{"empty", nil, nil, codes.OK},
+		{"allowed", map[string]string{"region": "eu", "tier": "free"}, map[string]string{"region": "eu", "tier": "free"}, codes.OK},
+		{"known value", map[string]string{"region": "eu"}, map[string]string{"region": "eu"}, codes.OK},
+		{"second value", map[string]string{"region": "us"}, map[string]string{"region": "us"}, codes.OK},
+		{"overflow", map[string]string{"region": "ap"}, map[string]string{"region": OverflowLabelValue}, codes.OK},
+		{"known after overflow", map[string]string{"region": "us"}, map[string]string{"region": "us"}, codes.OK},
+		{"not allowed", map[string]string{"version": "1"}, nil, codes.InvalidArgument},
+		{"empty value", map[string]string{"region": ""}, nil, codes.InvalidArgument},
+		{"long value", map[string]string{"region": long}, nil, codes.InvalidArgument},
+		{"too many", tooMany, nil, codes.InvalidArgument},
+	}
+
+	l := labelLimiter{policy: LabelPolicy{Keys: []string{"region", "tier"}, MaxValues: 2}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := l.check("svc", tt.labels)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("labelLimiter.check() code = %s, want %s", code, tt.wantCode)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("labelLimiter.check() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// values are capped for each service.
+	got, err := l.check("other", map[string]string{"region": "ap"})
+	if err != nil || got["region"] != "ap" {
+		t.Errorf("labelLimiter.check() other service = %v, %v, want ap", got, err)
+	}
+}
+
+func Test_labelLimiter_check_sets(t *testing.T) {
+	overflow := map[string]string{OverflowLabelKey: OverflowLabelValue}
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   map[string]string
+	}{
+		{"first", map[string]string{"region": "eu", "tier": "free"}, map[string]string{"region": "eu", "tier": "free"}},
+		{"second", map[string]string{"region": "us", "tier": "paid"}, map[string]string{"region": "us", "tier": "paid"}},
+		{"known values, new set", map[string]string{"region": "eu", "tier": "paid"}, overflow},
+		{"subset", map[string]string{"region": "eu"}, overflow},
+		{"known set", map[string]string{"region": "us", "tier": "paid"}, map[string]string{"region": "us", "tier": "paid"}},
+	}
+
+	l := labelLimiter{policy: LabelPolicy{Keys: []string{"region", "tier"}, MaxValues: 2, MaxSets: 2}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := l.check("svc", tt.labels)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("labelLimiter.check() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// sets are capped for each service.
+	got, err := l.check("other", map[string]string{"region": "eu"})
+	if err != nil || got["region"] != "eu" {
+		t.Errorf("labelLimiter.check() other service = %v, %v, want eu", got, err)
+	}
+}
+
+func Test_labelLimiter_check_disabled(t *testing.T) {
+	var l labelLimiter
+	_, err := l.check("svc", map[string]string{"region": "eu"})
+	if code := status.Code(err); code != codes.InvalidArgument {
+		t.Errorf("labelLimiter.check() code = %s, want %s", code, codes.InvalidArgument)
+	}
+}
+
+func TestCountServer_labels(t *testing.T) {
+	ctx := context.Background()
+	day := &date.Date{Year: 2022, Month: 11, Day: 12}
+	ts := timestamppb.New(time.Date(2022, 11, 12, 12, 0, 0, 0, time.UTC))
+
+	var stream []*countv1.AddRequest
+	for _, labels := range []map[string]string{
+		{"region": "eu"},
+		{"region": "eu"},
+		{"region": "us"},
+		{"region": "ap"},
+		nil,
+	} {
+		stream = append(stream, &countv1.AddRequest{
+			Method:           countv1.Method_GET,
+			Path:             "/foo",
+			RequestTimestamp: ts,
+			Labels:           labels,
+		})
+	}
+
+	s := NewCountService(grpc.NewServer(), store.NewMemory(), WithLabelPolicy(LabelPolicy{
+		Keys:      []string{"region"},
+		MaxValues: 2,
+	}))
+	if err := s.Add(&mockAddServer{ctx: ctx, stream: stream}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.CountDailyTotals(ctx, &countv1.CountDailyTotalsRequest{Date: day}); err != nil {
+		t.Fatal(err)
+	}
+
+	listResp, err := s.ListDailyTotals(ctx, &countv1.ListDailyTotalsRequest{
+		StartDate:     day,
+		EndDate:       day,
+		GroupByLabels: []string{"region"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]int64)
+	for _, mc := range listResp.GetMethodCounts() {
+		got[mc.GetLabels()["region"]] = mc.GetCount()
+	}
+	want := map[string]int64{"": 1, "eu": 2, "us": 1, OverflowLabelValue: 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CountServer.ListDailyTotals() = %v, want %v", got, want)
+	}
+
+	periodResp, err := s.GetPeriodTotals(ctx, &countv1.GetPeriodTotalsRequest{
+		PeriodType:  &countv1.GetPeriodTotalsRequest_Period{Period: day},
+		LabelFilter: map[string]string{"region": "eu"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts := periodResp.GetMethodCounts(); len(counts) != 1 || counts[0].GetCount() != 2 || counts[0].GetLabels() != nil {
+		t.Errorf("CountServer.GetPeriodTotals() = %v, want count 2 without labels", counts)
+	}
+
+	_, err = s.GetPeriodTotals(ctx, &countv1.GetPeriodTotalsRequest{
+		PeriodType:  &countv1.GetPeriodTotalsRequest_Period{Period: day},
+		LabelFilter: map[string]string{"region": "mars"},
+	})
+	if code := status.Code(err); code != codes.NotFound {
+		t.Errorf("CountServer.GetPeriodTotals() code = %s, want %s", code, codes.NotFound)
+	}
+}
+
+func TestCountServer_labels_error(t *testing.T) {
+	ctx := context.Background()
+	day := &date.Date{Year: 2022, Month: 11, Day: 12}
+	ts := timestamppb.New(time.Date(2022, 11, 12, 12, 0, 0, 0, time.UTC))
+	policy := WithLabelPolicy(LabelPolicy{Keys: []string{"region"}})
+
+	addTests := []struct {
+		name  string
+		store store.Store
+		opts  []Option
+		want  codes.Code
+	}{
+		{"disabled", store.NewMemory(), nil, codes.InvalidArgument},
+		{"unsupported", struct{ store.Store }{store.NewMemory()}, []Option{policy}, codes.Unimplemented},
+	}
+	for _, tt := range addTests {
+		t.Run("add "+tt.name, func(t *testing.T) {
+			s := NewCountService(grpc.NewServer(), tt.store, tt.opts...)
+			err := s.Add(&mockAddServer{ctx: ctx, stream: []*countv1.AddRequest{{
+				Method:           countv1.Method_GET,
+				Path:             "/foo",
+				RequestTimestamp: ts,
+				Labels:           map[string]string{"region": "eu"},
+			}}})
+			if code := status.Code(err); code != tt.want {
+				t.Errorf("CountServer.Add() code = %s, want %s", code, tt.want)
+			}
+		})
+	}
+
+	readTests := []struct {
+		name  string
+		store store.Store
+		req   *countv1.ListDailyTotalsRequest
+		want  codes.Code
+	}{
+		{"empty filter key", store.NewMemory(), &countv1.ListDailyTotalsRequest{StartDate: day, EndDate: day, LabelFilter: map[string]string{"": "eu"}}, codes.InvalidArgument},
+		{"empty group key", store.NewMemory(), &countv1.ListDailyTotalsRequest{StartDate: day, EndDate: day, GroupByLabels: []string{""}}, codes.InvalidArgument},
+		{"duplicate group key", store.NewMemory(), &countv1.ListDailyTotalsRequest{StartDate: day, EndDate: day, GroupByLabels: []string{"region", "region"}}, codes.InvalidArgument},
+		{"unsupported", struct{ store.Store }{store.NewMemory()}, &countv1.ListDailyTotalsRequest{StartDate: day, EndDate: day, GroupByLabels: []string{"region"}}, codes.Unimplemented},
+	}
+	for _, tt := range readTests {
+		t.Run("list "+tt.name, func(t *testing.T) {
+			s := NewCountService(grpc.NewServer(), tt.store, policy)
+			_, err := s.ListDailyTotals(ctx, tt.req)
+			if code := status.Code(err); code != tt.want {
+				t.Errorf("CountServer.ListDailyTotals() code = %s, want %s", code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultQuantiles are estimated when a GetLatencyStatsRequest has no quantiles.
+var defaultQuantiles = []float64{0.5, 0.95, 0.99}
+
+func (s *CountServer) GetLatencyStats(ctx context.Context, req *countv1.GetLatencyStatsRequest) (*countv1.GetLatencyStatsResponse, error) {
+	if err := s.checkTimeZone(req.GetTimeZone()); err != nil {
+		return nil, err
+	}
+
+	service, err := requestService(ctx, req.GetService())
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := periodInterval(req.GetPeriod(), req.GetWeek(), req.GetQuarter())
+	if err != nil {
+		return nil, err
+	}
+
+	quantiles := req.GetQuantiles()
+	if len(quantiles) == 0 {
+		quantiles = defaultQuantiles
+	}
+	for _, q := range quantiles {
+		if !(q >= 0 && q <= 1) {
+			return nil, status.Errorf(codes.InvalidArgument, "quantile %v out of range 0-1", q)
+		}
+	}
+
+	ctx, err = withFreshness(ctx, req.GetFreshness())
+	if err != nil {
+		return nil, err
+	}
+
+	dc, ok := s.store.(store.DetailCounter)
+	if !ok {
+		return nil, unsupported("GetLatencyStats")
+	}
+
+	stats, err := dc.GetLatencyStats(ctx, service, start, end, quantiles)
+	if err != nil {
+		return nil, err
+	}
+	if len(stats) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no latencies found between %q and %q", start, end)
+	}
+
+	return &countv1.GetLatencyStatsResponse{
+		LatencyStats: stats,
+	}, nil
+}
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// LimitMode determines what happens to datapoints over a rate limit.
+type LimitMode int
+
+const (
+	// LimitReject terminates the Add stream with ResourceExhausted.
+	LimitReject LimitMode = iota
+
+	// LimitSample drops datapoints over the limit and continues the stream.
+	// Only a sample of the requests is counted while a limit is exceeded.
+	LimitSample
+)
+
+// RateLimit is a token bucket of datapoints.
+type RateLimit struct {
+	// Rate of datapoints per second. Zero disables the limit.
+	Rate float64
+
+	// Burst is the amount of datapoints which can be added at once.
+	// Defaults to Rate, with a minimum of 1.
+	Burst int
+}
+
+// LimitPolicy limits the datapoints added by the Add RPC.
+type LimitPolicy struct {
+	// Global limits the datapoints of all clients together.
+	Global RateLimit
+
+	// Client limits the datapoints of each client.
+	// Clients are identified by their API key,
+	// or by their IP address when authentication is disabled.
+	Client RateLimit
+
+	// Mode of the rate limits. Defaults to LimitReject.
+	Mode LimitMode
+
+	// DailyQuota is the amount of datapoints per service and day,
+	// in the time zone of the server. Zero means unlimited.
+	// The quota is a soft limit: usage is only kept in memory,
+	// restarts at zero with the server, and is counted by each server
+	// on its own. Behind a load balancer with N servers, up to N times
+	// the quota can be added each day.
+	DailyQuota int64
+
+	// ServiceQuotas overrides DailyQuota for some services.
+	ServiceQuotas map[string]int64
+}
+
+// WithLimits sets the rate limits and quotas of the Add RPC.
+func WithLimits(policy LimitPolicy) Option {
+	return func(s *CountServer) {
+		s.limits.policy = policy
+	}
+}
+
+// errSampled is returned by limiter.admit for a dropped datapoint.
+var errSampled = errors.New("datapoint dropped by rate limit")
+
+type tokenBucket struct {
+	rate, burst, tokens float64
+	last                time.Time
+}
+
+func newTokenBucket(limit RateLimit, now time.Time) *tokenBucket {
+	burst := float64(limit.Burst)
+	if burst <= 0 {
+		burst = math.Max(limit.Rate, 1)
+	}
+	return &tokenBucket{
+		rate:   limit.Rate,
+		burst:  burst,
+		tokens: burst,
+		last:   now,
+	}
+}
+
+// refill the bucket with the tokens gained since the last call.
+func (b *tokenBucket) refill(now time.Time) {
+	if now.After(b.last) {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+	}
+}
+
+// take a token, reports false when the bucket is empty.
+func (b *tokenBucket) take(now time.Time) bool {
+	b.refill(now)
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// give back a token which was taken but not used.
+func (b *tokenBucket) give() {
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+// sweepInterval is the interval at which full client buckets
+// are removed, as they are equal to new buckets.
+const sweepInterval = time.Minute
+
+// limiter enforces a LimitPolicy and tracks quota usage.
+// The zero value has no limits.
+type limiter struct {
+	policy LimitPolicy
+
+	mu        sync.Mutex
+	global    *tokenBucket
+	clients   map[string]*tokenBucket
+	lastSweep time.Time
+
+	// day of the quota usage, reset when the day changes.
+	day  string
+	used map[string]int64
+}
+
+func (l *limiter) quotaLimit(service string) int64 {
+	if limit, ok := l.policy.ServiceQuotas[service]; ok {
+		return limit
+	}
+	return l.policy.DailyQuota
+}
+
+// takeRate takes a token from the client and global buckets.
+// No token is taken when either bucket is empty.
+func (l *limiter) takeRate(client string, now time.Time) error {
+	var taken *tokenBucket
+
+	if limit := l.policy.Client; limit.Rate > 0 {
+		if now.Sub(l.lastSweep) >= sweepInterval {
+			for id, b := range l.clients {
+				if b.refill(now); b.tokens >= b.burst {
+					delete(l.clients, id)
+				}
+			}
+			l.lastSweep = now
+		}
+
+		b, ok := l.clients[client]
+		if !ok {
+			if l.clients == nil {
+				l.clients = make(map[string]*tokenBucket)
+			}
+			b = newTokenBucket(limit, now)
+			l.clients[client] = b
+		}
+		if !b.take(now) {
+			return status.Errorf(codes.ResourceExhausted, "client rate limit of %g datapoints per second exceeded", limit.Rate)
+		}
+		taken = b
+	}
+
+	if limit := l.policy.Global; limit.Rate > 0 {
+		if l.global == nil {
+			l.global = newTokenBucket(limit, now)
+		}
+		if !l.global.take(now) {
+			if taken != nil {
+				taken.give()
+			}
+			return status.Errorf(codes.ResourceExhausted, "global rate limit of %g datapoints per second exceeded", limit.Rate)
+		}
+	}
+	return nil
+}
+
+// checkQuota returns a ResourceExhausted error when the quota
+// of service is used up on the day of now.
+func (l *limiter) checkQuota(service string, now time.Time) error {
+	if day := now.Format("2006-01-02"); day != l.day || l.used == nil {
+		l.day = day
+		l.used = make(map[string]int64)
+	}
+
+	limit := l.quotaLimit(service)
+	if limit > 0 && l.used[service] >= limit {
+		return status.Errorf(codes.ResourceExhausted, "daily quota of %d datapoints exceeded for service %q", limit, service)
+	}
+	return nil
+}
+
+// admit a datapoint of client for service, at now in the time zone of the server.
+// errSampled is returned when the datapoint must be dropped,
+// or a ResourceExhausted error when the stream must be terminated.
+// Rate tokens and quota are only used by admitted datapoints.
+func (l *limiter) admit(client, service string, now time.Time) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.checkQuota(service, now); err != nil {
+		return err
+	}
+	if err := l.takeRate(client, now); err != nil {
+		if l.policy.Mode == LimitSample {
+			return errSampled
+		}
+		return err
+	}
+	l.used[service]++
+	return nil
+}
+
+// quota returns the quota usage of service, at now in the time zone of the server.
+func (l *limiter) quota(service string, now time.Time) *countv1.Quota {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var used int64
+	if now.Format("2006-01-02") == l.day {
+		used = l.used[service]
+	}
+	limit := l.quotaLimit(service)
+	remaining := limit - used
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return &countv1.Quota{
+		Service:   service,
+		Date:      datepb.DateIn(now, now.Location()),
+		Limit:     limit,
+		Used:      used,
+		Remaining: remaining,
+	}
+}
+
+// clientID identifies the client of ctx for rate limiting,
+// by its API key or the IP address of the peer.
+func clientID(ctx context.Context) string {
+	if key, ok := APIKeyFromContext(ctx); ok {
+		return "api_key:" + strconv.FormatInt(key.GetId(), 10)
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		addr := p.Addr.String()
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			return "addr:" + host
+		}
+		return "addr:" + addr
+	}
+	return ""
+}
+
+func (s *CountServer) GetQuota(ctx context.Context, req *countv1.GetQuotaRequest) (*countv1.GetQuotaResponse, error) {
+	service, err := requestService(ctx, req.GetService())
+	if err != nil {
+		return nil, err
+	}
+	return &countv1.GetQuotaResponse{
+		Quota: s.limits.quota(service, time.Now().In(s.loc)),
+	}, nil
+}
@@ -0,0 +1,377 @@
+package service
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_tokenBucket(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newTokenBucket(RateLimit{Rate: 2, Burst: 3}, now)
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		want    bool
+	}{
+		{"burst 1", 0, true},
+		{"burst 2", 0, true},
+		{"burst 3", 0, true},
+		{"empty", 0, false},
+		{"half token", 250 * time.Millisecond, false},
+		{"refilled", 250 * time.Millisecond, true},
+		{"clock skew", -time.Second, false},
+		{"capped at burst", time.Hour, true},
+		{"capped 2", 0, true},
+		{"capped 3", 0, true},
+		{"capped empty", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = now.Add(tt.elapsed)
+			if got := b.take(now); got != tt.want {
+				t.Errorf("tokenBucket.take() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newTokenBucket_burst(t *testing.T) {
+	tests := []struct {
+		limit RateLimit
+		want  float64
+	}{
+		{RateLimit{Rate: 10}, 10},
+		{RateLimit{Rate: 0.5}, 1},
+		{RateLimit{Rate: 10, Burst: 20}, 20},
+	}
+	for _, tt := range tests {
+		if got := newTokenBucket(tt.limit, time.Time{}).burst; got != tt.want {
+			t.Errorf("newTokenBucket(%v).burst = %v, want %v", tt.limit, got, tt.want)
+		}
+	}
+}
+
+func Test_limiter_admit(t *testing.T) {
+	now := time.Date(2022, time.November, 10, 23, 59, 59, 0, time.UTC)
+
+	type admission struct {
+		client, service string
+		elapsed         time.Duration
+		wantCode        codes.Code
+		wantSampled     bool
+	}
+	tests := []struct {
+		name       string
+		policy     LimitPolicy
+		admissions []admission
+	}{
+		{
+			name: "no limits",
+			admissions: []admission{
+				{client: "a", service: "default"},
+				{client: "a", service: "default"},
+			},
+		},
+		{
+			name:   "client reject",
+			policy: LimitPolicy{Client: RateLimit{Rate: 1}},
+			admissions: []admission{
+				{client: "a", service: "default"},
+				{client: "b", service: "default"},
+				{client: "a", service: "default", wantCode: codes.ResourceExhausted},
+				{client: "a", service: "default", elapsed: time.Second},
+			},
+		},
+		{
+			name:   "global sample",
+			policy: LimitPolicy{Global: RateLimit{Rate: 1}, Mode: LimitSample},
+			admissions: []admission{
+				{client: "a", service: "default"},
+				{client: "b", service: "default", wantSampled: true},
+				{client: "b", service: "default", elapsed: time.Second},
+			},
+		},
+		{
+			name: "quota",
+			policy: LimitPolicy{
+				DailyQuota:    1,
+				ServiceQuotas: map[string]int64{"shop": 2, "blog": 0},
+			},
+			admissions: []admission{
+				{client: "a", service: "default"},
+				{client: "a", service: "default", wantCode: codes.ResourceExhausted},
+				{client: "a", service: "shop"},
+				{client: "a", service: "shop"},
+				{client: "a", service: "shop", wantCode: codes.ResourceExhausted},
+				{client: "a", service: "blog"},
+				{client: "a", service: "blog"},
+				// next day
+				{client: "a", service: "default", elapsed: time.Second},
+			},
+		},
+		{
+			name: "quota not used by sampled",
+			policy: LimitPolicy{
+				Client:     RateLimit{Rate: 1},
+				Mode:       LimitSample,
+				DailyQuota: 2,
+			},
+			admissions: []admission{
+				{client: "a", service: "default"},
+				{client: "a", service: "default", wantSampled: true},
+				{client: "b", service: "default"},
+				{client: "c", service: "default", wantCode: codes.ResourceExhausted},
+			},
+		},
+		{
+			name: "rate not used by quota",
+			policy: LimitPolicy{
+				Client:        RateLimit{Rate: 1, Burst: 2},
+				DailyQuota:    1,
+				ServiceQuotas: map[string]int64{"shop": 0},
+			},
+			admissions: []admission{
+				{client: "a", service: "default"},
+				{client: "a", service: "default", wantCode: codes.ResourceExhausted},
+				{client: "a", service: "shop"},
+			},
+		},
+		{
+			name: "client rate not used by global",
+			policy: LimitPolicy{
+				Global: RateLimit{Rate: 1},
+				Client: RateLimit{Rate: 0.5, Burst: 1},
+			},
+			admissions: []admission{
+				{client: "a", service: "default"},
+				{client: "b", service: "default", wantCode: codes.ResourceExhausted},
+				{client: "b", service: "default", elapsed: time.Second},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &limiter{policy: tt.policy}
+			now := now
+			for i, a := range tt.admissions {
+				now = now.Add(a.elapsed)
+				err := l.admit(a.client, a.service, now)
+				if sampled := err == errSampled; sampled != a.wantSampled {
+					t.Errorf("%d: limiter.admit() sampled = %v, want %v", i, sampled, a.wantSampled)
+				}
+				if a.wantSampled {
+					continue
+				}
+				if code := status.Code(err); code != a.wantCode {
+					t.Errorf("%d: limiter.admit() code = %s, want %s", i, code, a.wantCode)
+				}
+			}
+		})
+	}
+}
+
+func Test_limiter_sweep(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := &limiter{policy: LimitPolicy{Client: RateLimit{Rate: 1}}}
+
+	l.admit("a", "default", now)
+	now = now.Add(sweepInterval)
+	l.admit("b", "default", now)
+
+	if _, ok := l.clients["a"]; ok {
+		t.Error("limiter.admit() did not sweep full bucket of client a")
+	}
+	if _, ok := l.clients["b"]; !ok {
+		t.Error("limiter.admit() swept bucket of client b")
+	}
+}
+
+func Test_limiter_quota(t *testing.T) {
+	now := time.Date(2022, time.November, 10, 12, 0, 0, 0, time.UTC)
+	l := &limiter{policy: LimitPolicy{DailyQuota: 10}}
+	for i := 0; i < 3; i++ {
+		l.admit("a", "shop", now)
+	}
+
+	tests := []struct {
+		name    string
+		service string
+		now     time.Time
+		want    *countv1.Quota
+	}{
+		{
+			name:    "used",
+			service: "shop",
+			now:     now,
+			want: &countv1.Quota{
+				Service:   "shop",
+				Date:      &date.Date{Year: 2022, Month: 11, Day: 10},
+				Limit:     10,
+				Used:      3,
+				Remaining: 7,
+			},
+		},
+		{
+			name:    "unused",
+			service: "blog",
+			now:     now,
+			want: &countv1.Quota{
+				Service:   "blog",
+				Date:      &date.Date{Year: 2022, Month: 11, Day: 10},
+				Limit:     10,
+				Remaining: 10,
+			},
+		},
+		{
+			name:    "next day",
+			service: "shop",
+			now:     now.Add(24 * time.Hour),
+			want: &countv1.Quota{
+				Service:   "shop",
+				Date:      &date.Date{Year: 2022, Month: 11, Day: 11},
+				Limit:     10,
+				Remaining: 10,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := l.quota(tt.service, tt.now); !proto.Equal(got, tt.want) {
+				t.Errorf("limiter.quota() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_clientID(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"none", ctx, ""},
+		{
+			"peer",
+			peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1234}}),
+			"addr:10.0.0.1",
+		},
+		{
+			"api key",
+			context.WithValue(
+				peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1234}}),
+				apiKeyCtxKey{}, &countv1.ApiKey{Id: 3},
+			),
+			"api_key:3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := clientID(tt.ctx); got != tt.want {
+				t.Errorf("clientID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountServer_Add_limits(t *testing.T) {
+	ctx := context.Background()
+	stream := make([]*countv1.AddRequest, 5)
+	for i := range stream {
+		stream[i] = &countv1.AddRequest{
+			Method:           countv1.Method_GET,
+			Path:             "/foo",
+			RequestTimestamp: timestamppb.New(time.Date(2022, time.November, 10, 12, i, 0, 0, time.UTC)),
+		}
+	}
+
+	tests := []struct {
+		name      string
+		policy    LimitPolicy
+		wantCode  codes.Code
+		wantCount int64
+	}{
+		{
+			name:      "no limits",
+			wantCount: 5,
+		},
+		{
+			name:      "sample",
+			policy:    LimitPolicy{Client: RateLimit{Rate: 0.001, Burst: 2}, Mode: LimitSample},
+			wantCount: 2,
+		},
+		{
+			name:     "reject",
+			policy:   LimitPolicy{Global: RateLimit{Rate: 0.001, Burst: 2}},
+			wantCode: codes.ResourceExhausted,
+		},
+		{
+			name:     "quota",
+			policy:   LimitPolicy{DailyQuota: 4},
+			wantCode: codes.ResourceExhausted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mem := store.NewMemory()
+			s := NewCountService(grpc.NewServer(), mem, WithLimits(tt.policy))
+
+			err := s.Add(&mockAddServer{ctx: ctx, stream: stream})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("CountServer.Add() code = %s, want %s", code, tt.wantCode)
+			}
+			if tt.wantCode != codes.OK {
+				return
+			}
+
+			start, end := datepb.Interval(&date.Date{Year: 2022, Month: 11, Day: 10})
+			counts, err := mem.CountDailyMethodTotals(ctx, start, end)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(counts) != 1 || counts[0].GetCount() != tt.wantCount {
+				t.Errorf("CountServer.Add() counted %v, want %d", counts, tt.wantCount)
+			}
+
+			resp, err := s.GetQuota(ctx, &countv1.GetQuotaRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if used := resp.GetQuota().GetUsed(); used != tt.wantCount {
+				t.Errorf("CountServer.GetQuota() used = %d, want %d", used, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestCountServer_GetQuota_bound(t *testing.T) {
+	s := NewCountService(grpc.NewServer(), store.NewMemory())
+	ctx := context.WithValue(context.Background(), apiKeyCtxKey{}, &countv1.ApiKey{Id: 1, Service: "shop"})
+
+	resp, err := s.GetQuota(ctx, &countv1.GetQuotaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if service := resp.GetQuota().GetService(); service != "shop" {
+		t.Errorf("CountServer.GetQuota() service = %q, want %q", service, "shop")
+	}
+
+	_, err = s.GetQuota(ctx, &countv1.GetQuotaRequest{Service: "blog"})
+	if code := status.Code(err); code != codes.PermissionDenied {
+		t.Errorf("CountServer.GetQuota() code = %s, want %s", code, codes.PermissionDenied)
+	}
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/muhlemmer/count/internal/store"
+	"github.com/rs/zerolog"
+)
+
+// RunPartitioner creates partitions of count.requests for today and the
+// upcoming days in the time zone of the server.
+// Partitions are created immediately and after each interval,
+// until the context is canceled.
+// CountDailyTotals drops the partition of the counted day.
+// This is a no-op on databases which don't support partitioning.
+func (s *CountServer) RunPartitioner(ctx context.Context, days int, interval time.Duration) {
+	partitioner, ok := s.store.(store.Partitioner)
+	if !ok {
+		zerolog.Ctx(ctx).Warn().Msg("partitioning not supported by the storage backend")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		created, err := partitioner.PartitionRequests(ctx, s.loc, days)
+		zerolog.Ctx(ctx).Err(err).Int("created", created).Msg("count service partition requests")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
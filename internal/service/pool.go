@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"expvar"
+
+	"google.golang.org/grpc/status"
+)
+
+// DefaultMaxInserts is the default amount of concurrent
+// inserts of all Add streams.
+const DefaultMaxInserts = 100
+
+// Server metrics, published by expvar under "count_service".
+var (
+	metrics         = expvar.NewMap("count_service")
+	insertsInFlight = new(expvar.Int)
+	insertsWaiting  = new(expvar.Int)
+)
+
+func init() {
+	metrics.Set("inserts_in_flight", insertsInFlight)
+	metrics.Set("inserts_waiting", insertsWaiting)
+}
+
+// WithMaxInserts sets the amount of concurrent inserts
+// of all Add streams. Defaults to DefaultMaxInserts.
+func WithMaxInserts(n int) Option {
+	return func(s *CountServer) {
+		if n > 0 {
+			s.inserts = newInsertPool(n)
+		}
+	}
+}
+
+// insertPool bounds the amount of concurrent inserts.
+// The zero value is unbounded.
+type insertPool struct {
+	slots chan struct{}
+}
+
+func newInsertPool(n int) insertPool {
+	return insertPool{slots: make(chan struct{}, n)}
+}
+
+// acquire a slot for an insert, blocking until one
+// is released or ctx is done.
+func (p insertPool) acquire(ctx context.Context) error {
+	if p.slots != nil {
+		select {
+		case p.slots <- struct{}{}:
+		default:
+			insertsWaiting.Add(1)
+			defer insertsWaiting.Add(-1)
+
+			select {
+			case p.slots <- struct{}{}:
+			case <-ctx.Done():
+				return status.FromContextError(ctx.Err()).Err()
+			}
+		}
+	}
+	insertsInFlight.Add(1)
+	return nil
+}
+
+// release a slot acquired by acquire.
+func (p insertPool) release() {
+	insertsInFlight.Add(-1)
+	if p.slots != nil {
+		<-p.slots
+	}
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_insertPool(t *testing.T) {
+	ctx := context.Background()
+	p := newInsertPool(1)
+
+	if err := p.acquire(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := insertsInFlight.Value(); got != 1 {
+		t.Errorf("inserts_in_flight = %d, want 1", got)
+	}
+
+	shortCTX, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := p.acquire(shortCTX); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("insertPool.acquire() err = %v, want %s", err, codes.DeadlineExceeded)
+	}
+	if got := insertsWaiting.Value(); got != 0 {
+		t.Errorf("inserts_waiting = %d, want 0", got)
+	}
+
+	acquired := make(chan error)
+	go func() { acquired <- p.acquire(ctx) }()
+
+	select {
+	case <-acquired:
+		t.Fatal("insertPool.acquire() did not block")
+	case <-time.After(10 * time.Millisecond):
+	}
+	if got := insertsWaiting.Value(); got != 1 {
+		t.Errorf("inserts_waiting = %d, want 1", got)
+	}
+
+	p.release()
+	if err := <-acquired; err != nil {
+		t.Fatal(err)
+	}
+	p.release()
+	if got := insertsInFlight.Value(); got != 0 {
+		t.Errorf("inserts_in_flight = %d, want 0", got)
+	}
+
+	// zero value is unbounded
+	var unbounded insertPool
+	for i := 0; i < 3; i++ {
+		if err := unbounded.acquire(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		unbounded.release()
+	}
+}
+
+// blockingStore blocks inserts until release is closed
+// and records the maximum amount of concurrent inserts.
+type blockingStore struct {
+	store.Store
+
+	release           chan struct{}
+	inFlight, maxSeen atomic.Int64
+}
+
+func (s *blockingStore) InsertMethodRequest(ctx context.Context, service string, method countv1.Method, path string, requestTS time.Time) error {
+	n := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	for {
+		max := s.maxSeen.Load()
+		if n <= max || s.maxSeen.CompareAndSwap(max, n) {
+			break
+		}
+	}
+
+	<-s.release
+	return s.Store.InsertMethodRequest(ctx, service, method, path, requestTS)
+}
+
+func TestCountServer_Add_maxInserts(t *testing.T) {
+	ctx := context.Background()
+	stream := make([]*countv1.AddRequest, 10)
+	for i := range stream {
+		stream[i] = &countv1.AddRequest{
+			Method:           countv1.Method_GET,
+			Path:             "/foo",
+			RequestTimestamp: timestamppb.New(time.Unix(int64(i), 0)),
+		}
+	}
+
+	bs := &blockingStore{
+		Store:   store.NewMemory(),
+		release: make(chan struct{}),
+	}
+	s := NewCountService(grpc.NewServer(), bs, WithMaxInserts(2))
+
+	as := &mockAddServer{ctx: ctx, stream: stream}
+	done := make(chan error)
+	go func() { done <- s.Add(as) }()
+
+	time.Sleep(10 * time.Millisecond)
+	if got := bs.inFlight.Load(); got != 2 {
+		t.Errorf("CountServer.Add() inserts = %d, want 2", got)
+	}
+	// the third message is received and waits for an insert.
+	if got := insertsWaiting.Value(); got != 1 {
+		t.Errorf("inserts_waiting = %d, want 1", got)
+	}
+
+	close(bs.release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if got := bs.maxSeen.Load(); got != 2 {
+		t.Errorf("CountServer.Add() concurrent inserts = %d, want 2", got)
+	}
+	if got := insertsInFlight.Value(); got != 0 {
+		t.Errorf("inserts_in_flight = %d, want 0", got)
+	}
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+	"github.com/rs/zerolog"
+)
+
+// Retention defaults
+const (
+	DefaultPruneBatchSize = 1000
+)
+
+// RetentionPolicy determines how long data is kept.
+type RetentionPolicy struct {
+	// RequestsMaxAge is the maximum age of request entries.
+	// Older entries are counted into the daily totals and deleted.
+	// Pruning happens on whole days, so entries are kept
+	// untill the end of the day they exceed the maximum age.
+	// Zero disables pruning of requests.
+	RequestsMaxAge time.Duration
+
+	// DailyTotalsMaxAge is the maximum age of daily totals.
+	// Zero disables pruning of daily totals.
+	DailyTotalsMaxAge time.Duration
+
+	// KeepPeriodTotals keeps the monthly and yearly totals
+	// of deleted daily totals, down-sampling them.
+	// Daily totals are only pruned for whole months in this case.
+	// Otherwise, the monthly and yearly totals are reduced
+	// by the deleted daily totals, so that no data older
+	// than the maximum age is kept.
+	KeepPeriodTotals bool
+
+	// BatchSize is the amount of requests or daily totals
+	// pruned in a single transaction.
+	// Defaults to DefaultPruneBatchSize.
+	BatchSize int
+}
+
+// WithRetention sets the retention policy used by
+// the Prune method and RunPruner.
+func WithRetention(policy RetentionPolicy) Option {
+	return func(s *CountServer) {
+		if policy.BatchSize <= 0 {
+			policy.BatchSize = DefaultPruneBatchSize
+		}
+		s.retention = policy
+	}
+}
+
+// prune applies the retention policy, relative to now.
+func (s *CountServer) prune(ctx context.Context, now time.Time) (*countv1.PruneResponse, error) {
+	pruner, ok := s.store.(store.Pruner)
+	if !ok {
+		return nil, unsupported("Prune")
+	}
+
+	var (
+		policy = s.retention
+		resp   = new(countv1.PruneResponse)
+		err    error
+	)
+
+	if policy.RequestsMaxAge > 0 {
+		before := datepb.TimeIn(datepb.DateIn(now.Add(-policy.RequestsMaxAge), s.loc), s.loc)
+
+		resp.RequestsDeleted, err = pruner.PruneRequests(ctx, before, policy.BatchSize)
+		// pruned requests are counted into the daily totals.
+		s.cache.invalidate("", time.Time{}, before)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if policy.DailyTotalsMaxAge > 0 {
+		cutoff := datepb.DateIn(now.Add(-policy.DailyTotalsMaxAge), s.loc)
+		if policy.KeepPeriodTotals {
+			cutoff.Day = 0
+		}
+
+		resp.DailyTotalsDeleted, resp.MonthlyTotalsDownsampled, err = pruner.PruneDailyTotals(ctx, datepb.Time(cutoff), policy.KeepPeriodTotals, policy.BatchSize)
+		s.cache.invalidate("", time.Time{}, datepb.Time(cutoff))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *CountServer) Prune(ctx context.Context, req *countv1.PruneRequest) (*countv1.PruneResponse, error) {
+	return s.prune(ctx, time.Now())
+}
+
+// RunPruner applies the retention policy every interval,
+// untill the context is canceled.
+func (s *CountServer) RunPruner(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			resp, err := s.prune(ctx, now)
+			zerolog.Ctx(ctx).Err(err).
+				Int64("requests_deleted", resp.GetRequestsDeleted()).
+				Int64("daily_totals_deleted", resp.GetDailyTotalsDeleted()).
+				Int64("monthly_totals_downsampled", resp.GetMonthlyTotalsDownsampled()).
+				Msg("count service prune")
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestWithRetention(t *testing.T) {
+	s := new(CountServer)
+	WithRetention(RetentionPolicy{RequestsMaxAge: time.Hour})(s)
+
+	want := RetentionPolicy{
+		RequestsMaxAge: time.Hour,
+		BatchSize:      DefaultPruneBatchSize,
+	}
+	if s.retention != want {
+		t.Errorf("WithRetention() = %v, want %v", s.retention, want)
+	}
+}
+
+func TestCountServer_Prune(t *testing.T) {
+	const century = 100 * 365 * 24 * time.Hour
+
+	tests := []struct {
+		name    string
+		policy  RetentionPolicy
+		want    *countv1.PruneResponse
+		wantErr bool
+	}{
+		{
+			name:   "disabled",
+			policy: RetentionPolicy{},
+			want:   &countv1.PruneResponse{},
+		},
+		{
+			name: "invalid batch size",
+			policy: RetentionPolicy{
+				DailyTotalsMaxAge: century,
+			},
+			wantErr: true,
+		},
+		{
+			name: "nothing to prune",
+			policy: RetentionPolicy{
+				RequestsMaxAge:    century,
+				DailyTotalsMaxAge: century,
+				KeepPeriodTotals:  true,
+				BatchSize:         10,
+			},
+			want: &countv1.PruneResponse{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &CountServer{
+				store:     testServer.store,
+				loc:       time.UTC,
+				retention: tt.policy,
+			}
+
+			got, err := s.Prune(R.CTX, &countv1.PruneRequest{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CountServer.Prune() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("CountServer.Prune() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountServer_RunPruner(t *testing.T) {
+	s := &CountServer{
+		store: testServer.store,
+		loc:   time.UTC,
+	}
+
+	ctx, cancel := context.WithTimeout(R.CTX, 10*time.Millisecond)
+	defer cancel()
+
+	s.RunPruner(ctx, time.Millisecond)
+}
@@ -3,14 +3,16 @@ package service
 import (
 	"context"
 	"io"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
 
-	"github.com/muhlemmer/count/internal/db"
+	"github.com/muhlemmer/count/internal/store"
 	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
 	"github.com/muhlemmer/count/pkg/datepb"
 	"github.com/rs/zerolog"
+	"google.golang.org/genproto/googleapis/type/date"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -19,13 +21,117 @@ import (
 type CountServer struct {
 	countv1.UnimplementedCountServiceServer
 
-	db *db.DB
+	store     store.Store
+	loc       *time.Location
+	retention RetentionPolicy
+	limits    limiter
+	labels    labelLimiter
+	inserts   insertPool
+	cache     *responseCache
 }
 
-func NewCountService(s grpc.ServiceRegistrar, db *db.DB) {
-	countv1.RegisterCountServiceServer(s, &CountServer{
-		db: db,
-	})
+// Option configures a CountServer.
+type Option func(*CountServer)
+
+// WithTimeZone sets the time zone in which requests are
+// counted into days. Defaults to UTC.
+func WithTimeZone(loc *time.Location) Option {
+	return func(s *CountServer) {
+		s.loc = loc
+	}
+}
+
+// NewCountService creates a CountServer and registers it on s.
+// Some RPCs return Unimplemented when not supported by the store.
+func NewCountService(s grpc.ServiceRegistrar, store store.Store, opts ...Option) *CountServer {
+	server := &CountServer{
+		store:   store,
+		loc:     time.UTC,
+		inserts: newInsertPool(DefaultMaxInserts),
+	}
+	for _, opt := range opts {
+		opt(server)
+	}
+
+	countv1.RegisterCountServiceServer(s, server)
+	return server
+}
+
+// checkTimeZone returns an error if tz is not empty
+// and does not match the time zone of the server.
+// Time zones match when they have the same offsets,
+// such as "UTC" and "Etc/UTC".
+func (s *CountServer) checkTimeZone(tz string) error {
+	if tz == "" || tz == s.loc.String() {
+		return nil
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "time_zone: %v", err)
+	}
+	if sameZone(loc, s.loc) {
+		return nil
+	}
+
+	return status.Errorf(codes.FailedPrecondition, "time_zone %q does not match server time zone %q", tz, s.loc)
+}
+
+// Interval in which sameZone compares time zones.
+var (
+	zoneCompareStart = time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC)
+	zoneCompareEnd   = time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// sameZone reports whether a and b have the same offset
+// at all instants between zoneCompareStart and zoneCompareEnd.
+// The offsets are compared once for each zone period of a and b.
+func sameZone(a, b *time.Location) bool {
+	for t := zoneCompareStart; t.Before(zoneCompareEnd); {
+		ta, tb := t.In(a), t.In(b)
+		_, offA := ta.Zone()
+		_, offB := tb.Zone()
+		if offA != offB {
+			return false
+		}
+
+		_, endA := ta.ZoneBounds()
+		_, endB := tb.ZoneBounds()
+		next := zoneCompareEnd
+		if !endA.IsZero() && endA.Before(next) {
+			next = endA
+		}
+		if !endB.IsZero() && endB.Before(next) {
+			next = endB
+		}
+		if !next.After(t) {
+			// ZoneBounds may not advance at the end of a year
+			// of which the zone periods are computed from a rule.
+			next = t.Add(time.Hour)
+		}
+		t = next
+	}
+	return true
+}
+
+// requestService returns the service of which an RPC
+// adds or reads requests. When the API key of ctx is bound to a service,
+// that service is returned and service must be empty or equal to it.
+// Otherwise service is returned, or store.DefaultService when empty.
+func requestService(ctx context.Context, service string) (string, error) {
+	if key, ok := APIKeyFromContext(ctx); ok && key.GetService() != "" {
+		if service != "" && service != key.GetService() {
+			return "", status.Errorf(codes.PermissionDenied, "api key %d is bound to service %q", key.GetId(), key.GetService())
+		}
+		return key.GetService(), nil
+	}
+	if service == "" {
+		return store.DefaultService, nil
+	}
+	return service, nil
+}
+
+func unsupported(rpc string) error {
+	return status.Errorf(codes.Unimplemented, "%s not supported by the storage backend", rpc)
 }
 
 func (s *CountServer) Add(as countv1.CountService_AddServer) error {
@@ -36,10 +142,17 @@ func (s *CountServer) Add(as countv1.CountService_AddServer) error {
 	go func() {
 		defer wg.Done()
 
-		var abort atomic.Bool
+		var (
+			abort   atomic.Bool
+			client  = clientID(as.Context())
+			sampled int
+		)
 		for {
 			req, err := as.Recv()
 			if err == io.EOF || abort.Load() {
+				if sampled > 0 {
+					zerolog.Ctx(as.Context()).Warn().Int("sampled", sampled).Msg("count service stream add dropped datapoints over rate limit")
+				}
 				if err = as.SendAndClose(&countv1.AddResponse{}); err != nil {
 					errChan <- err
 				}
@@ -50,8 +163,38 @@ func (s *CountServer) Add(as countv1.CountService_AddServer) error {
 				return
 			}
 
+			service, err := requestService(as.Context(), req.GetService())
+			var details store.RequestDetails
+			if err == nil {
+				details, err = requestDetails(req)
+			}
+			if err == nil {
+				err = s.limits.admit(client, service, time.Now().In(s.loc))
+			}
+			if err == nil {
+				details.Labels, err = s.requestLabels(service, req.GetLabels())
+			}
+			if err == errSampled {
+				sampled++
+				continue
+			}
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			// Blocks while all inserts are in flight, so that the next message
+			// is not received and gRPC flow control pushes back on the client.
+			if err = s.inserts.acquire(as.Context()); err != nil {
+				errChan <- err
+				return
+			}
+
 			wg.Add(1)
 			go func() {
+				defer wg.Done()
+				defer s.inserts.release()
+
 				ctx, cancel := context.WithTimeout(as.Context(), time.Minute)
 				defer cancel()
 
@@ -62,17 +205,16 @@ func (s *CountServer) Add(as countv1.CountService_AddServer) error {
 				)
 
 				// add some details to the logger passed to the DB layer.
-				logger := zerolog.Ctx(ctx).With().Stringer("method", method).Str("path", path).Time("request_timestamp", requestTS).Logger()
+				logger := zerolog.Ctx(ctx).With().Str("service", service).Stringer("method", method).Str("path", path).Time("request_timestamp", requestTS).Logger()
 				ctx = logger.WithContext(ctx)
 
-				err := s.db.InsertMethodRequest(ctx, method, path, requestTS)
-				logger.Err(err).Msg("count service stream add request")
+				err := s.insertRequest(ctx, service, method, path, requestTS, details)
+				zerolog.Ctx(ctx).Err(err).Msg("count service stream add request")
 
 				if err != nil {
 					errChan <- err
 					abort.Store(true)
 				}
-				wg.Done()
 			}()
 		}
 	}()
@@ -104,10 +246,15 @@ func (s *CountServer) CountDailyTotals(ctx context.Context, req *countv1.CountDa
 	if date == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "date required")
 	}
+	if err := s.checkTimeZone(req.GetTimeZone()); err != nil {
+		return nil, err
+	}
 
-	start, end := datepb.Interval(date)
+	start, end := datepb.IntervalIn(date, s.loc)
 
-	counts, err := s.db.CountDailyMethodTotals(ctx, start, end)
+	counts, err := s.store.CountDailyMethodTotals(ctx, start, end)
+	day := datepb.Time(date)
+	s.cache.invalidate("", day, day)
 	if err != nil {
 		return nil, err
 	}
@@ -127,10 +274,34 @@ func (s *CountServer) ListDailyTotals(ctx context.Context, req *countv1.ListDail
 	if endDate == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "end_date required")
 	}
+	if err := s.checkTimeZone(req.GetTimeZone()); err != nil {
+		return nil, err
+	}
+
+	service, err := requestService(ctx, req.GetService())
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = withFreshness(ctx, req.GetFreshness())
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := s.labelQuery("ListDailyTotals", req.GetLabelFilter(), req.GetGroupByLabels())
+	if err != nil {
+		return nil, err
+	}
+	query := s.store.ListDailyTotals
+	if !q.Empty() {
+		query = func(ctx context.Context, service string, start, end time.Time) ([]*countv1.MethodCount, error) {
+			return s.store.(store.Labeler).ListLabeledTotals(ctx, service, start, end, q)
+		}
+	}
 
 	start, end := datepb.Time(startDate), datepb.Time(endDate)
 
-	counts, err := s.db.ListDailyTotals(ctx, start, end)
+	counts, err := s.cachedTotals(ctx, "ListDailyTotals", service, q, start, end, query)
 	if err != nil {
 		return nil, err
 	}
@@ -143,23 +314,235 @@ func (s *CountServer) ListDailyTotals(ctx context.Context, req *countv1.ListDail
 	}, nil
 }
 
+// periodInterval returns the interval of the requested period type,
+// of which at most one is set.
+func periodInterval(period *date.Date, week *countv1.IsoWeek, quarter *countv1.Quarter) (start, end time.Time, err error) {
+	switch {
+	case period != nil:
+		start, end = datepb.Interval(period)
+		return start, end, nil
+
+	case week != nil:
+		year, week := int(week.GetYear()), int(week.GetWeek())
+		if week < 1 || week > datepb.WeeksInYear(year) {
+			return start, end, status.Errorf(codes.InvalidArgument, "week %d out of range for year %d", week, year)
+		}
+		start, end = datepb.WeekInterval(year, week)
+		return start, end, nil
+
+	case quarter != nil:
+		year, quarter := int(quarter.GetYear()), int(quarter.GetQuarter())
+		if quarter < 1 || quarter > 4 {
+			return start, end, status.Errorf(codes.InvalidArgument, "quarter %d out of range", quarter)
+		}
+		start, end = datepb.QuarterInterval(year, quarter)
+		return start, end, nil
+	}
+
+	return start, end, status.Errorf(codes.InvalidArgument, "period, week or quarter required")
+}
+
 func (s *CountServer) GetPeriodTotals(ctx context.Context, req *countv1.GetPeriodTotalsRequest) (*countv1.GetPeriodTotalsResponse, error) {
+	if err := s.checkTimeZone(req.GetTimeZone()); err != nil {
+		return nil, err
+	}
+
+	service, err := requestService(ctx, req.GetService())
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := periodInterval(req.GetPeriod(), req.GetWeek(), req.GetQuarter())
+	if err != nil {
+		return nil, err
+	}
+	ctx, err = withFreshness(ctx, req.GetFreshness())
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := s.labelQuery("GetPeriodTotals", req.GetLabelFilter(), req.GetGroupByLabels())
+	if err != nil {
+		return nil, err
+	}
+	query := s.store.GetPeriodTotals
+	if !q.Empty() {
+		query = func(ctx context.Context, service string, start, end time.Time) ([]*countv1.MethodCount, error) {
+			return s.store.(store.Labeler).GetLabeledPeriodTotals(ctx, service, start, end, q)
+		}
+	}
+
+	counts, err := s.cachedTotals(ctx, "GetPeriodTotals", service, q, start, end, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(counts) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no results found between %q and %q", start, end)
+	}
+
+	return &countv1.GetPeriodTotalsResponse{
+		MethodCounts: counts,
+	}, nil
+}
+
+func (s *CountServer) ComparePeriods(ctx context.Context, req *countv1.ComparePeriodsRequest) (*countv1.ComparePeriodsResponse, error) {
 	period := req.GetPeriod()
 	if period == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "period required")
 	}
 
+	refPeriod := req.GetReferencePeriod()
+	if refPeriod == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "reference_period required")
+	}
+	if err := s.checkTimeZone(req.GetTimeZone()); err != nil {
+		return nil, err
+	}
+
+	service, err := requestService(ctx, req.GetService())
+	if err != nil {
+		return nil, err
+	}
+
 	start, end := datepb.Interval(period)
+	refStart, refEnd := datepb.Interval(refPeriod)
+
+	comparer, ok := s.store.(store.PeriodComparer)
+	if !ok {
+		return nil, unsupported("ComparePeriods")
+	}
 
-	counts, err := s.db.GetPeriodTotals(ctx, start, end)
+	comparisons, err := comparer.ComparePeriods(ctx, service, start, end, refStart, refEnd)
 	if err != nil {
 		return nil, err
 	}
-	if len(counts) == 0 {
+	if len(comparisons) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no results found between %q and %q, or %q and %q", start, end, refStart, refEnd)
+	}
+
+	return &countv1.ComparePeriodsResponse{
+		Comparisons: comparisons,
+	}, nil
+}
+
+var bucketPrecisions = map[countv1.BucketSize]datepb.Precision{
+	countv1.BucketSize_BUCKET_SIZE_DAY:     datepb.Day,
+	countv1.BucketSize_BUCKET_SIZE_WEEK:    datepb.Week,
+	countv1.BucketSize_BUCKET_SIZE_MONTH:   datepb.Month,
+	countv1.BucketSize_BUCKET_SIZE_QUARTER: datepb.Quarter,
+	countv1.BucketSize_BUCKET_SIZE_YEAR:    datepb.Year,
+}
+
+type methodPath struct {
+	method countv1.Method
+	path   string
+}
+
+// zeroFill returns counts for every bucket between start and end,
+// for each method and path pair found in counts.
+// Missing entries are added with a zero count.
+// Paths which are not found in counts are added
+// with an unspecified method and a zero count.
+// The result is ordered by date, path and method.
+func zeroFill(counts []*countv1.MethodCount, paths []string, start, end time.Time, precision datepb.Precision) []*countv1.MethodCount {
+	type bucketKey struct {
+		methodPath
+		bucket time.Time
+	}
+
+	var (
+		pairs   []methodPath
+		seen    = make(map[methodPath]bool)
+		indexed = make(map[bucketKey]*countv1.MethodCount, len(counts))
+	)
+
+	for _, mc := range counts {
+		mp := methodPath{mc.GetMethod(), mc.GetPath()}
+		if !seen[mp] {
+			seen[mp] = true
+			pairs = append(pairs, mp)
+		}
+		indexed[bucketKey{mp, datepb.Time(mc.GetDate())}] = mc
+	}
+
+	found := make(map[string]bool, len(pairs))
+	for _, mp := range pairs {
+		found[mp.path] = true
+	}
+	for _, path := range paths {
+		if !found[path] {
+			found[path] = true
+			pairs = append(pairs, methodPath{countv1.Method_METHOD_UNSPECIFIED, path})
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].path != pairs[j].path {
+			return pairs[i].path < pairs[j].path
+		}
+		return pairs[i].method.String() < pairs[j].method.String()
+	})
+
+	buckets := datepb.Buckets(start, end, precision)
+	results := make([]*countv1.MethodCount, 0, len(buckets)*len(pairs))
+
+	for _, bucket := range buckets {
+		for _, mp := range pairs {
+			mc, ok := indexed[bucketKey{mp, bucket}]
+			if !ok {
+				mc = &countv1.MethodCount{
+					Method: mp.method,
+					Path:   mp.path,
+					Date:   datepb.Date(bucket),
+				}
+			}
+			results = append(results, mc)
+		}
+	}
+
+	return results
+}
+
+func (s *CountServer) GetTimeSeries(ctx context.Context, req *countv1.GetTimeSeriesRequest) (*countv1.GetTimeSeriesResponse, error) {
+	startDate := req.GetStartDate()
+	if startDate == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "start_date required")
+	}
+
+	endDate := req.GetEndDate()
+	if endDate == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "end_date required")
+	}
+	if err := s.checkTimeZone(req.GetTimeZone()); err != nil {
+		return nil, err
+	}
+
+	precision, ok := bucketPrecisions[req.GetBucketSize()]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid bucket_size %s", req.GetBucketSize())
+	}
+
+	service, err := requestService(ctx, req.GetService())
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := datepb.Time(startDate), datepb.Time(endDate)
+
+	reader, ok := s.store.(store.TimeSeriesReader)
+	if !ok {
+		return nil, unsupported("GetTimeSeries")
+	}
+
+	counts, err := reader.GetTimeSeries(ctx, service, start, end, precision, req.GetPaths())
+	if err != nil {
+		return nil, err
+	}
+	if len(counts) == 0 && len(req.GetPaths()) == 0 {
 		return nil, status.Errorf(codes.NotFound, "no results found between %q and %q", start, end)
 	}
 
-	return &countv1.GetPeriodTotalsResponse{
-		MethodCounts: counts,
+	return &countv1.GetTimeSeriesResponse{
+		MethodCounts: zeroFill(counts, req.GetPaths(), start, end, precision),
 	}, nil
 }
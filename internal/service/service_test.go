@@ -7,13 +7,17 @@ import (
 	"os"
 	"testing"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/muhlemmer/count/internal/db"
+	"github.com/muhlemmer/count/internal/store"
 	"github.com/muhlemmer/count/internal/tester"
 	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
 	"github.com/muhlemmer/count/pkg/datepb"
 	"google.golang.org/genproto/googleapis/type/date"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -28,7 +32,8 @@ func TestMain(m *testing.M) {
 		tester.RunWithData(5*time.Minute, func(r *tester.Resources) int {
 			R = r
 			testServer = &CountServer{
-				db: db.Wrap(R.Pool),
+				store: db.Wrap(R.Pool),
+				loc:   time.UTC,
 			}
 			return m.Run()
 		}),
@@ -36,7 +41,90 @@ func TestMain(m *testing.M) {
 }
 
 func TestNewCountService(t *testing.T) {
-	NewCountService(grpc.NewServer(), db.Wrap(R.Pool))
+	NewCountService(grpc.NewServer(), db.Wrap(R.Pool), WithTimeZone(time.UTC))
+}
+
+func TestCountServer_unsupported(t *testing.T) {
+	s := NewCountService(grpc.NewServer(), store.NewMemory())
+	period := &date.Date{Year: 1986, Month: 3}
+
+	_, err := s.ComparePeriods(R.CTX, &countv1.ComparePeriodsRequest{
+		Period:          period,
+		ReferencePeriod: period,
+	})
+	if code := status.Code(err); code != codes.Unimplemented {
+		t.Errorf("CountServer.ComparePeriods() code = %s, want %s", code, codes.Unimplemented)
+	}
+
+	_, err = s.GetTimeSeries(R.CTX, &countv1.GetTimeSeriesRequest{
+		StartDate:  period,
+		EndDate:    period,
+		BucketSize: countv1.BucketSize_BUCKET_SIZE_DAY,
+	})
+	if code := status.Code(err); code != codes.Unimplemented {
+		t.Errorf("CountServer.GetTimeSeries() code = %s, want %s", code, codes.Unimplemented)
+	}
+
+	_, err = s.Prune(R.CTX, &countv1.PruneRequest{})
+	if code := status.Code(err); code != codes.Unimplemented {
+		t.Errorf("CountServer.Prune() code = %s, want %s", code, codes.Unimplemented)
+	}
+
+	// returns immediately
+	s.RunPartitioner(R.CTX, 1, time.Hour)
+}
+
+func TestCountServer_checkTimeZone(t *testing.T) {
+	tests := []struct {
+		name     string
+		tz       string
+		wantCode codes.Code
+	}{
+		{"empty", "", codes.OK},
+		{"server zone", "UTC", codes.OK},
+		{"same offsets", "Etc/UTC", codes.OK},
+		{"invalid", "Foo/Bar", codes.InvalidArgument},
+		{"other zone", "Europe/Zurich", codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testServer.checkTimeZone(tt.tz)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("CountServer.checkTimeZone() = %v, want %v", code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_sameZone(t *testing.T) {
+	load := func(name string) *time.Location {
+		t.Helper()
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return loc
+	}
+
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"UTC", "Etc/UTC", true},
+		{"Europe/Zurich", "Europe/Zurich", true},
+		{"Asia/Kolkata", "Asia/Calcutta", true},
+		{"Europe/Zurich", "Europe/Berlin", false}, // differed until 1980
+		{"Europe/Zurich", "UTC", false},
+		{"America/New_York", "America/Toronto", false},
+		{"Etc/GMT-1", "Europe/Zurich", false}, // no summer time
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			if got := sameZone(load(tt.a), load(tt.b)); got != tt.want {
+				t.Errorf("sameZone() = %t, want %t", got, tt.want)
+			}
+		})
+	}
 }
 
 type mockAddServer struct {
@@ -208,23 +296,31 @@ func TestCountServer_CountDailyTotals(t *testing.T) {
 			args:    args{R.CTX, &countv1.CountDailyTotalsRequest{}},
 			wantErr: true,
 		},
+		{
+			name: "time zone mismatch",
+			args: args{R.CTX, &countv1.CountDailyTotalsRequest{
+				Date:     datepb.Date(date),
+				TimeZone: "Europe/Zurich",
+			}},
+			wantErr: true,
+		},
 		{
 			name: "success",
 			args: args{R.CTX, &countv1.CountDailyTotalsRequest{Date: datepb.Date(date)}},
 			want: &countv1.CountDailyTotalsResponse{
 				MethodCounts: []*countv1.MethodCount{
-					{Method: countv1.Method_DELETE, Path: "/actions", Count: 50, Date: datepb.Date(date)},
-					{Method: countv1.Method_GET, Path: "/actions", Count: 50, Date: datepb.Date(date)},
-					{Method: countv1.Method_GRPC, Path: "/actions", Count: 50, Date: datepb.Date(date)},
-					{Method: countv1.Method_POST, Path: "/actions", Count: 50, Date: datepb.Date(date)},
-					{Method: countv1.Method_DELETE, Path: "/items", Count: 50, Date: datepb.Date(date)},
-					{Method: countv1.Method_GET, Path: "/items", Count: 50, Date: datepb.Date(date)},
-					{Method: countv1.Method_GRPC, Path: "/items", Count: 50, Date: datepb.Date(date)},
-					{Method: countv1.Method_POST, Path: "/items", Count: 50, Date: datepb.Date(date)},
-					{Method: countv1.Method_DELETE, Path: "/users", Count: 50, Date: datepb.Date(date)},
-					{Method: countv1.Method_GET, Path: "/users", Count: 49, Date: datepb.Date(date)},
-					{Method: countv1.Method_GRPC, Path: "/users", Count: 50, Date: datepb.Date(date)},
-					{Method: countv1.Method_POST, Path: "/users", Count: 50, Date: datepb.Date(date)},
+					{Method: countv1.Method_DELETE, Path: "/actions", Count: 50, Date: datepb.Date(date), Service: store.DefaultService},
+					{Method: countv1.Method_GET, Path: "/actions", Count: 50, Date: datepb.Date(date), Service: store.DefaultService},
+					{Method: countv1.Method_GRPC, Path: "/actions", Count: 50, Date: datepb.Date(date), Service: store.DefaultService},
+					{Method: countv1.Method_POST, Path: "/actions", Count: 50, Date: datepb.Date(date), Service: store.DefaultService},
+					{Method: countv1.Method_DELETE, Path: "/items", Count: 50, Date: datepb.Date(date), Service: store.DefaultService},
+					{Method: countv1.Method_GET, Path: "/items", Count: 50, Date: datepb.Date(date), Service: store.DefaultService},
+					{Method: countv1.Method_GRPC, Path: "/items", Count: 50, Date: datepb.Date(date), Service: store.DefaultService},
+					{Method: countv1.Method_POST, Path: "/items", Count: 50, Date: datepb.Date(date), Service: store.DefaultService},
+					{Method: countv1.Method_DELETE, Path: "/users", Count: 50, Date: datepb.Date(date), Service: store.DefaultService},
+					{Method: countv1.Method_GET, Path: "/users", Count: 49, Date: datepb.Date(date), Service: store.DefaultService},
+					{Method: countv1.Method_GRPC, Path: "/users", Count: 50, Date: datepb.Date(date), Service: store.DefaultService},
+					{Method: countv1.Method_POST, Path: "/users", Count: 50, Date: datepb.Date(date), Service: store.DefaultService},
 				},
 			},
 		},
@@ -364,23 +460,71 @@ func TestCountServer_GetPeriodTotals(t *testing.T) {
 		},
 		{
 			name: "context error",
-			args: args{R.ErrCTX, &countv1.GetPeriodTotalsRequest{Period: &date.Date{
+			args: args{R.ErrCTX, &countv1.GetPeriodTotalsRequest{PeriodType: &countv1.GetPeriodTotalsRequest_Period{Period: &date.Date{
 				Year: 1986,
-			}}},
+			}}}},
 			wantErr: true,
 		},
 		{
 			name: "not found",
-			args: args{R.CTX, &countv1.GetPeriodTotalsRequest{Period: &date.Date{
+			args: args{R.CTX, &countv1.GetPeriodTotalsRequest{PeriodType: &countv1.GetPeriodTotalsRequest_Period{Period: &date.Date{
 				Year: 1977,
-			}}},
+			}}}},
 			wantErr: true,
 		},
+		{
+			name: "invalid week",
+			args: args{R.CTX, &countv1.GetPeriodTotalsRequest{PeriodType: &countv1.GetPeriodTotalsRequest_Week{Week: &countv1.IsoWeek{
+				Year: 2022,
+				Week: 53,
+			}}}},
+			wantErr: true,
+		},
+		{
+			name: "invalid quarter",
+			args: args{R.CTX, &countv1.GetPeriodTotalsRequest{PeriodType: &countv1.GetPeriodTotalsRequest_Quarter{Quarter: &countv1.Quarter{
+				Year:    1986,
+				Quarter: 5,
+			}}}},
+			wantErr: true,
+		},
+		{
+			name: "week not found",
+			args: args{R.CTX, &countv1.GetPeriodTotalsRequest{PeriodType: &countv1.GetPeriodTotalsRequest_Week{Week: &countv1.IsoWeek{
+				Year: 1986,
+				Week: 1,
+			}}}},
+			wantErr: true,
+		},
+		{
+			// data starts in march, so the first quarter equals the month of march.
+			name: "quarter",
+			args: args{R.CTX, &countv1.GetPeriodTotalsRequest{PeriodType: &countv1.GetPeriodTotalsRequest_Quarter{Quarter: &countv1.Quarter{
+				Year:    1986,
+				Quarter: 1,
+			}}}},
+			want: &countv1.GetPeriodTotalsResponse{
+				MethodCounts: []*countv1.MethodCount{
+					{Path: "/actions", Method: countv1.Method_DELETE, Count: 6762},
+					{Path: "/actions", Method: countv1.Method_GET, Count: 11360},
+					{Path: "/actions", Method: countv1.Method_GRPC, Count: 9233},
+					{Path: "/actions", Method: countv1.Method_POST, Count: 8438},
+					{Path: "/items", Method: countv1.Method_DELETE, Count: 7759},
+					{Path: "/items", Method: countv1.Method_GET, Count: 8490},
+					{Path: "/items", Method: countv1.Method_GRPC, Count: 7744},
+					{Path: "/items", Method: countv1.Method_POST, Count: 7931},
+					{Path: "/users", Method: countv1.Method_DELETE, Count: 7220},
+					{Path: "/users", Method: countv1.Method_GET, Count: 8138},
+					{Path: "/users", Method: countv1.Method_GRPC, Count: 9961},
+					{Path: "/users", Method: countv1.Method_POST, Count: 9510},
+				},
+			},
+		},
 		{
 			name: "year",
-			args: args{R.CTX, &countv1.GetPeriodTotalsRequest{Period: &date.Date{
+			args: args{R.CTX, &countv1.GetPeriodTotalsRequest{PeriodType: &countv1.GetPeriodTotalsRequest_Period{Period: &date.Date{
 				Year: 1986,
-			}}},
+			}}}},
 			want: &countv1.GetPeriodTotalsResponse{
 				MethodCounts: []*countv1.MethodCount{
 					{Path: "/actions", Method: countv1.Method_DELETE, Count: 12721},
@@ -400,10 +544,10 @@ func TestCountServer_GetPeriodTotals(t *testing.T) {
 		},
 		{
 			name: "month",
-			args: args{R.CTX, &countv1.GetPeriodTotalsRequest{Period: &date.Date{
+			args: args{R.CTX, &countv1.GetPeriodTotalsRequest{PeriodType: &countv1.GetPeriodTotalsRequest_Period{Period: &date.Date{
 				Year:  1986,
 				Month: 3,
-			}}},
+			}}}},
 			want: &countv1.GetPeriodTotalsResponse{
 				MethodCounts: []*countv1.MethodCount{
 					{Path: "/actions", Method: countv1.Method_DELETE, Count: 6762},
@@ -423,11 +567,11 @@ func TestCountServer_GetPeriodTotals(t *testing.T) {
 		},
 		{
 			name: "day",
-			args: args{R.CTX, &countv1.GetPeriodTotalsRequest{Period: &date.Date{
+			args: args{R.CTX, &countv1.GetPeriodTotalsRequest{PeriodType: &countv1.GetPeriodTotalsRequest_Period{Period: &date.Date{
 				Year:  1986,
 				Month: 3,
 				Day:   25,
-			}}},
+			}}}},
 			want: &countv1.GetPeriodTotalsResponse{
 				MethodCounts: []*countv1.MethodCount{
 					{Path: "/actions", Method: countv1.Method_DELETE, Count: 46},
@@ -459,3 +603,240 @@ func TestCountServer_GetPeriodTotals(t *testing.T) {
 		})
 	}
 }
+
+func TestCountServer_ComparePeriods(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		req *countv1.ComparePeriodsRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *countv1.ComparePeriodsResponse
+		wantErr bool
+	}{
+		{
+			name: "missing period",
+			args: args{R.CTX, &countv1.ComparePeriodsRequest{
+				ReferencePeriod: &date.Date{Year: 1986},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "missing reference period",
+			args: args{R.CTX, &countv1.ComparePeriodsRequest{
+				Period: &date.Date{Year: 1986},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "context error",
+			args: args{R.ErrCTX, &countv1.ComparePeriodsRequest{
+				Period:          &date.Date{Year: 1986},
+				ReferencePeriod: &date.Date{Year: 1985},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "not found",
+			args: args{R.CTX, &countv1.ComparePeriodsRequest{
+				Period:          &date.Date{Year: 1977},
+				ReferencePeriod: &date.Date{Year: 1976},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			args: args{R.CTX, &countv1.ComparePeriodsRequest{
+				Period:          &date.Date{Year: 1986, Month: 3, Day: 25},
+				ReferencePeriod: &date.Date{Year: 1985},
+			}},
+			want: &countv1.ComparePeriodsResponse{
+				Comparisons: []*countv1.PeriodComparison{
+					{Path: "/actions", Method: countv1.Method_DELETE, Count: 46, Delta: 46, Presence: countv1.PeriodPresence_PERIOD_PRESENCE_PERIOD_ONLY},
+					{Path: "/actions", Method: countv1.Method_GET, Count: 565, Delta: 565, Presence: countv1.PeriodPresence_PERIOD_PRESENCE_PERIOD_ONLY},
+					{Path: "/actions", Method: countv1.Method_GRPC, Count: 725, Delta: 725, Presence: countv1.PeriodPresence_PERIOD_PRESENCE_PERIOD_ONLY},
+					{Path: "/actions", Method: countv1.Method_POST, Count: 211, Delta: 211, Presence: countv1.PeriodPresence_PERIOD_PRESENCE_PERIOD_ONLY},
+					{Path: "/items", Method: countv1.Method_DELETE, Count: 756, Delta: 756, Presence: countv1.PeriodPresence_PERIOD_PRESENCE_PERIOD_ONLY},
+					{Path: "/items", Method: countv1.Method_GET, Count: 853, Delta: 853, Presence: countv1.PeriodPresence_PERIOD_PRESENCE_PERIOD_ONLY},
+					{Path: "/items", Method: countv1.Method_GRPC, Count: 210, Delta: 210, Presence: countv1.PeriodPresence_PERIOD_PRESENCE_PERIOD_ONLY},
+					{Path: "/items", Method: countv1.Method_POST, Count: 107, Delta: 107, Presence: countv1.PeriodPresence_PERIOD_PRESENCE_PERIOD_ONLY},
+					{Path: "/users", Method: countv1.Method_DELETE, Count: 627, Delta: 627, Presence: countv1.PeriodPresence_PERIOD_PRESENCE_PERIOD_ONLY},
+					{Path: "/users", Method: countv1.Method_GET, Count: 473, Delta: 473, Presence: countv1.PeriodPresence_PERIOD_PRESENCE_PERIOD_ONLY},
+					{Path: "/users", Method: countv1.Method_GRPC, Count: 856, Delta: 856, Presence: countv1.PeriodPresence_PERIOD_PRESENCE_PERIOD_ONLY},
+					{Path: "/users", Method: countv1.Method_POST, Count: 288, Delta: 288, Presence: countv1.PeriodPresence_PERIOD_PRESENCE_PERIOD_ONLY},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testServer.ComparePeriods(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CountServer.ComparePeriods() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("CountServer.ComparePeriods() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_zeroFill(t *testing.T) {
+	var (
+		start = time.Date(1986, time.March, 1, 0, 0, 0, 0, time.UTC)
+		end   = time.Date(1986, time.May, 31, 0, 0, 0, 0, time.UTC)
+	)
+
+	counts := []*countv1.MethodCount{
+		{Date: &date.Date{Year: 1986, Month: 3, Day: 1}, Path: "/users", Method: countv1.Method_GET, Count: 1},
+		{Date: &date.Date{Year: 1986, Month: 5, Day: 1}, Path: "/items", Method: countv1.Method_POST, Count: 2},
+	}
+	want := []*countv1.MethodCount{
+		{Date: &date.Date{Year: 1986, Month: 3, Day: 1}, Path: "/items", Method: countv1.Method_POST},
+		{Date: &date.Date{Year: 1986, Month: 3, Day: 1}, Path: "/users", Method: countv1.Method_GET, Count: 1},
+		{Date: &date.Date{Year: 1986, Month: 4, Day: 1}, Path: "/items", Method: countv1.Method_POST},
+		{Date: &date.Date{Year: 1986, Month: 4, Day: 1}, Path: "/users", Method: countv1.Method_GET},
+		{Date: &date.Date{Year: 1986, Month: 5, Day: 1}, Path: "/items", Method: countv1.Method_POST, Count: 2},
+		{Date: &date.Date{Year: 1986, Month: 5, Day: 1}, Path: "/users", Method: countv1.Method_GET},
+	}
+
+	got := zeroFill(counts, nil, start, end, datepb.Month)
+	if len(got) != len(want) {
+		t.Fatalf("zeroFill() =\n%v\nwant\n%v", got, want)
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("zeroFill() #%d = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	// requested paths without requests.
+	want = []*countv1.MethodCount{
+		{Date: &date.Date{Year: 1986, Month: 3, Day: 1}, Path: "/foo"},
+		{Date: &date.Date{Year: 1986, Month: 3, Day: 1}, Path: "/users", Method: countv1.Method_GET, Count: 1},
+		{Date: &date.Date{Year: 1986, Month: 4, Day: 1}, Path: "/foo"},
+		{Date: &date.Date{Year: 1986, Month: 4, Day: 1}, Path: "/users", Method: countv1.Method_GET},
+		{Date: &date.Date{Year: 1986, Month: 5, Day: 1}, Path: "/foo"},
+		{Date: &date.Date{Year: 1986, Month: 5, Day: 1}, Path: "/users", Method: countv1.Method_GET},
+	}
+	got = zeroFill(counts[:1], []string{"/users", "/foo"}, start, end, datepb.Month)
+	if len(got) != len(want) {
+		t.Fatalf("zeroFill() =\n%v\nwant\n%v", got, want)
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("zeroFill() #%d = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if got = zeroFill(nil, nil, start, end, datepb.Month); len(got) != 0 {
+		t.Errorf("zeroFill() = %v, want empty", got)
+	}
+}
+
+func TestCountServer_GetTimeSeries(t *testing.T) {
+	var (
+		day1 = R.DailyTotalsBegin.Add(24 * time.Hour)
+		day2 = R.DailyTotalsBegin.Add(48 * time.Hour)
+	)
+
+	type args struct {
+		ctx context.Context
+		req *countv1.GetTimeSeriesRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *countv1.GetTimeSeriesResponse
+		wantErr bool
+	}{
+		{
+			name: "empty start",
+			args: args{R.CTX, &countv1.GetTimeSeriesRequest{
+				EndDate:    datepb.Date(day2),
+				BucketSize: countv1.BucketSize_BUCKET_SIZE_DAY,
+			}},
+			wantErr: true,
+		},
+		{
+			name: "empty end",
+			args: args{R.CTX, &countv1.GetTimeSeriesRequest{
+				StartDate:  datepb.Date(day1),
+				BucketSize: countv1.BucketSize_BUCKET_SIZE_DAY,
+			}},
+			wantErr: true,
+		},
+		{
+			name: "missing bucket size",
+			args: args{R.CTX, &countv1.GetTimeSeriesRequest{
+				StartDate: datepb.Date(day1),
+				EndDate:   datepb.Date(day2),
+			}},
+			wantErr: true,
+		},
+		{
+			name: "context error",
+			args: args{R.ErrCTX, &countv1.GetTimeSeriesRequest{
+				StartDate:  datepb.Date(day1),
+				EndDate:    datepb.Date(day2),
+				BucketSize: countv1.BucketSize_BUCKET_SIZE_DAY,
+			}},
+			wantErr: true,
+		},
+		{
+			name: "not found",
+			args: args{R.CTX, &countv1.GetTimeSeriesRequest{
+				StartDate:  datepb.Date(time.Date(1950, 1, 1, 0, 0, 0, 0, time.UTC)),
+				EndDate:    datepb.Date(time.Date(1950, 1, 2, 0, 0, 0, 0, time.UTC)),
+				BucketSize: countv1.BucketSize_BUCKET_SIZE_DAY,
+			}},
+			wantErr: true,
+		},
+		{
+			name: "zero filled path",
+			args: args{R.CTX, &countv1.GetTimeSeriesRequest{
+				StartDate:  datepb.Date(day1),
+				EndDate:    datepb.Date(day2),
+				BucketSize: countv1.BucketSize_BUCKET_SIZE_DAY,
+				Paths:      []string{"/foo"},
+			}},
+			want: &countv1.GetTimeSeriesResponse{
+				MethodCounts: []*countv1.MethodCount{
+					{Date: datepb.Date(day1), Path: "/foo"},
+					{Date: datepb.Date(day2), Path: "/foo"},
+				},
+			},
+		},
+		{
+			name: "success",
+			args: args{R.CTX, &countv1.GetTimeSeriesRequest{
+				StartDate:  datepb.Date(day1),
+				EndDate:    datepb.Date(day2),
+				BucketSize: countv1.BucketSize_BUCKET_SIZE_MONTH,
+				Paths:      []string{"/items"},
+			}},
+			want: &countv1.GetTimeSeriesResponse{
+				MethodCounts: []*countv1.MethodCount{
+					{Date: &date.Date{Year: 1986, Month: 3, Day: 1}, Path: "/items", Method: countv1.Method_DELETE, Count: 43 + 464},
+					{Date: &date.Date{Year: 1986, Month: 3, Day: 1}, Path: "/items", Method: countv1.Method_GET, Count: 211 + 589},
+					{Date: &date.Date{Year: 1986, Month: 3, Day: 1}, Path: "/items", Method: countv1.Method_GRPC, Count: 820 + 365},
+					{Date: &date.Date{Year: 1986, Month: 3, Day: 1}, Path: "/items", Method: countv1.Method_POST, Count: 740 + 849},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testServer.GetTimeSeries(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CountServer.GetTimeSeries() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("CountServer.GetTimeSeries() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
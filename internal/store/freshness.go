@@ -0,0 +1,37 @@
+package store
+
+import "context"
+
+// Freshness of the results of read methods.
+// Stores without replicas always return the latest results.
+type Freshness int
+
+const (
+	// Stale reads may be served by a read replica or follower,
+	// which may lag behind the latest writes by some seconds.
+	Stale Freshness = iota
+	// Strong reads are served by the primary and include the latest writes.
+	Strong
+)
+
+func (f Freshness) String() string {
+	if f == Strong {
+		return "strong"
+	}
+	return "stale"
+}
+
+type freshnessCtxKey struct{}
+
+// WithFreshness returns a context in which read methods
+// return results of freshness f.
+func WithFreshness(ctx context.Context, f Freshness) context.Context {
+	return context.WithValue(ctx, freshnessCtxKey{}, f)
+}
+
+// FreshnessFromContext returns the freshness set by WithFreshness.
+// Stale is returned when it is not set.
+func FreshnessFromContext(ctx context.Context) Freshness {
+	f, _ := ctx.Value(freshnessCtxKey{}).(Freshness)
+	return f
+}
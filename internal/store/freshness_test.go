@@ -0,0 +1,16 @@
+package store
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFreshnessFromContext(t *testing.T) {
+	ctx := context.Background()
+	if got := FreshnessFromContext(ctx); got != Stale {
+		t.Errorf("FreshnessFromContext() = %s, want %s", got, Stale)
+	}
+	if got := FreshnessFromContext(WithFreshness(ctx, Strong)); got != Strong {
+		t.Errorf("FreshnessFromContext() = %s, want %s", got, Strong)
+	}
+}
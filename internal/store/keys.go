@@ -0,0 +1,46 @@
+package store
+
+import (
+	"context"
+
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+)
+
+// KeyStore is implemented by stores which can persist API keys.
+// Only a hash of each key is stored.
+type KeyStore interface {
+	// CreateAPIKey stores a new API key, identified by the hash of its key.
+	// The key is bound to service, unless it is empty.
+	CreateAPIKey(ctx context.Context, name, service string, scopes []countv1.Scope, hash []byte) (*countv1.ApiKey, error)
+
+	// ListAPIKeys returns all API keys ordered by id, including revoked keys.
+	ListAPIKeys(ctx context.Context) ([]*countv1.ApiKey, error)
+
+	// RevokeAPIKey sets the revoke time of the API key with id.
+	// A NotFound error is returned when the key does not exist or is already revoked.
+	RevokeAPIKey(ctx context.Context, id int64) (*countv1.ApiKey, error)
+
+	// LookupAPIKey returns the API key with hash, which is not revoked.
+	// A NotFound error is returned when no such key exists.
+	LookupAPIKey(ctx context.Context, hash []byte) (*countv1.ApiKey, error)
+}
+
+// ScopeMask encodes scopes as a bit mask, for storage in a single column.
+func ScopeMask(scopes []countv1.Scope) int64 {
+	var mask int64
+	for _, s := range scopes {
+		mask |= 1 << s
+	}
+	return mask
+}
+
+// MaskScopes decodes a bit mask of ScopeMask into scopes, in ascending order.
+func MaskScopes(mask int64) []countv1.Scope {
+	var scopes []countv1.Scope
+	for s := countv1.Scope(0); s < 63; s++ {
+		if mask&(1<<s) != 0 {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
@@ -0,0 +1,33 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+)
+
+func TestScopeMask(t *testing.T) {
+	tests := []struct {
+		scopes []countv1.Scope
+		mask   int64
+		want   []countv1.Scope
+	}{
+		{nil, 0, nil},
+		{[]countv1.Scope{countv1.Scope_SCOPE_INGEST}, 2, []countv1.Scope{countv1.Scope_SCOPE_INGEST}},
+		{
+			[]countv1.Scope{countv1.Scope_SCOPE_ADMIN, countv1.Scope_SCOPE_READ, countv1.Scope_SCOPE_ADMIN},
+			12,
+			[]countv1.Scope{countv1.Scope_SCOPE_READ, countv1.Scope_SCOPE_ADMIN},
+		},
+	}
+	for _, tt := range tests {
+		mask := ScopeMask(tt.scopes)
+		if mask != tt.mask {
+			t.Errorf("ScopeMask(%v) = %d, want %d", tt.scopes, mask, tt.mask)
+		}
+		if got := MaskScopes(mask); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("MaskScopes(%d) = %v, want %v", mask, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/muhlemmer/count/internal/hll"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+	"google.golang.org/genproto/googleapis/type/date"
+)
+
+// EncodeLabels returns the canonical encoding of a label set:
+// a JSON object with sorted keys. The empty set is encoded as "{}".
+func EncodeLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "{}"
+	}
+	// a map of strings always encodes.
+	data, _ := json.Marshal(labels)
+	return string(data)
+}
+
+// DecodeLabels decodes a label set encoded by EncodeLabels.
+// Nil is returned for the empty set.
+func DecodeLabels(s string) (map[string]string, error) {
+	var labels map[string]string
+	if err := json.Unmarshal([]byte(s), &labels); err != nil {
+		return nil, err
+	}
+	if len(labels) == 0 {
+		return nil, nil
+	}
+	return labels, nil
+}
+
+// LabelQuery filters and groups totals by the labels of their requests.
+type LabelQuery struct {
+	// Filter only includes totals with all of these labels.
+	Filter map[string]string
+
+	// GroupBy are the label keys by which totals are grouped,
+	// in addition to date, method and path.
+	GroupBy []string
+}
+
+// Empty reports whether q neither filters nor groups.
+func (q LabelQuery) Empty() bool {
+	return len(q.Filter) == 0 && len(q.GroupBy) == 0
+}
+
+// String returns the canonical encoding of q.
+func (q LabelQuery) String() string {
+	if q.Empty() {
+		return ""
+	}
+	return EncodeLabels(q.Filter) + " by " + strings.Join(q.GroupBy, ",")
+}
+
+// LabeledTotal is a total of a method and path pair with a label set.
+type LabeledTotal struct {
+	// Date of the total, nil for the total of a period.
+	Date   *date.Date
+	Method countv1.Method
+	Path   string
+	Labels map[string]string
+	Count  int64
+
+	// Clients is nil when the store does not count distinct clients.
+	Clients *hll.Sketch
+}
+
+// match reports whether labels contains all labels of the filter.
+func (q LabelQuery) match(labels map[string]string) bool {
+	for k, v := range q.Filter {
+		if value, ok := labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// group returns the labels of the GroupBy keys.
+// Missing keys are grouped under an empty value.
+func (q LabelQuery) group(labels map[string]string) map[string]string {
+	if len(q.GroupBy) == 0 {
+		return nil
+	}
+	group := make(map[string]string, len(q.GroupBy))
+	for _, k := range q.GroupBy {
+		group[k] = labels[k]
+	}
+	return group
+}
+
+// Apply filters the totals and sums them by date, method, path
+// and the labels of the GroupBy keys, which are set on the results.
+// The distinct clients are estimated from the merged client sketches.
+// Results are ordered by date, path, method and labels.
+func (q LabelQuery) Apply(totals []LabeledTotal) []*countv1.MethodCount {
+	type groupKey struct {
+		date   string
+		method countv1.Method
+		path   string
+		labels string
+	}
+	type group struct {
+		mc      *countv1.MethodCount
+		clients *hll.Sketch
+	}
+
+	var (
+		keys   []groupKey
+		groups = make(map[groupKey]*group)
+	)
+	for _, t := range totals {
+		if !q.match(t.Labels) {
+			continue
+		}
+		labels := q.group(t.Labels)
+		k := groupKey{
+			method: t.Method,
+			path:   t.Path,
+			labels: EncodeLabels(labels),
+		}
+		if t.Date != nil {
+			k.date = datepb.Time(t.Date).Format("2006-01-02")
+		}
+
+		g, ok := groups[k]
+		if !ok {
+			g = &group{
+				mc: &countv1.MethodCount{
+					Date:   t.Date,
+					Method: t.Method,
+					Path:   t.Path,
+					Labels: labels,
+				},
+			}
+			groups[k] = g
+			keys = append(keys, k)
+		}
+		g.mc.Count += t.Count
+		if !t.Clients.Empty() {
+			if g.clients == nil {
+				g.clients = new(hll.Sketch)
+			}
+			g.clients.Merge(t.Clients)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.date != b.date {
+			return a.date < b.date
+		}
+		if a.path != b.path {
+			return a.path < b.path
+		}
+		if a.method != b.method {
+			return a.method.String() < b.method.String()
+		}
+		return a.labels < b.labels
+	})
+
+	results := make([]*countv1.MethodCount, len(keys))
+	for i, k := range keys {
+		g := groups[k]
+		g.mc.DistinctClients = g.clients.Estimate()
+		results[i] = g.mc
+	}
+	return results
+}
+
+// Labeler is implemented by stores which keep the labels of requests,
+// from RequestDetails.Labels. Label sets are stored normalized,
+// like method and path pairs, and CountDailyMethodTotals
+// sets the labels of its results.
+// The read methods are like ListDailyTotals and GetPeriodTotals,
+// with the totals filtered and grouped by q.
+type Labeler interface {
+	ListLabeledTotals(ctx context.Context, service string, start, end time.Time, q LabelQuery) ([]*countv1.MethodCount, error)
+	GetLabeledPeriodTotals(ctx context.Context, service string, start, end time.Time, q LabelQuery) ([]*countv1.MethodCount, error)
+}
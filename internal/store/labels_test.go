@@ -0,0 +1,136 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/muhlemmer/count/internal/hll"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestEncodeLabels(t *testing.T) {
+	tests := []struct {
+		labels map[string]string
+		want   string
+	}{
+		{nil, "{}"},
+		{map[string]string{}, "{}"},
+		{map[string]string{"tier": "free", "region": "eu"}, `{"region":"eu","tier":"free"}`},
+		{map[string]string{"a": `"quoted"`}, `{"a":"\"quoted\""}`},
+	}
+	for _, tt := range tests {
+		got := EncodeLabels(tt.labels)
+		if got != tt.want {
+			t.Errorf("EncodeLabels(%v) = %s, want %s", tt.labels, got, tt.want)
+		}
+
+		decoded, err := DecodeLabels(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tt.labels) == 0 {
+			if decoded != nil {
+				t.Errorf("DecodeLabels(%s) = %v, want nil", got, decoded)
+			}
+		} else if !reflect.DeepEqual(decoded, tt.labels) {
+			t.Errorf("DecodeLabels(%s) = %v, want %v", got, decoded, tt.labels)
+		}
+	}
+
+	if _, err := DecodeLabels("["); err == nil {
+		t.Error("DecodeLabels() expected error")
+	}
+}
+
+func TestLabelQuery_String(t *testing.T) {
+	tests := []struct {
+		q    LabelQuery
+		want string
+	}{
+		{LabelQuery{}, ""},
+		{LabelQuery{Filter: map[string]string{"region": "eu"}}, `{"region":"eu"} by `},
+		{LabelQuery{GroupBy: []string{"region", "tier"}}, "{} by region,tier"},
+	}
+	for _, tt := range tests {
+		if got := tt.q.String(); got != tt.want {
+			t.Errorf("LabelQuery.String() = %q, want %q", got, tt.want)
+		}
+		if got := tt.q.Empty(); got != (tt.want == "") {
+			t.Errorf("LabelQuery.Empty() = %t, want %t", got, tt.want == "")
+		}
+	}
+}
+
+func TestLabelQuery_Apply(t *testing.T) {
+	var (
+		day1 = &date.Date{Year: 2022, Month: 11, Day: 1}
+		day2 = &date.Date{Year: 2022, Month: 11, Day: 2}
+	)
+	clients := func(ids ...string) *hll.Sketch {
+		s := new(hll.Sketch)
+		for _, id := range ids {
+			s.Add(hll.Hash(id))
+		}
+		return s
+	}
+	totals := []LabeledTotal{
+		{Date: day2, Method: countv1.Method_GET, Path: "/a", Labels: map[string]string{"region": "eu"}, Count: 1},
+		{Date: day1, Method: countv1.Method_POST, Path: "/a", Labels: map[string]string{"region": "eu", "tier": "free"}, Count: 2, Clients: clients("x", "y")},
+		{Date: day1, Method: countv1.Method_GET, Path: "/a", Labels: map[string]string{"region": "us", "tier": "free"}, Count: 3, Clients: clients("y", "z")},
+		{Date: day1, Method: countv1.Method_GET, Path: "/a", Labels: map[string]string{"region": "eu", "tier": "free"}, Count: 4, Clients: clients("x")},
+		{Date: day1, Method: countv1.Method_GET, Path: "/a", Count: 5},
+	}
+
+	tests := []struct {
+		name string
+		q    LabelQuery
+		want []*countv1.MethodCount
+	}{
+		{
+			name: "empty",
+			want: []*countv1.MethodCount{
+				{Date: day1, Method: countv1.Method_GET, Path: "/a", Count: 12, DistinctClients: 3},
+				{Date: day1, Method: countv1.Method_POST, Path: "/a", Count: 2, DistinctClients: 2},
+				{Date: day2, Method: countv1.Method_GET, Path: "/a", Count: 1},
+			},
+		},
+		{
+			name: "filter",
+			q:    LabelQuery{Filter: map[string]string{"tier": "free", "region": "eu"}},
+			want: []*countv1.MethodCount{
+				{Date: day1, Method: countv1.Method_GET, Path: "/a", Count: 4, DistinctClients: 1},
+				{Date: day1, Method: countv1.Method_POST, Path: "/a", Count: 2, DistinctClients: 2},
+			},
+		},
+		{
+			name: "group by",
+			q:    LabelQuery{GroupBy: []string{"tier"}},
+			want: []*countv1.MethodCount{
+				{Date: day1, Method: countv1.Method_GET, Path: "/a", Count: 5, Labels: map[string]string{"tier": ""}},
+				{Date: day1, Method: countv1.Method_GET, Path: "/a", Count: 7, DistinctClients: 3, Labels: map[string]string{"tier": "free"}},
+				{Date: day1, Method: countv1.Method_POST, Path: "/a", Count: 2, DistinctClients: 2, Labels: map[string]string{"tier": "free"}},
+				{Date: day2, Method: countv1.Method_GET, Path: "/a", Count: 1, Labels: map[string]string{"tier": ""}},
+			},
+		},
+		{
+			name: "no match",
+			q:    LabelQuery{Filter: map[string]string{"region": "ap"}},
+			want: []*countv1.MethodCount{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.q.Apply(totals)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Apply() =\n%v\nwant\n%v", got, tt.want)
+			}
+			for i := range tt.want {
+				if !proto.Equal(got[i], tt.want[i]) {
+					t.Errorf("Apply() [%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,483 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/muhlemmer/count/internal/ddsketch"
+	"github.com/muhlemmer/count/internal/hll"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type methodPath struct {
+	service string
+	method  countv1.Method
+	path    string
+}
+
+type memoryRequest struct {
+	methodPath
+	ts      time.Time
+	details RequestDetails
+}
+
+type dailyKey struct {
+	day time.Time
+	methodPath
+
+	// labels is the encoded label set,
+	// empty for requests without labels.
+	labels string
+}
+
+// labelKey returns the labels of a dailyKey.
+func labelKey(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	return EncodeLabels(labels)
+}
+
+// Memory is a Store which keeps all data in memory.
+// It is meant for tests and development,
+// as all data is lost when the process exits.
+type Memory struct {
+	mu        sync.Mutex
+	requests  []memoryRequest
+	daily     map[dailyKey]int64
+	clients   map[dailyKey]*hll.Sketch
+	latencies map[dailyKey]*ddsketch.Sketch
+	keys      []*countv1.ApiKey
+	hashes    map[string]*countv1.ApiKey
+}
+
+var (
+	_ Store         = &Memory{}
+	_ Exporter      = &Memory{}
+	_ Importer      = &Memory{}
+	_ KeyStore      = &Memory{}
+	_ DetailCounter = &Memory{}
+	_ Labeler       = &Memory{}
+)
+
+// NewMemory returns an empty in-memory Store.
+func NewMemory() *Memory {
+	return &Memory{
+		daily:     make(map[dailyKey]int64),
+		clients:   make(map[dailyKey]*hll.Sketch),
+		latencies: make(map[dailyKey]*ddsketch.Sketch),
+		hashes:    make(map[string]*countv1.ApiKey),
+	}
+}
+
+// civilDate returns the date of t in its own location, as UTC midnight.
+func civilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// lessPath orders by service, path, then method name.
+func lessPath(a, b methodPath) bool {
+	if a.service != b.service {
+		return a.service < b.service
+	}
+	if a.path != b.path {
+		return a.path < b.path
+	}
+	return a.method.String() < b.method.String()
+}
+
+// sortedPairs returns the keys of pairs, ordered by lessPath.
+func sortedPairs[V any](pairs map[methodPath]V) []methodPath {
+	keys := make([]methodPath, 0, len(pairs))
+	for mp := range pairs {
+		keys = append(keys, mp)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return lessPath(keys[i], keys[j])
+	})
+	return keys
+}
+
+func sortDailyKeys(keys []dailyKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		if !keys[i].day.Equal(keys[j].day) {
+			return keys[i].day.Before(keys[j].day)
+		}
+		if keys[i].methodPath != keys[j].methodPath {
+			return lessPath(keys[i].methodPath, keys[j].methodPath)
+		}
+		return keys[i].labels < keys[j].labels
+	})
+}
+
+func dailyCounts(keys []dailyKey, totals map[dailyKey]int64) []*countv1.MethodCount {
+	sortDailyKeys(keys)
+
+	var results []*countv1.MethodCount
+	for _, k := range keys {
+		results = append(results, &countv1.MethodCount{
+			Method: k.method,
+			Path:   k.path,
+			Count:  totals[k],
+			Date:   datepb.Date(k.day),
+		})
+	}
+	return results
+}
+
+func (m *Memory) insert(ctx context.Context, r memoryRequest) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, r)
+	return nil
+}
+
+func (m *Memory) InsertMethodRequest(ctx context.Context, service string, method countv1.Method, path string, requestTS time.Time) error {
+	return m.insert(ctx, memoryRequest{
+		methodPath: methodPath{service, method, path},
+		ts:         requestTS,
+	})
+}
+
+func (m *Memory) InsertRequestDetails(ctx context.Context, service string, method countv1.Method, path string, requestTS time.Time, details RequestDetails) error {
+	return m.insert(ctx, memoryRequest{
+		methodPath: methodPath{service, method, path},
+		ts:         requestTS,
+		details:    details,
+	})
+}
+
+func (m *Memory) CountDailyMethodTotals(ctx context.Context, start, end time.Time) ([]*countv1.MethodCount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var (
+		remaining []memoryRequest
+		counted   = make(map[dailyKey]int64)
+		clients   = make(map[dailyKey]*hll.Sketch)
+		latencies = make(map[dailyKey]*ddsketch.Sketch)
+	)
+	for _, r := range m.requests {
+		if r.ts.Before(start) || r.ts.After(end) {
+			remaining = append(remaining, r)
+			continue
+		}
+		k := dailyKey{
+			day:        civilDate(r.ts.In(start.Location())),
+			methodPath: r.methodPath,
+			labels:     labelKey(r.details.Labels),
+		}
+		counted[k]++
+
+		if client := r.details.Client; client != nil {
+			if clients[k] == nil {
+				clients[k] = new(hll.Sketch)
+			}
+			clients[k].Add(*client)
+		}
+		if duration := r.details.Duration; duration != nil {
+			if latencies[k] == nil {
+				latencies[k] = new(ddsketch.Sketch)
+			}
+			latencies[k].Add(*duration)
+		}
+	}
+
+	keys := make([]dailyKey, 0, len(counted))
+	for k, total := range counted {
+		m.daily[k] += total
+		keys = append(keys, k)
+	}
+	for k, s := range clients {
+		if c, ok := m.clients[k]; ok {
+			c.Merge(s)
+		} else {
+			m.clients[k] = s
+		}
+	}
+	for k, s := range latencies {
+		if l, ok := m.latencies[k]; ok {
+			l.Merge(s)
+		} else {
+			m.latencies[k] = s
+		}
+	}
+	m.requests = remaining
+
+	results := dailyCounts(keys, m.daily)
+	for i, k := range keys {
+		results[i].Service = k.service
+		results[i].Labels, _ = DecodeLabels(k.labels)
+	}
+	return results, nil
+}
+
+// dailyInterval returns the keys of the daily totals of service
+// between the dates of start and end inclusive.
+func (m *Memory) dailyInterval(service string, start, end time.Time) []dailyKey {
+	start, end = civilDate(start), civilDate(end)
+
+	var keys []dailyKey
+	for k := range m.daily {
+		if k.service == service && !k.day.Before(start) && !k.day.After(end) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func (m *Memory) ListDailyTotals(ctx context.Context, service string, start, end time.Time) ([]*countv1.MethodCount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// sum the totals of all label sets.
+	sums := make(map[dailyKey]int64)
+	for _, k := range m.dailyInterval(service, start, end) {
+		sums[dailyKey{day: k.day, methodPath: k.methodPath}] += m.daily[k]
+	}
+
+	keys := make([]dailyKey, 0, len(sums))
+	for k := range sums {
+		keys = append(keys, k)
+	}
+	return dailyCounts(keys, sums), nil
+}
+
+func (m *Memory) GetPeriodTotals(ctx context.Context, service string, start, end time.Time) ([]*countv1.MethodCount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var (
+		sums    = make(map[methodPath]int64)
+		clients = make(map[methodPath]*hll.Sketch)
+	)
+	for _, k := range m.dailyInterval(service, start, end) {
+		sums[k.methodPath] += m.daily[k]
+
+		if s, ok := m.clients[k]; ok {
+			if clients[k.methodPath] == nil {
+				clients[k.methodPath] = new(hll.Sketch)
+			}
+			clients[k.methodPath].Merge(s)
+		}
+	}
+
+	var results []*countv1.MethodCount
+	for _, mp := range sortedPairs(sums) {
+		results = append(results, &countv1.MethodCount{
+			Method:          mp.method,
+			Path:            mp.path,
+			Count:           sums[mp],
+			DistinctClients: clients[mp].Estimate(),
+		})
+	}
+	return results, nil
+}
+
+func (m *Memory) GetLatencyStats(ctx context.Context, service string, start, end time.Time, quantiles []float64) ([]*countv1.LatencyStats, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	latencies := make(map[methodPath]*ddsketch.Sketch)
+	for _, k := range m.dailyInterval(service, start, end) {
+		if s, ok := m.latencies[k]; ok {
+			if latencies[k.methodPath] == nil {
+				latencies[k.methodPath] = new(ddsketch.Sketch)
+			}
+			latencies[k.methodPath].Merge(s)
+		}
+	}
+
+	var results []*countv1.LatencyStats
+	for _, mp := range sortedPairs(latencies) {
+		results = append(results, NewLatencyStats(mp.method, mp.path, latencies[mp], quantiles))
+	}
+	return results, nil
+}
+
+// labeledTotals returns the daily totals of service between
+// the dates of start and end inclusive, with their labels.
+// The date is only set when daily is true.
+func (m *Memory) labeledTotals(service string, start, end time.Time, daily bool) []LabeledTotal {
+	keys := m.dailyInterval(service, start, end)
+	sortDailyKeys(keys)
+
+	totals := make([]LabeledTotal, len(keys))
+	for i, k := range keys {
+		labels, _ := DecodeLabels(k.labels)
+		totals[i] = LabeledTotal{
+			Method:  k.method,
+			Path:    k.path,
+			Labels:  labels,
+			Count:   m.daily[k],
+			Clients: m.clients[k],
+		}
+		if daily {
+			totals[i].Date = datepb.Date(k.day)
+		}
+	}
+	return totals
+}
+
+func (m *Memory) ListLabeledTotals(ctx context.Context, service string, start, end time.Time, q LabelQuery) ([]*countv1.MethodCount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return q.Apply(m.labeledTotals(service, start, end, true)), nil
+}
+
+func (m *Memory) GetLabeledPeriodTotals(ctx context.Context, service string, start, end time.Time, q LabelQuery) ([]*countv1.MethodCount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return q.Apply(m.labeledTotals(service, start, end, false)), nil
+}
+
+func (m *Memory) ExportDailyTotals(ctx context.Context, service string, start, end time.Time, pageSize int, fn func(page []*countv1.MethodCount) error) error {
+	counts, err := m.ListDailyTotals(ctx, service, start, end)
+	if err != nil {
+		return err
+	}
+
+	for len(counts) > 0 {
+		n := pageSize
+		if n > len(counts) {
+			n = len(counts)
+		}
+		if err = fn(counts[:n]); err != nil {
+			return err
+		}
+		counts = counts[n:]
+	}
+	return nil
+}
+
+func (m *Memory) ImportDailyTotals(ctx context.Context, service string, totals []*countv1.MethodCount, mode ImportMode) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, mc := range totals {
+		k := dailyKey{
+			day:        datepb.Time(mc.GetDate()),
+			methodPath: methodPath{service, mc.GetMethod(), mc.GetPath()},
+		}
+		if mode == Replace {
+			m.daily[k] = mc.GetCount()
+		} else {
+			m.daily[k] += mc.GetCount()
+		}
+	}
+	return nil
+}
+
+func (m *Memory) CreateAPIKey(ctx context.Context, name, service string, scopes []countv1.Scope, hash []byte) (*countv1.ApiKey, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.hashes[string(hash)]; ok {
+		return nil, status.Error(codes.AlreadyExists, "create api key: key already exists")
+	}
+
+	key := &countv1.ApiKey{
+		Id:         int64(len(m.keys) + 1),
+		Name:       name,
+		Scopes:     MaskScopes(ScopeMask(scopes)),
+		CreateTime: timestamppb.Now(),
+		Service:    service,
+	}
+	m.keys = append(m.keys, key)
+	m.hashes[string(hash)] = key
+
+	return proto.Clone(key).(*countv1.ApiKey), nil
+}
+
+func (m *Memory) ListAPIKeys(ctx context.Context) ([]*countv1.ApiKey, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := make([]*countv1.ApiKey, len(m.keys))
+	for i, key := range m.keys {
+		keys[i] = proto.Clone(key).(*countv1.ApiKey)
+	}
+	return keys, nil
+}
+
+func (m *Memory) RevokeAPIKey(ctx context.Context, id int64) (*countv1.ApiKey, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if id < 1 || id > int64(len(m.keys)) || m.keys[id-1].RevokeTime != nil {
+		return nil, status.Errorf(codes.NotFound, "revoke api key: key %d not found", id)
+	}
+
+	key := m.keys[id-1]
+	key.RevokeTime = timestamppb.Now()
+	return proto.Clone(key).(*countv1.ApiKey), nil
+}
+
+func (m *Memory) LookupAPIKey(ctx context.Context, hash []byte) (*countv1.ApiKey, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key, ok := m.hashes[string(hash)]
+	if !ok || key.RevokeTime != nil {
+		return nil, status.Error(codes.NotFound, "lookup api key: key not found")
+	}
+	return proto.Clone(key).(*countv1.ApiKey), nil
+}
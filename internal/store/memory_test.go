@@ -0,0 +1,13 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/muhlemmer/count/internal/store"
+	"github.com/muhlemmer/count/internal/store/storetest"
+)
+
+func TestMemory(t *testing.T) {
+	storetest.Run(t, context.Background(), store.NewMemory())
+}
@@ -0,0 +1,146 @@
+// Package store defines the storage backend of the count service.
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/muhlemmer/count/internal/ddsketch"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// DefaultService is the service of requests which are added
+// without a service, including all requests from before
+// services were introduced.
+const DefaultService = "default"
+
+// Store persists requests and their totals.
+// Method and path pairs are namespaced by a service,
+// reads only return the totals of the requested service.
+// Read methods return an empty result, without error,
+// when no totals are found in the interval.
+// It is implemented by *db.DB and Memory.
+type Store interface {
+	// InsertMethodRequest inserts a request for a certain method and path of service.
+	InsertMethodRequest(ctx context.Context, service string, method countv1.Method, path string, requestTS time.Time) error
+
+	// CountDailyMethodTotals deletes requests between start and end inclusive,
+	// and counts them against their date in the location of start,
+	// for each service, method and path.
+	// The counts are added to existing daily totals, such as imported ones,
+	// and the resulting daily totals are returned,
+	// ordered by date, service, path and method, with their service set.
+	CountDailyMethodTotals(ctx context.Context, start, end time.Time) ([]*countv1.MethodCount, error)
+
+	// ListDailyTotals returns the daily totals of service between the dates
+	// of start and end inclusive, ordered by date, path and method.
+	// The results may be stale, unless Strong freshness is set on ctx.
+	ListDailyTotals(ctx context.Context, service string, start, end time.Time) ([]*countv1.MethodCount, error)
+
+	// GetPeriodTotals returns the sum of the daily totals of service
+	// between the dates of start and end inclusive,
+	// for each method and path pair ordered by path and method.
+	// The date of the results is not set.
+	// The results may be stale, unless Strong freshness is set on ctx.
+	GetPeriodTotals(ctx context.Context, service string, start, end time.Time) ([]*countv1.MethodCount, error)
+}
+
+// PeriodComparer is implemented by stores which can compare periods.
+type PeriodComparer interface {
+	ComparePeriods(ctx context.Context, service string, start, end, refStart, refEnd time.Time) ([]*countv1.PeriodComparison, error)
+}
+
+// TimeSeriesReader is implemented by stores which can return time series.
+type TimeSeriesReader interface {
+	GetTimeSeries(ctx context.Context, service string, start, end time.Time, precision datepb.Precision, paths []string) ([]*countv1.MethodCount, error)
+}
+
+// Pruner is implemented by stores which support retention policies.
+type Pruner interface {
+	PruneRequests(ctx context.Context, before time.Time, batchSize int) (deleted int64, err error)
+	PruneDailyTotals(ctx context.Context, before time.Time, keepPeriodTotals bool, batchSize int) (deleted, kept int64, err error)
+}
+
+// Partitioner is implemented by stores which can partition requests by day.
+type Partitioner interface {
+	PartitionRequests(ctx context.Context, loc *time.Location, days int) (created int, err error)
+}
+
+// Exporter is implemented by stores which can export daily totals
+// without loading them in memory at once.
+type Exporter interface {
+	// ExportDailyTotals calls fn with pages of at most pageSize daily totals of service
+	// between the dates of start and end inclusive, ordered by date, path and method.
+	// Iteration stops when fn returns an error, which is returned.
+	ExportDailyTotals(ctx context.Context, service string, start, end time.Time, pageSize int, fn func(page []*countv1.MethodCount) error) error
+}
+
+// RequestDetails are optional details of a request,
+// which are kept in sketches of the totals.
+type RequestDetails struct {
+	// Client is the hash of the client ID, see hll.Hash.
+	// Nil when the client is unknown.
+	Client *uint64
+
+	// Duration of the request. Nil when unknown.
+	Duration *time.Duration
+
+	// Labels of the request, only supported by a Labeler.
+	Labels map[string]string
+}
+
+// DetailCounter is implemented by stores which keep sketches of request details:
+// HyperLogLog sketches of the client hashes and DDSketches of the durations
+// of each method and path. Counting merges the sketches into the totals.
+// GetPeriodTotals sets the distinct clients of its results
+// from the merged client sketches.
+type DetailCounter interface {
+	// InsertRequestDetails is like InsertMethodRequest, for a request with details.
+	InsertRequestDetails(ctx context.Context, service string, method countv1.Method, path string, requestTS time.Time, details RequestDetails) error
+
+	// GetLatencyStats returns the estimated quantiles of the durations of service
+	// between the dates of start and end inclusive, for each method and path pair
+	// with durations, ordered by path and method.
+	// The results may be stale, unless Strong freshness is set on ctx.
+	GetLatencyStats(ctx context.Context, service string, start, end time.Time, quantiles []float64) ([]*countv1.LatencyStats, error)
+}
+
+// NewLatencyStats returns the stats of a method and path pair
+// with the quantiles estimated from latencies.
+func NewLatencyStats(method countv1.Method, path string, latencies *ddsketch.Sketch, quantiles []float64) *countv1.LatencyStats {
+	stats := &countv1.LatencyStats{
+		Method:    method,
+		Path:      path,
+		Count:     latencies.Count(),
+		Quantiles: make([]*countv1.LatencyQuantile, len(quantiles)),
+	}
+	for i, q := range quantiles {
+		stats.Quantiles[i] = &countv1.LatencyQuantile{
+			Quantile: q,
+			Duration: durationpb.New(latencies.Quantile(q)),
+		}
+	}
+	return stats
+}
+
+// ImportMode defines how imported totals are combined with existing totals.
+type ImportMode int
+
+const (
+	// Merge adds imported totals to existing totals.
+	Merge ImportMode = iota
+	// Replace overwrites existing totals with imported totals.
+	Replace
+)
+
+// Importer is implemented by stores which can import daily totals,
+// for example from access logs of the time before requests were counted.
+type Importer interface {
+	// ImportDailyTotals merges or replaces the daily total of service for the date,
+	// method and path of each total, which is created when it does not exist.
+	// A date, method and path may appear only once in totals.
+	// Totals are imported atomically: all or none are imported.
+	ImportDailyTotals(ctx context.Context, service string, totals []*countv1.MethodCount, mode ImportMode) error
+}
@@ -0,0 +1,956 @@
+// Package storetest provides a conformance test suite
+// for store.Store implementations.
+package storetest
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/muhlemmer/count/internal/ddsketch"
+	"github.com/muhlemmer/count/internal/hll"
+	"github.com/muhlemmer/count/internal/store"
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"github.com/muhlemmer/count/pkg/datepb"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Data is inserted in 1960, so that the suite can run
+// against a database which is seeded with other test data.
+var (
+	day1 = &date.Date{Year: 1960, Month: 3, Day: 1}
+	day2 = &date.Date{Year: 1960, Month: 3, Day: 2}
+	day3 = &date.Date{Year: 1960, Month: 3, Day: 5}
+
+	// day6 is counted repeatedly, in a year of its own.
+	day6  = &date.Date{Year: 1961, Month: 1, Day: 1}
+	year6 = &date.Date{Year: 1961}
+)
+
+func methodCount(d *date.Date, method countv1.Method, path string, count int64) *countv1.MethodCount {
+	return &countv1.MethodCount{
+		Method: method,
+		Path:   path,
+		Count:  count,
+		Date:   d,
+	}
+}
+
+// counted sets service on method counts,
+// as returned by CountDailyMethodTotals.
+func counted(service string, mcs ...*countv1.MethodCount) []*countv1.MethodCount {
+	for _, mc := range mcs {
+		mc.Service = service
+	}
+	return mcs
+}
+
+func assertCounts(t *testing.T, name string, got, want []*countv1.MethodCount) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("%s =\n%v\nwant\n%v", name, got, want)
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Fatalf("%s =\n%v\nwant\n%v", name, got, want)
+		}
+	}
+}
+
+func insert(t *testing.T, ctx context.Context, s store.Store, service string, method countv1.Method, path string, ts time.Time, n int) {
+	t.Helper()
+
+	for i := 0; i < n; i++ {
+		if err := s.InsertMethodRequest(ctx, service, method, path, ts); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// Run the conformance suite against s.
+// The suite may run only once against the same store,
+// as data is not cleaned up.
+func Run(t *testing.T, ctx context.Context, s store.Store) {
+	zurich, err := time.LoadLocation("Europe/Zurich")
+	if err != nil {
+		t.Fatal(err)
+	}
+	year := &date.Date{Year: 1960}
+	month := &date.Date{Year: 1960, Month: 3}
+
+	t.Run("empty", func(t *testing.T) {
+		start, end := datepb.Interval(year)
+
+		got, err := s.ListDailyTotals(ctx, store.DefaultService, start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "ListDailyTotals()", got, nil)
+
+		got, err = s.GetPeriodTotals(ctx, store.DefaultService, start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "GetPeriodTotals()", got, nil)
+
+		got, err = s.CountDailyMethodTotals(ctx, start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "CountDailyMethodTotals()", got, nil)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		start, end := datepb.Interval(year)
+
+		if err := s.InsertMethodRequest(ctx, store.DefaultService, countv1.Method_GET, "/conformance/a", start); err == nil {
+			t.Error("InsertMethodRequest() expected error")
+		}
+		if _, err := s.CountDailyMethodTotals(ctx, start, end); err == nil {
+			t.Error("CountDailyMethodTotals() expected error")
+		}
+		if _, err := s.ListDailyTotals(ctx, store.DefaultService, start, end); err == nil {
+			t.Error("ListDailyTotals() expected error")
+		}
+		if _, err := s.GetPeriodTotals(ctx, store.DefaultService, start, end); err == nil {
+			t.Error("GetPeriodTotals() expected error")
+		}
+	})
+
+	t.Run("count", func(t *testing.T) {
+		start, end := datepb.Interval(day1)
+		insert(t, ctx, s, store.DefaultService, countv1.Method_GET, "/conformance/a", start, 3)
+		insert(t, ctx, s, store.DefaultService, countv1.Method_POST, "/conformance/a", start.Add(time.Hour), 1)
+		insert(t, ctx, s, store.DefaultService, countv1.Method_GET, "/conformance/b", end, 2)
+		insert(t, ctx, s, store.DefaultService, countv1.Method_GET, "/conformance/a", end.Add(1), 1)
+
+		got, err := s.CountDailyMethodTotals(ctx, start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "CountDailyMethodTotals()", got, counted(store.DefaultService,
+			methodCount(day1, countv1.Method_GET, "/conformance/a", 3),
+			methodCount(day1, countv1.Method_POST, "/conformance/a", 1),
+			methodCount(day1, countv1.Method_GET, "/conformance/b", 2),
+		))
+
+		// counted requests are deleted
+		got, err = s.CountDailyMethodTotals(ctx, start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "CountDailyMethodTotals() repeated", got, nil)
+
+		start, end = datepb.Interval(day2)
+		got, err = s.CountDailyMethodTotals(ctx, start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "CountDailyMethodTotals() day 2", got, counted(store.DefaultService,
+			methodCount(day2, countv1.Method_GET, "/conformance/a", 1),
+		))
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		start, end := datepb.Interval(day6)
+		insert(t, ctx, s, store.DefaultService, countv1.Method_GET, "/conformance/a", start, 2)
+		if _, err := s.CountDailyMethodTotals(ctx, start, end); err != nil {
+			t.Fatal(err)
+		}
+
+		// requests which arrive after counting are added
+		insert(t, ctx, s, store.DefaultService, countv1.Method_GET, "/conformance/a", end, 1)
+		got, err := s.CountDailyMethodTotals(ctx, start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "CountDailyMethodTotals()", got, counted(store.DefaultService,
+			methodCount(day6, countv1.Method_GET, "/conformance/a", 3),
+		))
+
+		got, err = s.ListDailyTotals(ctx, store.DefaultService, start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "ListDailyTotals()", got, []*countv1.MethodCount{
+			methodCount(day6, countv1.Method_GET, "/conformance/a", 3),
+		})
+
+		start, end = datepb.Interval(year6)
+		got, err = s.GetPeriodTotals(ctx, store.DefaultService, start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "GetPeriodTotals()", got, []*countv1.MethodCount{
+			methodCount(nil, countv1.Method_GET, "/conformance/a", 3),
+		})
+	})
+
+	t.Run("time zone", func(t *testing.T) {
+		// 23:30 UTC is already the next day in Zurich.
+		insert(t, ctx, s, store.DefaultService, countv1.Method_DELETE, "/conformance/a",
+			time.Date(1960, time.March, 4, 23, 30, 0, 0, time.UTC), 1,
+		)
+
+		start, end := datepb.IntervalIn(day3, zurich)
+		got, err := s.CountDailyMethodTotals(ctx, start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "CountDailyMethodTotals()", got, counted(store.DefaultService,
+			methodCount(day3, countv1.Method_DELETE, "/conformance/a", 1),
+		))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		got, err := s.ListDailyTotals(ctx, store.DefaultService, datepb.Time(day1), datepb.Time(day3))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "ListDailyTotals()", got, []*countv1.MethodCount{
+			methodCount(day1, countv1.Method_GET, "/conformance/a", 3),
+			methodCount(day1, countv1.Method_POST, "/conformance/a", 1),
+			methodCount(day1, countv1.Method_GET, "/conformance/b", 2),
+			methodCount(day2, countv1.Method_GET, "/conformance/a", 1),
+			methodCount(day3, countv1.Method_DELETE, "/conformance/a", 1),
+		})
+
+		got, err = s.ListDailyTotals(ctx, store.DefaultService, datepb.Time(day2), datepb.Time(day2))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "ListDailyTotals() day 2", got, []*countv1.MethodCount{
+			methodCount(day2, countv1.Method_GET, "/conformance/a", 1),
+		})
+	})
+
+	t.Run("period", func(t *testing.T) {
+		want := []*countv1.MethodCount{
+			methodCount(nil, countv1.Method_DELETE, "/conformance/a", 1),
+			methodCount(nil, countv1.Method_GET, "/conformance/a", 4),
+			methodCount(nil, countv1.Method_POST, "/conformance/a", 1),
+			methodCount(nil, countv1.Method_GET, "/conformance/b", 2),
+		}
+
+		for _, period := range []*date.Date{day1, month, year} {
+			start, end := datepb.Interval(period)
+			got, err := s.GetPeriodTotals(ctx, store.DefaultService, start, end)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if period == day1 {
+				assertCounts(t, "GetPeriodTotals() day 1", got, []*countv1.MethodCount{
+					methodCount(nil, countv1.Method_GET, "/conformance/a", 3),
+					methodCount(nil, countv1.Method_POST, "/conformance/a", 1),
+					methodCount(nil, countv1.Method_GET, "/conformance/b", 2),
+				})
+				continue
+			}
+			assertCounts(t, "GetPeriodTotals()", got, want)
+		}
+
+		start, end := datepb.Interval(&date.Date{Year: 1960, Month: 4})
+		got, err := s.GetPeriodTotals(ctx, store.DefaultService, start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "GetPeriodTotals() empty month", got, nil)
+	})
+
+	t.Run("compare", func(t *testing.T) {
+		comparer, ok := s.(store.PeriodComparer)
+		if !ok {
+			t.Skip("store does not implement store.PeriodComparer")
+		}
+
+		start, _ := datepb.Interval(day2)
+		_, end := datepb.Interval(day3)
+		refStart, refEnd := datepb.Interval(day1)
+		got, err := comparer.ComparePeriods(ctx, store.DefaultService, start, end, refStart, refEnd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []*countv1.PeriodComparison{
+			{Method: countv1.Method_DELETE, Path: "/conformance/a", Count: 1, Delta: 1, Presence: countv1.PeriodPresence_PERIOD_PRESENCE_PERIOD_ONLY},
+			{Method: countv1.Method_GET, Path: "/conformance/a", Count: 1, ReferenceCount: 3, Delta: -2, RelativeChange: float64(-2) / 3, Presence: countv1.PeriodPresence_PERIOD_PRESENCE_BOTH},
+			{Method: countv1.Method_POST, Path: "/conformance/a", ReferenceCount: 1, Delta: -1, RelativeChange: -1, Presence: countv1.PeriodPresence_PERIOD_PRESENCE_REFERENCE_ONLY},
+			{Method: countv1.Method_GET, Path: "/conformance/b", ReferenceCount: 2, Delta: -2, RelativeChange: -1, Presence: countv1.PeriodPresence_PERIOD_PRESENCE_REFERENCE_ONLY},
+		}
+		if len(got) != len(want) {
+			t.Fatalf("ComparePeriods() =\n%v\nwant\n%v", got, want)
+		}
+		for i := range want {
+			if !proto.Equal(got[i], want[i]) {
+				t.Errorf("ComparePeriods() [%d] = %v, want %v", i, got[i], want[i])
+			}
+		}
+	})
+
+	t.Run("time series", func(t *testing.T) {
+		reader, ok := s.(store.TimeSeriesReader)
+		if !ok {
+			t.Skip("store does not implement store.TimeSeriesReader")
+		}
+
+		start, end := datepb.Interval(month)
+		got, err := reader.GetTimeSeries(ctx, store.DefaultService, start, end, datepb.Month, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "GetTimeSeries() month", got, []*countv1.MethodCount{
+			methodCount(day1, countv1.Method_DELETE, "/conformance/a", 1),
+			methodCount(day1, countv1.Method_GET, "/conformance/a", 4),
+			methodCount(day1, countv1.Method_POST, "/conformance/a", 1),
+			methodCount(day1, countv1.Method_GET, "/conformance/b", 2),
+		})
+
+		got, err = reader.GetTimeSeries(ctx, store.DefaultService, start, end, datepb.Day, []string{"/conformance/a"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "GetTimeSeries() day", got, []*countv1.MethodCount{
+			methodCount(day1, countv1.Method_GET, "/conformance/a", 3),
+			methodCount(day1, countv1.Method_POST, "/conformance/a", 1),
+			methodCount(day2, countv1.Method_GET, "/conformance/a", 1),
+			methodCount(day3, countv1.Method_DELETE, "/conformance/a", 1),
+		})
+	})
+
+	t.Run("export", func(t *testing.T) {
+		exporter, ok := s.(store.Exporter)
+		if !ok {
+			t.Skip("store does not implement store.Exporter")
+		}
+
+		want, err := s.ListDailyTotals(ctx, store.DefaultService, datepb.Time(day1), datepb.Time(day3))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, pageSize := range []int{1, 2, len(want), 100} {
+			var (
+				got   []*countv1.MethodCount
+				pages int
+			)
+			err := exporter.ExportDailyTotals(ctx, store.DefaultService, datepb.Time(day1), datepb.Time(day3), pageSize, func(page []*countv1.MethodCount) error {
+				if len(page) > pageSize {
+					t.Errorf("ExportDailyTotals() page size %d, want max %d", len(page), pageSize)
+				}
+				pages++
+				got = append(got, page...)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertCounts(t, "ExportDailyTotals()", got, want)
+			if wantPages := (len(want) + pageSize - 1) / pageSize; pages != wantPages {
+				t.Errorf("ExportDailyTotals() pages = %d, want %d", pages, wantPages)
+			}
+		}
+
+		errStop := errors.New("stop")
+		err = exporter.ExportDailyTotals(ctx, store.DefaultService, datepb.Time(day1), datepb.Time(day3), 1, func(page []*countv1.MethodCount) error {
+			return errStop
+		})
+		if !errors.Is(err, errStop) {
+			t.Errorf("ExportDailyTotals() err = %v, want %v", err, errStop)
+		}
+	})
+
+	t.Run("import", func(t *testing.T) {
+		importer, ok := s.(store.Importer)
+		if !ok {
+			t.Skip("store does not implement store.Importer")
+		}
+
+		day4 := &date.Date{Year: 1960, Month: 3, Day: 6}
+		err := importer.ImportDailyTotals(ctx, store.DefaultService, []*countv1.MethodCount{
+			methodCount(day1, countv1.Method_GET, "/conformance/a", 2),
+			methodCount(day4, countv1.Method_PUT, "/conformance/d", 7),
+		}, store.Merge)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := s.ListDailyTotals(ctx, store.DefaultService, datepb.Time(day1), datepb.Time(day4))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "ListDailyTotals()", got, []*countv1.MethodCount{
+			methodCount(day1, countv1.Method_GET, "/conformance/a", 5),
+			methodCount(day1, countv1.Method_POST, "/conformance/a", 1),
+			methodCount(day1, countv1.Method_GET, "/conformance/b", 2),
+			methodCount(day2, countv1.Method_GET, "/conformance/a", 1),
+			methodCount(day3, countv1.Method_DELETE, "/conformance/a", 1),
+			methodCount(day4, countv1.Method_PUT, "/conformance/d", 7),
+		})
+
+		// pre-aggregated totals are merged as well
+		for _, period := range []*date.Date{month, year} {
+			start, end := datepb.Interval(period)
+			got, err = s.GetPeriodTotals(ctx, store.DefaultService, start, end)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertCounts(t, "GetPeriodTotals()", got, []*countv1.MethodCount{
+				methodCount(nil, countv1.Method_DELETE, "/conformance/a", 1),
+				methodCount(nil, countv1.Method_GET, "/conformance/a", 6),
+				methodCount(nil, countv1.Method_POST, "/conformance/a", 1),
+				methodCount(nil, countv1.Method_GET, "/conformance/b", 2),
+				methodCount(nil, countv1.Method_PUT, "/conformance/d", 7),
+			})
+		}
+
+		err = importer.ImportDailyTotals(ctx, store.DefaultService, []*countv1.MethodCount{
+			methodCount(day1, countv1.Method_GET, "/conformance/a", 4),
+			methodCount(day4, countv1.Method_HEAD, "/conformance/d", 1),
+		}, store.Replace)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err = s.ListDailyTotals(ctx, store.DefaultService, datepb.Time(day1), datepb.Time(day1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "ListDailyTotals() replaced", got, []*countv1.MethodCount{
+			methodCount(day1, countv1.Method_GET, "/conformance/a", 4),
+			methodCount(day1, countv1.Method_POST, "/conformance/a", 1),
+			methodCount(day1, countv1.Method_GET, "/conformance/b", 2),
+		})
+
+		start, end := datepb.Interval(month)
+		got, err = s.GetPeriodTotals(ctx, store.DefaultService, start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "GetPeriodTotals() replaced", got, []*countv1.MethodCount{
+			methodCount(nil, countv1.Method_DELETE, "/conformance/a", 1),
+			methodCount(nil, countv1.Method_GET, "/conformance/a", 5),
+			methodCount(nil, countv1.Method_POST, "/conformance/a", 1),
+			methodCount(nil, countv1.Method_GET, "/conformance/b", 2),
+			methodCount(nil, countv1.Method_HEAD, "/conformance/d", 1),
+			methodCount(nil, countv1.Method_PUT, "/conformance/d", 7),
+		})
+
+		// requests counted after an import are added to the imported totals
+		err = importer.ImportDailyTotals(ctx, store.DefaultService, []*countv1.MethodCount{
+			methodCount(day6, countv1.Method_GET, "/conformance/a", 5),
+		}, store.Merge)
+		if err != nil {
+			t.Fatal(err)
+		}
+		start, end = datepb.Interval(day6)
+		insert(t, ctx, s, store.DefaultService, countv1.Method_GET, "/conformance/a", start, 1)
+		got, err = s.CountDailyMethodTotals(ctx, start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "CountDailyMethodTotals() imported", got, counted(store.DefaultService,
+			methodCount(day6, countv1.Method_GET, "/conformance/a", 9),
+		))
+
+		start, end = datepb.Interval(year6)
+		got, err = s.GetPeriodTotals(ctx, store.DefaultService, start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "GetPeriodTotals() imported", got, []*countv1.MethodCount{
+			methodCount(nil, countv1.Method_GET, "/conformance/a", 9),
+		})
+	})
+
+	t.Run("services", func(t *testing.T) {
+		const other = "conformance"
+		day5 := &date.Date{Year: 1960, Month: 3, Day: 8}
+		start, end := datepb.Interval(day5)
+		insert(t, ctx, s, other, countv1.Method_GET, "/conformance/a", start, 2)
+		insert(t, ctx, s, store.DefaultService, countv1.Method_GET, "/conformance/e", start, 1)
+
+		got, err := s.CountDailyMethodTotals(ctx, start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "CountDailyMethodTotals()", got, append(
+			counted(other, methodCount(day5, countv1.Method_GET, "/conformance/a", 2)),
+			counted(store.DefaultService, methodCount(day5, countv1.Method_GET, "/conformance/e", 1))...,
+		))
+
+		got, err = s.ListDailyTotals(ctx, other, datepb.Time(day1), datepb.Time(day5))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "ListDailyTotals() other", got, []*countv1.MethodCount{
+			methodCount(day5, countv1.Method_GET, "/conformance/a", 2),
+		})
+
+		got, err = s.ListDailyTotals(ctx, store.DefaultService, start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "ListDailyTotals() default", got, []*countv1.MethodCount{
+			methodCount(day5, countv1.Method_GET, "/conformance/e", 1),
+		})
+
+		start, end = datepb.Interval(month)
+		got, err = s.GetPeriodTotals(ctx, other, start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "GetPeriodTotals() other", got, []*countv1.MethodCount{
+			methodCount(nil, countv1.Method_GET, "/conformance/a", 2),
+		})
+
+		if importer, ok := s.(store.Importer); ok {
+			err = importer.ImportDailyTotals(ctx, other, []*countv1.MethodCount{
+				methodCount(day5, countv1.Method_GET, "/conformance/a", 3),
+			}, store.Merge)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err = s.ListDailyTotals(ctx, other, datepb.Time(day5), datepb.Time(day5))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertCounts(t, "ListDailyTotals() imported", got, []*countv1.MethodCount{
+				methodCount(day5, countv1.Method_GET, "/conformance/a", 5),
+			})
+		}
+
+		if exporter, ok := s.(store.Exporter); ok {
+			want, err := s.ListDailyTotals(ctx, other, start, end)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []*countv1.MethodCount
+			err = exporter.ExportDailyTotals(ctx, other, start, end, 100, func(page []*countv1.MethodCount) error {
+				got = append(got, page...)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertCounts(t, "ExportDailyTotals() other", got, want)
+		}
+	})
+
+	t.Run("request details", func(t *testing.T) {
+		dc, ok := s.(store.DetailCounter)
+		if !ok {
+			t.Skip("store does not implement store.DetailCounter")
+		}
+
+		const service = "details"
+		var (
+			june  = &date.Date{Year: 1960, Month: 6}
+			june1 = &date.Date{Year: 1960, Month: 6, Day: 1}
+			june2 = &date.Date{Year: 1960, Month: 6, Day: 2}
+		)
+		insertDetails := func(d *date.Date, method countv1.Method, client string, duration time.Duration) {
+			t.Helper()
+			var details store.RequestDetails
+			if client != "" {
+				hash := hll.Hash(client)
+				details.Client = &hash
+			}
+			if duration > 0 {
+				details.Duration = &duration
+			}
+			if err := dc.InsertRequestDetails(ctx, service, method, "/conformance/c", datepb.Time(d), details); err != nil {
+				t.Fatal(err)
+			}
+		}
+		insertDetails(june1, countv1.Method_GET, "a", 10*time.Millisecond)
+		insertDetails(june1, countv1.Method_GET, "b", 20*time.Millisecond)
+		insertDetails(june1, countv1.Method_GET, "b", 0)
+		insertDetails(june2, countv1.Method_GET, "b", 30*time.Millisecond)
+		insertDetails(june2, countv1.Method_GET, "c", 40*time.Millisecond)
+		insertDetails(june2, countv1.Method_POST, "", 5*time.Millisecond)
+		insert(t, ctx, s, service, countv1.Method_GET, "/conformance/c", datepb.Time(june1), 1)
+		insert(t, ctx, s, service, countv1.Method_POST, "/conformance/c", datepb.Time(june1), 1)
+
+		for _, d := range []*date.Date{june1, june2} {
+			start, end := datepb.Interval(d)
+			if _, err := s.CountDailyMethodTotals(ctx, start, end); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		withClients := func(mc *countv1.MethodCount, clients int64) *countv1.MethodCount {
+			mc.DistinctClients = clients
+			return mc
+		}
+		type latency struct {
+			method    countv1.Method
+			count     int64
+			quantiles []time.Duration
+		}
+		quantiles := []float64{0.5, 1}
+
+		tests := []struct {
+			name          string
+			period        *date.Date
+			wantCounts    []*countv1.MethodCount
+			wantLatencies []latency
+		}{
+			{
+				name:   "day",
+				period: june1,
+				wantCounts: []*countv1.MethodCount{
+					withClients(methodCount(nil, countv1.Method_GET, "/conformance/c", 4), 2),
+					methodCount(nil, countv1.Method_POST, "/conformance/c", 1),
+				},
+				wantLatencies: []latency{
+					{countv1.Method_GET, 2, []time.Duration{10 * time.Millisecond, 20 * time.Millisecond}},
+				},
+			},
+			{
+				name:   "month",
+				period: june,
+				wantCounts: []*countv1.MethodCount{
+					withClients(methodCount(nil, countv1.Method_GET, "/conformance/c", 6), 3),
+					methodCount(nil, countv1.Method_POST, "/conformance/c", 2),
+				},
+				wantLatencies: []latency{
+					{countv1.Method_GET, 4, []time.Duration{20 * time.Millisecond, 40 * time.Millisecond}},
+					{countv1.Method_POST, 1, []time.Duration{5 * time.Millisecond, 5 * time.Millisecond}},
+				},
+			},
+			{
+				name:   "year",
+				period: year,
+				wantCounts: []*countv1.MethodCount{
+					withClients(methodCount(nil, countv1.Method_GET, "/conformance/c", 6), 3),
+					methodCount(nil, countv1.Method_POST, "/conformance/c", 2),
+				},
+				wantLatencies: []latency{
+					{countv1.Method_GET, 4, []time.Duration{20 * time.Millisecond, 40 * time.Millisecond}},
+					{countv1.Method_POST, 1, []time.Duration{5 * time.Millisecond, 5 * time.Millisecond}},
+				},
+			},
+		}
+		for _, tt := range tests {
+			start, end := datepb.Interval(tt.period)
+			got, err := s.GetPeriodTotals(ctx, service, start, end)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertCounts(t, "GetPeriodTotals() "+tt.name, got, tt.wantCounts)
+
+			stats, err := dc.GetLatencyStats(ctx, service, start, end, quantiles)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(stats) != len(tt.wantLatencies) {
+				t.Fatalf("GetLatencyStats() %s = %v, want %d stats", tt.name, stats, len(tt.wantLatencies))
+			}
+			for i, want := range tt.wantLatencies {
+				ls := stats[i]
+				if ls.GetMethod() != want.method || ls.GetPath() != "/conformance/c" || ls.GetCount() != want.count {
+					t.Errorf("GetLatencyStats() %s = %v, want %s /conformance/c with count %d", tt.name, ls, want.method, want.count)
+				}
+				for j, q := range ls.GetQuantiles() {
+					d, w := q.GetDuration().AsDuration(), want.quantiles[j]
+					if q.GetQuantile() != quantiles[j] || math.Abs(float64(d-w)) > ddsketch.RelativeAccuracy*float64(w) {
+						t.Errorf("GetLatencyStats() %s quantile %v = %v, want %v", tt.name, q.GetQuantile(), d, w)
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("labels", func(t *testing.T) {
+		dc, ok := s.(store.DetailCounter)
+		if !ok {
+			t.Skip("store does not implement store.DetailCounter")
+		}
+		l, ok := s.(store.Labeler)
+		if !ok {
+			t.Skip("store does not implement store.Labeler")
+		}
+
+		const (
+			service = "labels"
+			path    = "/conformance/l"
+		)
+		var (
+			august  = &date.Date{Year: 1960, Month: 8}
+			august1 = &date.Date{Year: 1960, Month: 8, Day: 1}
+			august2 = &date.Date{Year: 1960, Month: 8, Day: 2}
+		)
+		insertLabels := func(d *date.Date, client string, labels map[string]string) {
+			t.Helper()
+			details := store.RequestDetails{Labels: labels}
+			if client != "" {
+				hash := hll.Hash(client)
+				details.Client = &hash
+			}
+			if err := dc.InsertRequestDetails(ctx, service, countv1.Method_GET, path, datepb.Time(d), details); err != nil {
+				t.Fatal(err)
+			}
+		}
+		insertLabels(august1, "a", map[string]string{"region": "eu", "tier": "free"})
+		insertLabels(august1, "b", map[string]string{"region": "eu", "tier": "free"})
+		insertLabels(august1, "c", map[string]string{"region": "us", "tier": "free"})
+		insertLabels(august2, "", map[string]string{"region": "eu", "tier": "paid"})
+		insertLabels(august2, "", map[string]string{"region": "eu", "tier": "paid"})
+		insertLabels(august2, "", map[string]string{"region": "eu", "tier": "paid"})
+		insert(t, ctx, s, service, countv1.Method_GET, path, datepb.Time(august1), 1)
+
+		start, end := datepb.Interval(august1)
+		got, err := s.CountDailyMethodTotals(ctx, start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the order of label sets depends on the store.
+		wantCounted := map[string]int64{
+			`{}`:                            1,
+			`{"region":"eu","tier":"free"}`: 2,
+			`{"region":"us","tier":"free"}`: 1,
+		}
+		if len(got) != len(wantCounted) {
+			t.Fatalf("CountDailyMethodTotals() =\n%v\nwant %v", got, wantCounted)
+		}
+		for _, mc := range got {
+			if want, ok := wantCounted[store.EncodeLabels(mc.GetLabels())]; !ok || mc.GetCount() != want || mc.GetService() != service {
+				t.Errorf("CountDailyMethodTotals() = %v, want %v", mc, wantCounted)
+			}
+		}
+		start, end = datepb.Interval(august2)
+		if _, err = s.CountDailyMethodTotals(ctx, start, end); err != nil {
+			t.Fatal(err)
+		}
+
+		withLabels := func(mc *countv1.MethodCount, clients int64, labels map[string]string) *countv1.MethodCount {
+			mc.DistinctClients = clients
+			mc.Labels = labels
+			return mc
+		}
+
+		start, end = datepb.Interval(august)
+		got, err = s.ListDailyTotals(ctx, service, start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "ListDailyTotals()", got, []*countv1.MethodCount{
+			methodCount(august1, countv1.Method_GET, path, 4),
+			methodCount(august2, countv1.Method_GET, path, 3),
+		})
+
+		got, err = s.GetPeriodTotals(ctx, service, start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "GetPeriodTotals()", got, []*countv1.MethodCount{
+			withLabels(methodCount(nil, countv1.Method_GET, path, 7), 3, nil),
+		})
+
+		tests := []struct {
+			name   string
+			list   bool
+			period *date.Date
+			q      store.LabelQuery
+			want   []*countv1.MethodCount
+		}{
+			{
+				name:   "list group by",
+				list:   true,
+				period: august,
+				q:      store.LabelQuery{GroupBy: []string{"region"}},
+				want: []*countv1.MethodCount{
+					withLabels(methodCount(august1, countv1.Method_GET, path, 1), 0, map[string]string{"region": ""}),
+					withLabels(methodCount(august1, countv1.Method_GET, path, 2), 2, map[string]string{"region": "eu"}),
+					withLabels(methodCount(august1, countv1.Method_GET, path, 1), 1, map[string]string{"region": "us"}),
+					withLabels(methodCount(august2, countv1.Method_GET, path, 3), 0, map[string]string{"region": "eu"}),
+				},
+			},
+			{
+				name:   "list filter",
+				list:   true,
+				period: august,
+				q:      store.LabelQuery{Filter: map[string]string{"tier": "free"}},
+				want: []*countv1.MethodCount{
+					withLabels(methodCount(august1, countv1.Method_GET, path, 3), 3, nil),
+				},
+			},
+			{
+				name:   "period filter and group by",
+				period: august,
+				q: store.LabelQuery{
+					Filter:  map[string]string{"region": "eu"},
+					GroupBy: []string{"tier"},
+				},
+				want: []*countv1.MethodCount{
+					withLabels(methodCount(nil, countv1.Method_GET, path, 2), 2, map[string]string{"tier": "free"}),
+					withLabels(methodCount(nil, countv1.Method_GET, path, 3), 0, map[string]string{"tier": "paid"}),
+				},
+			},
+			{
+				name:   "year",
+				period: year,
+				q:      store.LabelQuery{GroupBy: []string{"region"}},
+				want: []*countv1.MethodCount{
+					withLabels(methodCount(nil, countv1.Method_GET, path, 1), 0, map[string]string{"region": ""}),
+					withLabels(methodCount(nil, countv1.Method_GET, path, 5), 2, map[string]string{"region": "eu"}),
+					withLabels(methodCount(nil, countv1.Method_GET, path, 1), 1, map[string]string{"region": "us"}),
+				},
+			},
+			{
+				name:   "no match",
+				period: august,
+				q:      store.LabelQuery{Filter: map[string]string{"region": "ap"}},
+			},
+		}
+		for _, tt := range tests {
+			start, end := datepb.Interval(tt.period)
+			if tt.list {
+				got, err = l.ListLabeledTotals(ctx, service, start, end, tt.q)
+				if err != nil {
+					t.Fatal(err)
+				}
+				assertCounts(t, "ListLabeledTotals() "+tt.name, got, tt.want)
+			} else {
+				got, err = l.GetLabeledPeriodTotals(ctx, service, start, end, tt.q)
+				if err != nil {
+					t.Fatal(err)
+				}
+				assertCounts(t, "GetLabeledPeriodTotals() "+tt.name, got, tt.want)
+			}
+		}
+	})
+
+	t.Run("api keys", func(t *testing.T) {
+		keys, ok := s.(store.KeyStore)
+		if !ok {
+			t.Skip("store does not implement store.KeyStore")
+		}
+
+		before, err := keys.ListAPIKeys(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hash := []byte("conformance hash " + time.Now().String())
+		created, err := keys.CreateAPIKey(ctx, "conformance", "conformance", []countv1.Scope{countv1.Scope_SCOPE_READ, countv1.Scope_SCOPE_INGEST}, hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if created.GetId() == 0 || created.GetName() != "conformance" || created.GetService() != "conformance" || created.GetCreateTime() == nil || created.GetRevokeTime() != nil {
+			t.Errorf("CreateAPIKey() = %v", created)
+		}
+		wantScopes := []countv1.Scope{countv1.Scope_SCOPE_INGEST, countv1.Scope_SCOPE_READ}
+		if !reflect.DeepEqual(created.GetScopes(), wantScopes) {
+			t.Errorf("CreateAPIKey() scopes = %v, want %v", created.GetScopes(), wantScopes)
+		}
+
+		if _, err = keys.CreateAPIKey(ctx, "duplicate", "", wantScopes, hash); status.Code(err) != codes.AlreadyExists {
+			t.Errorf("CreateAPIKey() duplicate err = %v, want code %s", err, codes.AlreadyExists)
+		}
+
+		got, err := keys.LookupAPIKey(ctx, hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(got, created) {
+			t.Errorf("LookupAPIKey() =\n%v\nwant\n%v", got, created)
+		}
+		if _, err = keys.LookupAPIKey(ctx, []byte("unknown")); status.Code(err) != codes.NotFound {
+			t.Errorf("LookupAPIKey() unknown err = %v, want code %s", err, codes.NotFound)
+		}
+
+		list, err := keys.ListAPIKeys(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list) != len(before)+1 || !proto.Equal(list[len(list)-1], created) {
+			t.Errorf("ListAPIKeys() = %v, want %v last", list, created)
+		}
+
+		revoked, err := keys.RevokeAPIKey(ctx, created.GetId())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if revoked.GetRevokeTime() == nil || revoked.GetId() != created.GetId() {
+			t.Errorf("RevokeAPIKey() = %v", revoked)
+		}
+		if _, err = keys.RevokeAPIKey(ctx, created.GetId()); status.Code(err) != codes.NotFound {
+			t.Errorf("RevokeAPIKey() repeated err = %v, want code %s", err, codes.NotFound)
+		}
+		if _, err = keys.LookupAPIKey(ctx, hash); status.Code(err) != codes.NotFound {
+			t.Errorf("LookupAPIKey() revoked err = %v, want code %s", err, codes.NotFound)
+		}
+	})
+
+	t.Run("retention", func(t *testing.T) {
+		pruner, ok := s.(store.Pruner)
+		if !ok {
+			t.Skip("store does not implement store.Pruner")
+		}
+
+		const service = "retention"
+		var (
+			october1 = &date.Date{Year: 1960, Month: 10, Day: 1}
+			october2 = &date.Date{Year: 1960, Month: 10, Day: 2}
+		)
+		insert(t, ctx, s, service, countv1.Method_GET, "/conformance/r", datepb.Time(october1), 3)
+		insert(t, ctx, s, service, countv1.Method_GET, "/conformance/r", datepb.Time(october2), 1)
+
+		// requests of earlier subtests may be pruned as well.
+		deleted, err := pruner.PruneRequests(ctx, datepb.Time(october2), 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if deleted < 3 {
+			t.Errorf("PruneRequests() = %d, want at least 3", deleted)
+		}
+
+		got, err := s.ListDailyTotals(ctx, service, datepb.Time(october1), datepb.Time(october2))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "ListDailyTotals() pruned requests", got, []*countv1.MethodCount{
+			methodCount(october1, countv1.Method_GET, "/conformance/r", 3),
+		})
+
+		deleted, _, err = pruner.PruneDailyTotals(ctx, datepb.Time(october2), false, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if deleted < 1 {
+			t.Errorf("PruneDailyTotals() = %d, want at least 1", deleted)
+		}
+
+		got, err = s.ListDailyTotals(ctx, service, datepb.Time(october1), datepb.Time(october2))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCounts(t, "ListDailyTotals() pruned daily totals", got, nil)
+
+		// period totals of the pruned dates are removed as well
+		for _, period := range []*date.Date{{Year: 1960, Month: 10}, {Year: 1960}} {
+			start, end := datepb.Interval(period)
+			got, err = s.GetPeriodTotals(ctx, service, start, end)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertCounts(t, "GetPeriodTotals() pruned daily totals", got, nil)
+		}
+
+		if _, err = pruner.PruneRequests(ctx, datepb.Time(october2), 0); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("PruneRequests() err = %v, want code %s", err, codes.InvalidArgument)
+		}
+	})
+}
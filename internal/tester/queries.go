@@ -9,4 +9,6 @@ var (
 	insertRequestsSQL string
 	//go:embed queries/insert_daily_method_totals.sql
 	insertDailyMethodTotalsSQL string
+	//go:embed queries/insert_period_method_totals.sql
+	insertPeriodMethodTotalsSQL string
 )
@@ -0,0 +1,11 @@
+insert into count.monthly_method_totals
+    (month, method_id, total)
+select date_trunc('month', day::timestamp)::date, method_id, sum(total)::bigint
+from count.daily_method_totals
+group by date_trunc('month', day::timestamp)::date, method_id;
+
+insert into count.yearly_method_totals
+    (year, method_id, total)
+select date_trunc('year', day::timestamp)::date, method_id, sum(total)::bigint
+from count.daily_method_totals
+group by date_trunc('year', day::timestamp)::date, method_id;
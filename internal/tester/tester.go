@@ -178,6 +178,17 @@ func (r *Resources) dailyMethodTotalsData(ctx context.Context) {
 	}
 }
 
+// periodMethodTotalsData aggregates the daily method totals
+// into the monthly and yearly method totals.
+func (r *Resources) periodMethodTotalsData(ctx context.Context) {
+	_, err := r.Pool.Exec(ctx, insertPeriodMethodTotalsSQL)
+
+	zerolog.Ctx(ctx).Err(err).Msg("tester period method totals data insert")
+	if err != nil {
+		panic(fmt.Errorf("tester PeriodMethodTotalsData: %w", err))
+	}
+}
+
 // Run resets the database by migrating Down and Up.
 //
 // The run function is meant to iniate tests and supply
@@ -206,8 +217,12 @@ func Run(timeout time.Duration, run func(r *Resources) int) int {
 
 	migrDSN := strings.Replace(dsn, "postgresql", migrDriver, 1)
 
-	migrations.Down(migrDSN)
-	migrations.Up(migrDSN)
+	if err := migrations.Down(migrDSN); err != nil {
+		panic(err)
+	}
+	if err := migrations.Up(migrDSN); err != nil {
+		panic(err)
+	}
 
 	conf, err := pgxpool.ParseConfig(dsn)
 	if err != nil {
@@ -252,6 +267,7 @@ func RunWithData(timeout time.Duration, run func(r *Resources) int) int {
 		r.methodsData(r.CTX)
 		r.requestsData(r.CTX, 100)
 		r.dailyMethodTotalsData(r.CTX)
+		r.periodMethodTotalsData(r.CTX)
 
 		return run(r)
 	})
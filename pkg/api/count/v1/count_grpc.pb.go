@@ -26,6 +26,9 @@ type CountServiceClient interface {
 	// Datapoints are stored asynchronous, to prevent blocking at the client side.
 	// The stream is terminated by the server after the first error,
 	// which might result in some datapoints not being stored.
+	// Datapoints over a rate limit of the server are either dropped,
+	// or terminate the stream with ResourceExhausted. Datapoints over the daily
+	// quota of a service always terminate the stream with ResourceExhausted.
 	Add(ctx context.Context, opts ...grpc.CallOption) (CountService_AddClient, error)
 	// CountDailyTotals triggers a count of daily requests.
 	// Request entries for specified date are deleted, while being counted against
@@ -45,10 +48,76 @@ type CountServiceClient interface {
 	//   - day and month are zero, a list of totals for the requested year is returned.
 	//   - only day is zero, a list of totals for the requested month and year is returned.
 	//   - day and month are non zero, a list of totals for the request date is returned.
+	// Alternatively, a week or quarter can be requested.
 	//
 	// When the requested period does not result in any entries,
 	// a NotFound error will be returned.
 	GetPeriodTotals(ctx context.Context, in *GetPeriodTotalsRequest, opts ...grpc.CallOption) (*GetPeriodTotalsResponse, error)
+	// GetLatencyStats returns the estimated latency quantiles
+	// for each method and path pair, from the durations of the
+	// requests counted in the period. The period is determined
+	// like in GetPeriodTotals.
+	// Only entries which are previously created by CountDailyTotals can be returned.
+	//
+	// When the requested period has no requests with a duration,
+	// a NotFound error will be returned.
+	GetLatencyStats(ctx context.Context, in *GetLatencyStatsRequest, opts ...grpc.CallOption) (*GetLatencyStatsResponse, error)
+	// ComparePeriods returns the counts of each method and path pair
+	// for two periods, along with the absolute and relative change.
+	// Pairs which are counted in only one of the periods are included
+	// and flagged by the presence field.
+	// Only entries which are previously created by CountDailyTotals can be returned.
+	//
+	// When both periods do not result in any entries,
+	// a NotFound error will be returned.
+	ComparePeriods(ctx context.Context, in *ComparePeriodsRequest, opts ...grpc.CallOption) (*ComparePeriodsResponse, error)
+	// GetTimeSeries returns counts for each method and path pair,
+	// summed over buckets of the requested size.
+	// Only entries which are previously created by CountDailyTotals can be returned.
+	// Only days between start_date and end_date are counted,
+	// so the first and last bucket may be partial.
+	// Buckets without requests are returned with a zero count
+	// for each method and path pair that is found in the interval.
+	// Requested paths which are not found in the interval are returned
+	// with an unspecified method and a zero count for each bucket.
+	//
+	// When no paths are requested and the interval does not
+	// result in any entries, a NotFound error will be returned.
+	GetTimeSeries(ctx context.Context, in *GetTimeSeriesRequest, opts ...grpc.CallOption) (*GetTimeSeriesResponse, error)
+	// Prune applies the retention policy of the server.
+	// Request entries older than the maximum age are counted
+	// into the daily totals first, adding to existing totals, and deleted.
+	// Daily totals older than the maximum age are deleted.
+	// The monthly and yearly totals are reduced accordingly,
+	// unless down-sampling is configured.
+	// Deletion happens in batches, so a failed call may
+	// have partially pruned the data. It is safe to retry.
+	// This method is meant for administrators. The server can also be
+	// configured to prune periodically.
+	Prune(ctx context.Context, in *PruneRequest, opts ...grpc.CallOption) (*PruneResponse, error)
+	// ExportTotals streams the daily totals between start_date and end_date,
+	// in chunks of whole days. The stream ends without responses when
+	// the interval does not contain any daily totals.
+	// This method is meant for exports into other systems, such as a data warehouse.
+	ExportTotals(ctx context.Context, in *ExportTotalsRequest, opts ...grpc.CallOption) (CountService_ExportTotalsClient, error)
+	// ImportDailyTotals upserts pre-aggregated daily totals, for example from CDN logs.
+	// Each request is imported in a single transaction: all or none of the
+	// method counts are imported. Monthly and yearly totals are updated accordingly.
+	// Dates are in the time zone of the server.
+	ImportDailyTotals(ctx context.Context, in *ImportDailyTotalsRequest, opts ...grpc.CallOption) (*ImportDailyTotalsResponse, error)
+	// GetQuota returns the usage of the daily quota of a service.
+	// The quota is a soft limit, enforced by each server on its own:
+	// usage is tracked in memory, restarts at zero with the server
+	// and only includes the datapoints added to the server which
+	// answers this call.
+	GetQuota(ctx context.Context, in *GetQuotaRequest, opts ...grpc.CallOption) (*GetQuotaResponse, error)
+	// CreateApiKey creates an API key and returns the key once.
+	CreateApiKey(ctx context.Context, in *CreateApiKeyRequest, opts ...grpc.CallOption) (*CreateApiKeyResponse, error)
+	// ListApiKeys lists all API keys, without the keys themselves.
+	ListApiKeys(ctx context.Context, in *ListApiKeysRequest, opts ...grpc.CallOption) (*ListApiKeysResponse, error)
+	// RevokeApiKey revokes an API key, after which it can no longer be used.
+	// Revoked keys are kept for auditing.
+	RevokeApiKey(ctx context.Context, in *RevokeApiKeyRequest, opts ...grpc.CallOption) (*RevokeApiKeyResponse, error)
 }
 
 type countServiceClient struct {
@@ -120,6 +189,119 @@ func (c *countServiceClient) GetPeriodTotals(ctx context.Context, in *GetPeriodT
 	return out, nil
 }
 
+func (c *countServiceClient) GetLatencyStats(ctx context.Context, in *GetLatencyStatsRequest, opts ...grpc.CallOption) (*GetLatencyStatsResponse, error) {
+	out := new(GetLatencyStatsResponse)
+	err := c.cc.Invoke(ctx, "/count.v1.CountService/GetLatencyStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *countServiceClient) ComparePeriods(ctx context.Context, in *ComparePeriodsRequest, opts ...grpc.CallOption) (*ComparePeriodsResponse, error) {
+	out := new(ComparePeriodsResponse)
+	err := c.cc.Invoke(ctx, "/count.v1.CountService/ComparePeriods", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *countServiceClient) GetTimeSeries(ctx context.Context, in *GetTimeSeriesRequest, opts ...grpc.CallOption) (*GetTimeSeriesResponse, error) {
+	out := new(GetTimeSeriesResponse)
+	err := c.cc.Invoke(ctx, "/count.v1.CountService/GetTimeSeries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *countServiceClient) Prune(ctx context.Context, in *PruneRequest, opts ...grpc.CallOption) (*PruneResponse, error) {
+	out := new(PruneResponse)
+	err := c.cc.Invoke(ctx, "/count.v1.CountService/Prune", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *countServiceClient) ExportTotals(ctx context.Context, in *ExportTotalsRequest, opts ...grpc.CallOption) (CountService_ExportTotalsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CountService_ServiceDesc.Streams[1], "/count.v1.CountService/ExportTotals", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &countServiceExportTotalsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CountService_ExportTotalsClient interface {
+	Recv() (*ExportTotalsResponse, error)
+	grpc.ClientStream
+}
+
+type countServiceExportTotalsClient struct {
+	grpc.ClientStream
+}
+
+func (x *countServiceExportTotalsClient) Recv() (*ExportTotalsResponse, error) {
+	m := new(ExportTotalsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *countServiceClient) ImportDailyTotals(ctx context.Context, in *ImportDailyTotalsRequest, opts ...grpc.CallOption) (*ImportDailyTotalsResponse, error) {
+	out := new(ImportDailyTotalsResponse)
+	err := c.cc.Invoke(ctx, "/count.v1.CountService/ImportDailyTotals", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *countServiceClient) GetQuota(ctx context.Context, in *GetQuotaRequest, opts ...grpc.CallOption) (*GetQuotaResponse, error) {
+	out := new(GetQuotaResponse)
+	err := c.cc.Invoke(ctx, "/count.v1.CountService/GetQuota", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *countServiceClient) CreateApiKey(ctx context.Context, in *CreateApiKeyRequest, opts ...grpc.CallOption) (*CreateApiKeyResponse, error) {
+	out := new(CreateApiKeyResponse)
+	err := c.cc.Invoke(ctx, "/count.v1.CountService/CreateApiKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *countServiceClient) ListApiKeys(ctx context.Context, in *ListApiKeysRequest, opts ...grpc.CallOption) (*ListApiKeysResponse, error) {
+	out := new(ListApiKeysResponse)
+	err := c.cc.Invoke(ctx, "/count.v1.CountService/ListApiKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *countServiceClient) RevokeApiKey(ctx context.Context, in *RevokeApiKeyRequest, opts ...grpc.CallOption) (*RevokeApiKeyResponse, error) {
+	out := new(RevokeApiKeyResponse)
+	err := c.cc.Invoke(ctx, "/count.v1.CountService/RevokeApiKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CountServiceServer is the server API for CountService service.
 // All implementations must embed UnimplementedCountServiceServer
 // for forward compatibility
@@ -128,6 +310,9 @@ type CountServiceServer interface {
 	// Datapoints are stored asynchronous, to prevent blocking at the client side.
 	// The stream is terminated by the server after the first error,
 	// which might result in some datapoints not being stored.
+	// Datapoints over a rate limit of the server are either dropped,
+	// or terminate the stream with ResourceExhausted. Datapoints over the daily
+	// quota of a service always terminate the stream with ResourceExhausted.
 	Add(CountService_AddServer) error
 	// CountDailyTotals triggers a count of daily requests.
 	// Request entries for specified date are deleted, while being counted against
@@ -147,10 +332,76 @@ type CountServiceServer interface {
 	//   - day and month are zero, a list of totals for the requested year is returned.
 	//   - only day is zero, a list of totals for the requested month and year is returned.
 	//   - day and month are non zero, a list of totals for the request date is returned.
+	// Alternatively, a week or quarter can be requested.
 	//
 	// When the requested period does not result in any entries,
 	// a NotFound error will be returned.
 	GetPeriodTotals(context.Context, *GetPeriodTotalsRequest) (*GetPeriodTotalsResponse, error)
+	// GetLatencyStats returns the estimated latency quantiles
+	// for each method and path pair, from the durations of the
+	// requests counted in the period. The period is determined
+	// like in GetPeriodTotals.
+	// Only entries which are previously created by CountDailyTotals can be returned.
+	//
+	// When the requested period has no requests with a duration,
+	// a NotFound error will be returned.
+	GetLatencyStats(context.Context, *GetLatencyStatsRequest) (*GetLatencyStatsResponse, error)
+	// ComparePeriods returns the counts of each method and path pair
+	// for two periods, along with the absolute and relative change.
+	// Pairs which are counted in only one of the periods are included
+	// and flagged by the presence field.
+	// Only entries which are previously created by CountDailyTotals can be returned.
+	//
+	// When both periods do not result in any entries,
+	// a NotFound error will be returned.
+	ComparePeriods(context.Context, *ComparePeriodsRequest) (*ComparePeriodsResponse, error)
+	// GetTimeSeries returns counts for each method and path pair,
+	// summed over buckets of the requested size.
+	// Only entries which are previously created by CountDailyTotals can be returned.
+	// Only days between start_date and end_date are counted,
+	// so the first and last bucket may be partial.
+	// Buckets without requests are returned with a zero count
+	// for each method and path pair that is found in the interval.
+	// Requested paths which are not found in the interval are returned
+	// with an unspecified method and a zero count for each bucket.
+	//
+	// When no paths are requested and the interval does not
+	// result in any entries, a NotFound error will be returned.
+	GetTimeSeries(context.Context, *GetTimeSeriesRequest) (*GetTimeSeriesResponse, error)
+	// Prune applies the retention policy of the server.
+	// Request entries older than the maximum age are counted
+	// into the daily totals first, adding to existing totals, and deleted.
+	// Daily totals older than the maximum age are deleted.
+	// The monthly and yearly totals are reduced accordingly,
+	// unless down-sampling is configured.
+	// Deletion happens in batches, so a failed call may
+	// have partially pruned the data. It is safe to retry.
+	// This method is meant for administrators. The server can also be
+	// configured to prune periodically.
+	Prune(context.Context, *PruneRequest) (*PruneResponse, error)
+	// ExportTotals streams the daily totals between start_date and end_date,
+	// in chunks of whole days. The stream ends without responses when
+	// the interval does not contain any daily totals.
+	// This method is meant for exports into other systems, such as a data warehouse.
+	ExportTotals(*ExportTotalsRequest, CountService_ExportTotalsServer) error
+	// ImportDailyTotals upserts pre-aggregated daily totals, for example from CDN logs.
+	// Each request is imported in a single transaction: all or none of the
+	// method counts are imported. Monthly and yearly totals are updated accordingly.
+	// Dates are in the time zone of the server.
+	ImportDailyTotals(context.Context, *ImportDailyTotalsRequest) (*ImportDailyTotalsResponse, error)
+	// GetQuota returns the usage of the daily quota of a service.
+	// The quota is a soft limit, enforced by each server on its own:
+	// usage is tracked in memory, restarts at zero with the server
+	// and only includes the datapoints added to the server which
+	// answers this call.
+	GetQuota(context.Context, *GetQuotaRequest) (*GetQuotaResponse, error)
+	// CreateApiKey creates an API key and returns the key once.
+	CreateApiKey(context.Context, *CreateApiKeyRequest) (*CreateApiKeyResponse, error)
+	// ListApiKeys lists all API keys, without the keys themselves.
+	ListApiKeys(context.Context, *ListApiKeysRequest) (*ListApiKeysResponse, error)
+	// RevokeApiKey revokes an API key, after which it can no longer be used.
+	// Revoked keys are kept for auditing.
+	RevokeApiKey(context.Context, *RevokeApiKeyRequest) (*RevokeApiKeyResponse, error)
 	mustEmbedUnimplementedCountServiceServer()
 }
 
@@ -170,6 +421,36 @@ func (UnimplementedCountServiceServer) ListDailyTotals(context.Context, *ListDai
 func (UnimplementedCountServiceServer) GetPeriodTotals(context.Context, *GetPeriodTotalsRequest) (*GetPeriodTotalsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPeriodTotals not implemented")
 }
+func (UnimplementedCountServiceServer) GetLatencyStats(context.Context, *GetLatencyStatsRequest) (*GetLatencyStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLatencyStats not implemented")
+}
+func (UnimplementedCountServiceServer) ComparePeriods(context.Context, *ComparePeriodsRequest) (*ComparePeriodsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ComparePeriods not implemented")
+}
+func (UnimplementedCountServiceServer) GetTimeSeries(context.Context, *GetTimeSeriesRequest) (*GetTimeSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTimeSeries not implemented")
+}
+func (UnimplementedCountServiceServer) Prune(context.Context, *PruneRequest) (*PruneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Prune not implemented")
+}
+func (UnimplementedCountServiceServer) ExportTotals(*ExportTotalsRequest, CountService_ExportTotalsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportTotals not implemented")
+}
+func (UnimplementedCountServiceServer) ImportDailyTotals(context.Context, *ImportDailyTotalsRequest) (*ImportDailyTotalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportDailyTotals not implemented")
+}
+func (UnimplementedCountServiceServer) GetQuota(context.Context, *GetQuotaRequest) (*GetQuotaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuota not implemented")
+}
+func (UnimplementedCountServiceServer) CreateApiKey(context.Context, *CreateApiKeyRequest) (*CreateApiKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateApiKey not implemented")
+}
+func (UnimplementedCountServiceServer) ListApiKeys(context.Context, *ListApiKeysRequest) (*ListApiKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListApiKeys not implemented")
+}
+func (UnimplementedCountServiceServer) RevokeApiKey(context.Context, *RevokeApiKeyRequest) (*RevokeApiKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeApiKey not implemented")
+}
 func (UnimplementedCountServiceServer) mustEmbedUnimplementedCountServiceServer() {}
 
 // UnsafeCountServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -263,6 +544,189 @@ func _CountService_GetPeriodTotals_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CountService_GetLatencyStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLatencyStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CountServiceServer).GetLatencyStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/count.v1.CountService/GetLatencyStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CountServiceServer).GetLatencyStats(ctx, req.(*GetLatencyStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CountService_ComparePeriods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComparePeriodsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CountServiceServer).ComparePeriods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/count.v1.CountService/ComparePeriods",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CountServiceServer).ComparePeriods(ctx, req.(*ComparePeriodsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CountService_GetTimeSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTimeSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CountServiceServer).GetTimeSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/count.v1.CountService/GetTimeSeries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CountServiceServer).GetTimeSeries(ctx, req.(*GetTimeSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CountService_Prune_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CountServiceServer).Prune(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/count.v1.CountService/Prune",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CountServiceServer).Prune(ctx, req.(*PruneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CountService_ExportTotals_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportTotalsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CountServiceServer).ExportTotals(m, &countServiceExportTotalsServer{stream})
+}
+
+type CountService_ExportTotalsServer interface {
+	Send(*ExportTotalsResponse) error
+	grpc.ServerStream
+}
+
+type countServiceExportTotalsServer struct {
+	grpc.ServerStream
+}
+
+func (x *countServiceExportTotalsServer) Send(m *ExportTotalsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CountService_ImportDailyTotals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportDailyTotalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CountServiceServer).ImportDailyTotals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/count.v1.CountService/ImportDailyTotals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CountServiceServer).ImportDailyTotals(ctx, req.(*ImportDailyTotalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CountService_GetQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CountServiceServer).GetQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/count.v1.CountService/GetQuota",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CountServiceServer).GetQuota(ctx, req.(*GetQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CountService_CreateApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateApiKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CountServiceServer).CreateApiKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/count.v1.CountService/CreateApiKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CountServiceServer).CreateApiKey(ctx, req.(*CreateApiKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CountService_ListApiKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListApiKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CountServiceServer).ListApiKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/count.v1.CountService/ListApiKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CountServiceServer).ListApiKeys(ctx, req.(*ListApiKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CountService_RevokeApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeApiKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CountServiceServer).RevokeApiKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/count.v1.CountService/RevokeApiKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CountServiceServer).RevokeApiKey(ctx, req.(*RevokeApiKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CountService_ServiceDesc is the grpc.ServiceDesc for CountService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -282,6 +746,42 @@ var CountService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPeriodTotals",
 			Handler:    _CountService_GetPeriodTotals_Handler,
 		},
+		{
+			MethodName: "GetLatencyStats",
+			Handler:    _CountService_GetLatencyStats_Handler,
+		},
+		{
+			MethodName: "ComparePeriods",
+			Handler:    _CountService_ComparePeriods_Handler,
+		},
+		{
+			MethodName: "GetTimeSeries",
+			Handler:    _CountService_GetTimeSeries_Handler,
+		},
+		{
+			MethodName: "Prune",
+			Handler:    _CountService_Prune_Handler,
+		},
+		{
+			MethodName: "ImportDailyTotals",
+			Handler:    _CountService_ImportDailyTotals_Handler,
+		},
+		{
+			MethodName: "GetQuota",
+			Handler:    _CountService_GetQuota_Handler,
+		},
+		{
+			MethodName: "CreateApiKey",
+			Handler:    _CountService_CreateApiKey_Handler,
+		},
+		{
+			MethodName: "ListApiKeys",
+			Handler:    _CountService_ListApiKeys_Handler,
+		},
+		{
+			MethodName: "RevokeApiKey",
+			Handler:    _CountService_RevokeApiKey_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -289,6 +789,11 @@ var CountService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _CountService_Add_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "ExportTotals",
+			Handler:       _CountService_ExportTotals_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "count/v1/count.proto",
 }
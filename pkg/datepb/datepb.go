@@ -24,6 +24,11 @@ func extractYMD(date *date.Date) (year int, month time.Month, day int) {
 //
 // Overlowing or negative values are normalized by Go's time.Date() function.
 func Time(date *date.Date) time.Time {
+	return TimeIn(date, time.UTC)
+}
+
+// TimeIn is like Time, but returns the time at 00:00 in loc.
+func TimeIn(date *date.Date, loc *time.Location) time.Time {
 	year, month, day := extractYMD(date)
 
 	if day == 0 {
@@ -33,7 +38,7 @@ func Time(date *date.Date) time.Time {
 		}
 	}
 
-	return time.Date(int(year), time.Month(month), int(day), 0, 0, 0, 0, time.UTC)
+	return time.Date(int(year), time.Month(month), int(day), 0, 0, 0, 0, loc)
 }
 
 // Interval returns a start and end time.Time for the given period in UTC.
@@ -46,25 +51,37 @@ func Time(date *date.Date) time.Time {
 //     and ends 1ns before the end of the last day of the month.
 //   - if day and month are zero, the period starts on 1st of January 00:00 and ends on 31st of December 23:59:00
 func Interval(date *date.Date) (start, end time.Time) {
+	return IntervalIn(date, time.UTC)
+}
+
+// IntervalIn is like Interval, but returns the period in loc.
+// Days are not assumed to be 24 hours long, so the interval
+// is correct around daylight saving time transitions.
+func IntervalIn(date *date.Date, loc *time.Location) (start, end time.Time) {
 	year, month, day := extractYMD(date)
 
 	if day == 0 {
 		if month == 0 {
-			return time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC),
-				time.Date(year+1, 1, 1, 0, 0, 0, -1, time.UTC)
+			return time.Date(year, 1, 1, 0, 0, 0, 0, loc),
+				time.Date(year+1, 1, 1, 0, 0, 0, -1, loc)
 		}
 
-		return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC),
-			time.Date(year, month+1, 1, 0, 0, 0, -1, time.UTC)
+		return time.Date(year, month, 1, 0, 0, 0, 0, loc),
+			time.Date(year, month+1, 1, 0, 0, 0, -1, loc)
 	}
 
-	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC),
-		time.Date(year, month, day+1, 0, 0, 0, -1, time.UTC)
+	return time.Date(year, month, day, 0, 0, 0, 0, loc),
+		time.Date(year, month, day+1, 0, 0, 0, -1, loc)
 }
 
 // Date converts a standard time.Time to a googleapis/type/date.Date in UTC timezone.
 func Date(ts time.Time) *date.Date {
-	year, month, day := ts.UTC().Date()
+	return DateIn(ts, time.UTC)
+}
+
+// DateIn converts a standard time.Time to a googleapis/type/date.Date in loc.
+func DateIn(ts time.Time, loc *time.Location) *date.Date {
+	year, month, day := ts.In(loc).Date()
 	return &date.Date{
 		Year:  int32(year),
 		Month: int32(month),
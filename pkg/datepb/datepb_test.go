@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"testing"
 	"time"
+	_ "time/tzdata"
 
 	"google.golang.org/genproto/googleapis/type/date"
 	"google.golang.org/protobuf/proto"
@@ -131,6 +132,120 @@ func TestInterval(t *testing.T) {
 	}
 }
 
+func mustLoadLocation(t testing.TB, name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func TestTimeIn(t *testing.T) {
+	zurich := mustLoadLocation(t, "Europe/Zurich")
+
+	tests := []struct {
+		name string
+		date *date.Date
+		want time.Time
+	}{
+		{
+			name: "year",
+			date: &date.Date{Year: 2022},
+			want: time.Date(2021, time.December, 31, 23, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "summer time",
+			date: &date.Date{Year: 2022, Month: 10, Day: 16},
+			want: time.Date(2022, time.October, 15, 22, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TimeIn(tt.date, zurich)
+			if !got.Equal(tt.want) {
+				t.Errorf("TimeIn() = %v, want %v", got, tt.want)
+			}
+			if got.Location() != zurich {
+				t.Errorf("TimeIn() location = %v, want %v", got.Location(), zurich)
+			}
+		})
+	}
+}
+
+func TestIntervalIn(t *testing.T) {
+	zurich := mustLoadLocation(t, "Europe/Zurich")
+
+	tests := []struct {
+		name       string
+		date       *date.Date
+		wantStart  time.Time
+		wantEnd    time.Time
+		wantLength time.Duration
+	}{
+		{
+			name:       "regular day",
+			date:       &date.Date{Year: 2022, Month: 10, Day: 16},
+			wantStart:  time.Date(2022, time.October, 15, 22, 0, 0, 0, time.UTC),
+			wantEnd:    time.Date(2022, time.October, 16, 22, 0, 0, -1, time.UTC),
+			wantLength: 24 * time.Hour,
+		},
+		{
+			name:       "start of summer time",
+			date:       &date.Date{Year: 2022, Month: 3, Day: 27},
+			wantStart:  time.Date(2022, time.March, 26, 23, 0, 0, 0, time.UTC),
+			wantEnd:    time.Date(2022, time.March, 27, 22, 0, 0, -1, time.UTC),
+			wantLength: 23 * time.Hour,
+		},
+		{
+			name:       "end of summer time",
+			date:       &date.Date{Year: 2022, Month: 10, Day: 30},
+			wantStart:  time.Date(2022, time.October, 29, 22, 0, 0, 0, time.UTC),
+			wantEnd:    time.Date(2022, time.October, 30, 23, 0, 0, -1, time.UTC),
+			wantLength: 25 * time.Hour,
+		},
+		{
+			name:       "month with end of summer time",
+			date:       &date.Date{Year: 2022, Month: 10},
+			wantStart:  time.Date(2022, time.September, 30, 22, 0, 0, 0, time.UTC),
+			wantEnd:    time.Date(2022, time.October, 31, 23, 0, 0, -1, time.UTC),
+			wantLength: 31*24*time.Hour + time.Hour,
+		},
+		{
+			name:       "year",
+			date:       &date.Date{Year: 2022},
+			wantStart:  time.Date(2021, time.December, 31, 23, 0, 0, 0, time.UTC),
+			wantEnd:    time.Date(2022, time.December, 31, 23, 0, 0, -1, time.UTC),
+			wantLength: 365 * 24 * time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStart, gotEnd := IntervalIn(tt.date, zurich)
+			if !gotStart.Equal(tt.wantStart) {
+				t.Errorf("IntervalIn() gotStart = %v, want %v", gotStart, tt.wantStart)
+			}
+			if !gotEnd.Equal(tt.wantEnd) {
+				t.Errorf("IntervalIn() gotEnd = %v, want %v", gotEnd, tt.wantEnd)
+			}
+			if length := gotEnd.Sub(gotStart) + 1; length != tt.wantLength {
+				t.Errorf("IntervalIn() length = %v, want %v", length, tt.wantLength)
+			}
+		})
+	}
+}
+
+func TestDateIn(t *testing.T) {
+	zurich := mustLoadLocation(t, "Europe/Zurich")
+
+	// 23:30 UTC is already the next day in Zurich.
+	ts := time.Date(2022, time.March, 26, 23, 30, 0, 0, time.UTC)
+	want := &date.Date{Year: 2022, Month: 3, Day: 27}
+
+	if got := DateIn(ts, zurich); !proto.Equal(got, want) {
+		t.Errorf("DateIn() = %v, want %v", got, want)
+	}
+}
+
 func ExampleInterval_year() {
 	start, end := Interval(&date.Date{
 		Year: 1986,
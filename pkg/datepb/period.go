@@ -0,0 +1,167 @@
+package datepb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WeekInterval returns a start and end time.Time in UTC for
+// the ISO 8601 week of year. Weeks start on Monday and week 1
+// is the week containing the first Thursday of the year.
+// start is at 00:00 on Monday, end is 1ns before the next Monday.
+//
+// Overflowing or negative weeks are normalized into
+// the previous or next years.
+func WeekInterval(year, week int) (start, end time.Time) {
+	// January 4th is always in week 1.
+	start = Truncate(time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC), Week)
+	start = AddPrecision(start, Week, week-1)
+
+	return start, AddPrecision(start, Week, 1).Add(-1)
+}
+
+// WeeksInYear returns the amount of ISO 8601 weeks in year, 52 or 53.
+func WeeksInYear(year int) int {
+	// December 28th is always in the last week.
+	_, week := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return week
+}
+
+// QuarterInterval returns a start and end time.Time in UTC
+// for quarter 1-4 of year.
+// start is at 00:00 on the first day of the quarter,
+// end is 1ns before the start of the next quarter.
+//
+// Overflowing or negative quarters are normalized into
+// the previous or next years.
+func QuarterInterval(year, quarter int) (start, end time.Time) {
+	start = time.Date(year, time.Month(3*(quarter-1)+1), 1, 0, 0, 0, 0, time.UTC)
+	return start, AddPrecision(start, Quarter, 1).Add(-1)
+}
+
+// Period is a time bucket of a certain Precision.
+type Period struct {
+	// Start of the period at 00:00 UTC.
+	Start     time.Time
+	Precision Precision
+}
+
+// NewPeriod returns the period of precision p, which contains t.
+func NewPeriod(t time.Time, p Precision) Period {
+	return Period{
+		Start:     Truncate(t, p),
+		Precision: p,
+	}
+}
+
+// End returns the end of the period, which is 1ns before the start of the next.
+func (p Period) End() time.Time {
+	return p.Next().Start.Add(-1)
+}
+
+// Interval returns the start and end of the period.
+func (p Period) Interval() (start, end time.Time) {
+	return p.Start, p.End()
+}
+
+// Next returns the following period of the same precision.
+func (p Period) Next() Period {
+	return Period{
+		Start:     AddPrecision(p.Start, p.Precision, 1),
+		Precision: p.Precision,
+	}
+}
+
+// Prev returns the preceding period of the same precision.
+func (p Period) Prev() Period {
+	return Period{
+		Start:     AddPrecision(p.Start, p.Precision, -1),
+		Precision: p.Precision,
+	}
+}
+
+// String formats the period as:
+//   - Day: 2022-10-16
+//   - Week: 2022-W41
+//   - Month: 2022-10
+//   - Quarter: 2022-Q4
+//   - Year: 2022
+//
+// String panics on an unknown precision.
+func (p Period) String() string {
+	switch p.Precision {
+	case Day:
+		return p.Start.Format("2006-01-02")
+	case Week:
+		year, week := p.Start.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, week)
+	case Month:
+		return p.Start.Format("2006-01")
+	case Quarter:
+		return fmt.Sprintf("%04d-Q%d", p.Start.Year(), (p.Start.Month()-1)/3+1)
+	case Year:
+		return p.Start.Format("2006")
+	default:
+		panic(fmt.Errorf("datepb: unknown %s", p.Precision))
+	}
+}
+
+// ParsePeriod parses a period from a string,
+// in one of the formats returned by Period.String.
+// The precision is derived from the format.
+func ParsePeriod(s string) (Period, error) {
+	year, rest, _ := strings.Cut(s, "-")
+	y, err := strconv.Atoi(year)
+	if err != nil || len(year) != 4 {
+		return Period{}, fmt.Errorf("datepb: invalid year in period %q", s)
+	}
+
+	switch {
+	case rest == "":
+		return Period{Start: time.Date(y, time.January, 1, 0, 0, 0, 0, time.UTC), Precision: Year}, nil
+
+	case strings.HasPrefix(rest, "W"):
+		w, err := strconv.Atoi(rest[1:])
+		if err != nil || len(rest) != 3 || w < 1 || w > WeeksInYear(y) {
+			return Period{}, fmt.Errorf("datepb: invalid week in period %q", s)
+		}
+		start, _ := WeekInterval(y, w)
+		return Period{Start: start, Precision: Week}, nil
+
+	case strings.HasPrefix(rest, "Q"):
+		q, err := strconv.Atoi(rest[1:])
+		if err != nil || len(rest) != 2 || q < 1 || q > 4 {
+			return Period{}, fmt.Errorf("datepb: invalid quarter in period %q", s)
+		}
+		start, _ := QuarterInterval(y, q)
+		return Period{Start: start, Precision: Quarter}, nil
+
+	case len(rest) == 2:
+		start, err := time.Parse("2006-01", s)
+		if err != nil {
+			return Period{}, fmt.Errorf("datepb: invalid month in period %q", s)
+		}
+		return Period{Start: start, Precision: Month}, nil
+
+	default:
+		start, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			return Period{}, fmt.Errorf("datepb: invalid date in period %q", s)
+		}
+		return Period{Start: start, Precision: Day}, nil
+	}
+}
+
+// Periods returns all periods of precision p
+// which overlap with the interval start-end, inclusive.
+// The periods start at the Buckets of the interval.
+func Periods(start, end time.Time, p Precision) []Period {
+	buckets := Buckets(start, end, p)
+	periods := make([]Period, len(buckets))
+	for i, b := range buckets {
+		periods[i] = Period{Start: b, Precision: p}
+	}
+	return periods
+}
@@ -0,0 +1,182 @@
+package datepb
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestWeekInterval(t *testing.T) {
+	tests := []struct {
+		name      string
+		year      int
+		week      int
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "first week in previous year",
+			year:      2015,
+			week:      1,
+			wantStart: time.Date(2014, time.December, 29, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2015, time.January, 4, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:      "week 42",
+			year:      2022,
+			week:      42,
+			wantStart: time.Date(2022, time.October, 17, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2022, time.October, 23, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:      "week 53",
+			year:      2020,
+			week:      53,
+			wantStart: time.Date(2020, time.December, 28, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2021, time.January, 3, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:      "overflow",
+			year:      2022,
+			week:      53,
+			wantStart: time.Date(2023, time.January, 2, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2023, time.January, 8, 23, 59, 59, 999999999, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStart, gotEnd := WeekInterval(tt.year, tt.week)
+			if !gotStart.Equal(tt.wantStart) {
+				t.Errorf("WeekInterval() gotStart = %v, want %v", gotStart, tt.wantStart)
+			}
+			if !gotEnd.Equal(tt.wantEnd) {
+				t.Errorf("WeekInterval() gotEnd = %v, want %v", gotEnd, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestWeeksInYear(t *testing.T) {
+	tests := []struct {
+		year int
+		want int
+	}{
+		{2015, 53},
+		{2020, 53},
+		{2021, 52},
+		{2022, 52},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.year), func(t *testing.T) {
+			if got := WeeksInYear(tt.year); got != tt.want {
+				t.Errorf("WeeksInYear() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuarterInterval(t *testing.T) {
+	tests := []struct {
+		name      string
+		year      int
+		quarter   int
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "first",
+			year:      2022,
+			quarter:   1,
+			wantStart: time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2022, time.March, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:      "fourth",
+			year:      2022,
+			quarter:   4,
+			wantStart: time.Date(2022, time.October, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2022, time.December, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:      "overflow",
+			year:      2022,
+			quarter:   5,
+			wantStart: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2023, time.March, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStart, gotEnd := QuarterInterval(tt.year, tt.quarter)
+			if !gotStart.Equal(tt.wantStart) {
+				t.Errorf("QuarterInterval() gotStart = %v, want %v", gotStart, tt.wantStart)
+			}
+			if !gotEnd.Equal(tt.wantEnd) {
+				t.Errorf("QuarterInterval() gotEnd = %v, want %v", gotEnd, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestPeriod(t *testing.T) {
+	p := NewPeriod(time.Date(2022, time.October, 16, 13, 0, 0, 0, time.UTC), Quarter)
+
+	if want := time.Date(2022, time.October, 1, 0, 0, 0, 0, time.UTC); !p.Start.Equal(want) {
+		t.Errorf("NewPeriod() Start = %v, want %v", p.Start, want)
+	}
+	if want := time.Date(2022, time.December, 31, 23, 59, 59, 999999999, time.UTC); !p.End().Equal(want) {
+		t.Errorf("Period.End() = %v, want %v", p.End(), want)
+	}
+	if got, want := p.Next().String(), "2023-Q1"; got != want {
+		t.Errorf("Period.Next() = %v, want %v", got, want)
+	}
+	if got, want := p.Prev().String(), "2022-Q3"; got != want {
+		t.Errorf("Period.Prev() = %v, want %v", got, want)
+	}
+}
+
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		s         string
+		wantStart time.Time
+		wantP     Precision
+		wantErr   bool
+	}{
+		{"2022", time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC), Year, false},
+		{"2022-10", time.Date(2022, time.October, 1, 0, 0, 0, 0, time.UTC), Month, false},
+		{"2022-W42", time.Date(2022, time.October, 17, 0, 0, 0, 0, time.UTC), Week, false},
+		{"2022-Q4", time.Date(2022, time.October, 1, 0, 0, 0, 0, time.UTC), Quarter, false},
+		{"2022-10-16", time.Date(2022, time.October, 16, 0, 0, 0, 0, time.UTC), Day, false},
+		{"22", time.Time{}, 0, true},
+		{"foo", time.Time{}, 0, true},
+		{"2022-13", time.Time{}, 0, true},
+		{"2022-W53", time.Time{}, 0, true},
+		{"2022-W1", time.Time{}, 0, true},
+		{"2022-Q5", time.Time{}, 0, true},
+		{"2022-10-32", time.Time{}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParsePeriod(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParsePeriod() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.Start.Equal(tt.wantStart) || got.Precision != tt.wantP {
+				t.Errorf("ParsePeriod() = %v %v, want %v %v", got.Start, got.Precision, tt.wantStart, tt.wantP)
+			}
+			if !tt.wantErr && got.String() != tt.s {
+				t.Errorf("Period.String() = %v, want %v", got.String(), tt.s)
+			}
+		})
+	}
+}
+
+func TestPeriod_String_panic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Period.String() did not panic")
+		}
+	}()
+	_ = Period{Precision: 99}.String()
+}
@@ -0,0 +1,93 @@
+package datepb
+
+import (
+	"fmt"
+	"time"
+)
+
+// Precision defines the length of a time bucket.
+type Precision int
+
+const (
+	Day Precision = iota + 1
+	// Week is an ISO 8601 week, starting on Monday.
+	Week
+	Month
+	// Quarter is a calendar quarter, starting in
+	// January, April, July or October.
+	Quarter
+	Year
+)
+
+var precisionNames = map[Precision]string{
+	Day:     "day",
+	Week:    "week",
+	Month:   "month",
+	Quarter: "quarter",
+	Year:    "year",
+}
+
+// String returns the lower case name of the precision,
+// as understood by the SQL date_trunc function.
+func (p Precision) String() string {
+	if name, ok := precisionNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("Precision(%d)", int(p))
+}
+
+// Truncate returns the start of the bucket of precision p,
+// which contains t. The result is at 00:00 UTC.
+// Truncate panics on an unknown precision.
+func Truncate(t time.Time, p Precision) time.Time {
+	year, month, day := t.UTC().Date()
+
+	switch p {
+	case Day:
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	case Week:
+		// time.Weekday starts on Sunday,
+		// shift so that Monday is 0.
+		offset := (int(t.UTC().Weekday()) + 6) % 7
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, time.UTC)
+	case Month:
+		return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	case Quarter:
+		return time.Date(year, month-(month-1)%3, 1, 0, 0, 0, 0, time.UTC)
+	case Year:
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		panic(fmt.Errorf("datepb: unknown %s", p))
+	}
+}
+
+// AddPrecision adds n buckets of precision p to t.
+// AddPrecision panics on an unknown precision.
+func AddPrecision(t time.Time, p Precision, n int) time.Time {
+	switch p {
+	case Day:
+		return t.AddDate(0, 0, n)
+	case Week:
+		return t.AddDate(0, 0, 7*n)
+	case Month:
+		return t.AddDate(0, n, 0)
+	case Quarter:
+		return t.AddDate(0, 3*n, 0)
+	case Year:
+		return t.AddDate(n, 0, 0)
+	default:
+		panic(fmt.Errorf("datepb: unknown %s", p))
+	}
+}
+
+// Buckets returns the start times of all buckets of precision p
+// which overlap with the interval start-end, inclusive.
+func Buckets(start, end time.Time, p Precision) []time.Time {
+	var buckets []time.Time
+
+	for b := Truncate(start, p); !b.After(end); b = AddPrecision(b, p, 1) {
+		buckets = append(buckets, b)
+	}
+
+	return buckets
+}
@@ -0,0 +1,231 @@
+package datepb
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPrecision_String(t *testing.T) {
+	tests := []struct {
+		p    Precision
+		want string
+	}{
+		{Day, "day"},
+		{Week, "week"},
+		{Month, "month"},
+		{Quarter, "quarter"},
+		{Year, "year"},
+		{99, "Precision(99)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.p.String(); got != tt.want {
+				t.Errorf("Precision.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	// Tuesday
+	ts := time.Date(1986, time.March, 25, 13, 14, 15, 16, time.UTC)
+
+	tests := []struct {
+		name    string
+		p       Precision
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name: "day",
+			p:    Day,
+			want: time.Date(1986, time.March, 25, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "week",
+			p:    Week,
+			want: time.Date(1986, time.March, 24, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "month",
+			p:    Month,
+			want: time.Date(1986, time.March, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "quarter",
+			p:    Quarter,
+			want: time.Date(1986, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "year",
+			p:    Year,
+			want: time.Date(1986, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "unknown",
+			p:       99,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				err, _ := recover().(error)
+				if tt.wantErr != (err != nil) {
+					t.Errorf("Truncate() err = %v, wantErr %v", err, tt.wantErr)
+				}
+			}()
+
+			if got := Truncate(ts, tt.p); !got.Equal(tt.want) {
+				t.Errorf("Truncate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncate_week(t *testing.T) {
+	tests := []struct {
+		ts   time.Time
+		want time.Time
+	}{
+		{ // Monday
+			time.Date(1986, time.March, 24, 0, 0, 0, 0, time.UTC),
+			time.Date(1986, time.March, 24, 0, 0, 0, 0, time.UTC),
+		},
+		{ // Sunday
+			time.Date(1986, time.March, 30, 23, 0, 0, 0, time.UTC),
+			time.Date(1986, time.March, 24, 0, 0, 0, 0, time.UTC),
+		},
+		{ // Thursday, week starts in previous year
+			time.Date(2015, time.January, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2014, time.December, 29, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ts.Weekday().String(), func(t *testing.T) {
+			if got := Truncate(tt.ts, Week); !got.Equal(tt.want) {
+				t.Errorf("Truncate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddPrecision(t *testing.T) {
+	ts := time.Date(1986, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		p       Precision
+		n       int
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name: "day",
+			p:    Day,
+			n:    31,
+			want: time.Date(1986, time.April, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "week",
+			p:    Week,
+			n:    -1,
+			want: time.Date(1986, time.February, 22, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "month",
+			p:    Month,
+			n:    10,
+			want: time.Date(1987, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "quarter",
+			p:    Quarter,
+			n:    2,
+			want: time.Date(1986, time.September, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "year",
+			p:    Year,
+			n:    1,
+			want: time.Date(1987, time.March, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "unknown",
+			p:       99,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				err, _ := recover().(error)
+				if tt.wantErr != (err != nil) {
+					t.Errorf("AddPrecision() err = %v, wantErr %v", err, tt.wantErr)
+				}
+			}()
+
+			if got := AddPrecision(ts, tt.p, tt.n); !got.Equal(tt.want) {
+				t.Errorf("AddPrecision() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuckets(t *testing.T) {
+	start := time.Date(1986, time.March, 15, 0, 0, 0, 0, time.UTC)
+	end := time.Date(1986, time.April, 15, 23, 59, 59, 999999999, time.UTC)
+
+	tests := []struct {
+		name string
+		p    Precision
+		want []time.Time
+	}{
+		{
+			name: "month",
+			p:    Month,
+			want: []time.Time{
+				time.Date(1986, time.March, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(1986, time.April, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "quarter",
+			p:    Quarter,
+			want: []time.Time{
+				time.Date(1986, time.January, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(1986, time.April, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "year",
+			p:    Year,
+			want: []time.Time{
+				time.Date(1986, time.January, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Buckets(start, end, tt.p); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Buckets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func ExampleBuckets() {
+	start := time.Date(1986, time.March, 15, 0, 0, 0, 0, time.UTC)
+	end := time.Date(1986, time.April, 6, 0, 0, 0, 0, time.UTC)
+
+	for _, b := range Buckets(start, end, Week) {
+		fmt.Println(b.Format("2006-01-02"))
+	}
+	// output:
+	// 1986-03-10
+	// 1986-03-17
+	// 1986-03-24
+	// 1986-03-31
+}
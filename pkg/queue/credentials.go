@@ -0,0 +1,41 @@
+package queue
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// APIKey are per-RPC credentials, which send an API key
+// as bearer token in the authorization metadata.
+// It can be used as dial option with grpc.WithPerRPCCredentials,
+// or passed to NewCountAddClient with WithAPIKey.
+type APIKey struct {
+	Key string
+
+	// AllowInsecure allows sending the key over
+	// connections without transport security.
+	AllowInsecure bool
+}
+
+var _ credentials.PerRPCCredentials = APIKey{}
+
+func (k APIKey) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{
+		"authorization": "Bearer " + k.Key,
+	}, nil
+}
+
+func (k APIKey) RequireTransportSecurity() bool {
+	return !k.AllowInsecure
+}
+
+// WithAPIKey returns a call option which sends key on the Add stream.
+// Transport security is required, unless allowInsecure is true.
+func WithAPIKey(key string, allowInsecure bool) grpc.CallOption {
+	return grpc.PerRPCCredentials(APIKey{
+		Key:           key,
+		AllowInsecure: allowInsecure,
+	})
+}
@@ -0,0 +1,27 @@
+package queue
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestAPIKey(t *testing.T) {
+	key := APIKey{Key: "count_foo"}
+
+	got, err := key.GetRequestMetadata(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"authorization": "Bearer count_foo"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("APIKey.GetRequestMetadata() = %v, want %v", got, want)
+	}
+
+	if !key.RequireTransportSecurity() {
+		t.Error("APIKey.RequireTransportSecurity() = false, want true")
+	}
+	key.AllowInsecure = true
+	if key.RequireTransportSecurity() {
+		t.Error("APIKey.RequireTransportSecurity() = true, want false")
+	}
+}
@@ -0,0 +1,33 @@
+package queue
+
+import (
+	"net/url"
+	"strings"
+
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+)
+
+// NormalizeMethod returns the Method for the name of an HTTP method.
+// Unknown methods result in METHOD_UNSPECIFIED.
+func NormalizeMethod(name string) countv1.Method {
+	return countv1.Method(countv1.Method_value[name])
+}
+
+// NormalizePath returns the path which is counted for a request target,
+// as found in the HTTP request line. The path is unescaped and the
+// query and fragment are removed. For targets in absolute form,
+// the scheme and host are removed as well.
+// Middleware uses the same rules, so that imported access logs
+// are counted against the same paths.
+func NormalizePath(target string) string {
+	u, err := url.ParseRequestURI(target)
+	if err != nil {
+		target, _, _ = strings.Cut(target, "?")
+		target, _, _ = strings.Cut(target, "#")
+		return target
+	}
+	if u.Path == "" {
+		return "/"
+	}
+	return u.Path
+}
@@ -0,0 +1,49 @@
+package queue
+
+import (
+	"testing"
+
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+)
+
+func TestNormalizeMethod(t *testing.T) {
+	tests := []struct {
+		name string
+		want countv1.Method
+	}{
+		{"GET", countv1.Method_GET},
+		{"DELETE", countv1.Method_DELETE},
+		{"get", countv1.Method_METHOD_UNSPECIFIED},
+		{"PATCH", countv1.Method_METHOD_UNSPECIFIED},
+		{"", countv1.Method_METHOD_UNSPECIFIED},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeMethod(tt.name); got != tt.want {
+				t.Errorf("NormalizeMethod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizePath(t *testing.T) {
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{"/foo/bar", "/foo/bar"},
+		{"/foo?bar=1", "/foo"},
+		{"/foo%20bar", "/foo bar"},
+		{"http://example.com/foo?bar=1", "/foo"},
+		{"http://example.com", "/"},
+		{"*", "*"},
+		{"/%zz?a=1#b", "/%zz"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			if got := NormalizePath(tt.target); got != tt.want {
+				t.Errorf("NormalizePath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -9,10 +9,12 @@ import (
 	"sync"
 	"time"
 
-	"github.com/muhlemmer/count/internal/timer"
+	"github.com/muhlemmer/count/internal/backoff"
 	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -24,35 +26,52 @@ type request struct {
 // CountAddQueue provides a countv1.CountService_AddClient stream queue,
 // with automatic reconnect on errors.
 type CountAddQueue struct {
-	ctx    context.Context
-	client countv1.CountServiceClient
-	opts   []grpc.CallOption
+	ctx     context.Context
+	client  countv1.CountServiceClient
+	opts    []grpc.CallOption
+	service string
 
 	wg     sync.WaitGroup
 	queue  chan *request
 	stream countv1.CountService_AddClient
 }
 
+// reconnectPolicy retries reconnects until the context of the queue is done.
+var reconnectPolicy = backoff.Policy{
+	Initial: time.Second / 5,
+	Max:     time.Second * 5,
+	Jitter:  backoff.FullJitter,
+}
+
+// permanentCodes are status codes of stream errors which
+// are not resolved by reconnecting.
+var permanentCodes = map[codes.Code]bool{
+	codes.InvalidArgument:    true,
+	codes.Unauthenticated:    true,
+	codes.PermissionDenied:   true,
+	codes.Unimplemented:      true,
+	codes.FailedPrecondition: true,
+}
+
 func (c *CountAddQueue) reconnectCountAddClientStream() error {
-	for {
+	return backoff.Retry(c.ctx, reconnectPolicy, func(ctx context.Context) error {
 		stream, err := func() (countv1.CountService_AddClient, error) {
-			ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+			ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 			defer cancel()
 
 			return c.client.Add(ctx, c.opts...)
 		}()
 		zerolog.Ctx(c.ctx).Err(err).Msg("count reconnect stream")
-		if err == nil {
-			c.stream = stream
-			return nil
+		if err != nil {
+			if permanentCodes[status.Code(err)] {
+				return backoff.Permanent(err)
+			}
+			return err
 		}
 
-		select {
-		case <-timer.RandomTimer(time.Second/5, time.Second*5):
-		case <-c.ctx.Done():
-			return c.ctx.Err()
-		}
-	}
+		c.stream = stream
+		return nil
+	})
 }
 
 const countMsgDroppedFmt = "count message dropped: %s"
@@ -84,6 +103,9 @@ func (c *CountAddQueue) processQueue() {
 // The context needs to remain available for automatic reconnection.
 // When the context is expired or canceled, automatic reconnection will fail.
 // However, existing entries in the queue will still be processed, as long as the stream does not break.
+//
+// Use WithService to count requests for a service and WithAPIKey
+// to authenticate, when the server requires it.
 func NewCountAddClient(ctx context.Context, cc *grpc.ClientConn, opts ...grpc.CallOption) (*CountAddQueue, error) {
 	client := countv1.NewCountServiceClient(cc)
 	stream, err := client.Add(ctx, opts...)
@@ -92,11 +114,12 @@ func NewCountAddClient(ctx context.Context, cc *grpc.ClientConn, opts ...grpc.Ca
 	}
 
 	c := &CountAddQueue{
-		ctx:    ctx,
-		client: client,
-		opts:   opts,
-		queue:  make(chan *request, 1024),
-		stream: stream,
+		ctx:     ctx,
+		client:  client,
+		opts:    opts,
+		service: serviceFromOptions(opts),
+		queue:   make(chan *request, 1024),
+		stream:  stream,
 	}
 
 	c.wg.Add(1)
@@ -120,7 +143,7 @@ func (c *CountAddQueue) Close() {
 func (c *CountAddQueue) Queue(ctx context.Context, req *countv1.AddRequest) {
 	c.queue <- &request{
 		ctx: ctx,
-		msg: req,
+		msg: setService(req, c.service),
 	}
 }
 
@@ -132,7 +155,7 @@ func (c *CountAddQueue) QueueOrDrop(ctx context.Context, req *countv1.AddRequest
 	select {
 	case c.queue <- &request{
 		ctx: ctx,
-		msg: req,
+		msg: setService(req, c.service),
 	}:
 	default:
 		zerolog.Ctx(ctx).Warn().Msgf(countMsgDroppedFmt, "queue full")
@@ -147,8 +170,8 @@ func (c *CountAddQueue) QueueOrDrop(ctx context.Context, req *countv1.AddRequest
 func (c *CountAddQueue) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		c.QueueOrDrop(r.Context(), &countv1.AddRequest{
-			Method:           countv1.Method(countv1.Method_value[r.Method]),
-			Path:             r.URL.Path,
+			Method:           NormalizeMethod(r.Method),
+			Path:             NormalizePath(r.URL.RequestURI()),
 			RequestTimestamp: timestamppb.Now(),
 		})
 
@@ -17,7 +17,9 @@ import (
 	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -57,9 +59,19 @@ func TestMain(m *testing.M) {
 	}))
 }
 
+// unauthenticatedClient fails to open Add streams with Unauthenticated.
+type unauthenticatedClient struct {
+	countv1.CountServiceClient
+}
+
+func (unauthenticatedClient) Add(context.Context, ...grpc.CallOption) (countv1.CountService_AddClient, error) {
+	return nil, status.Error(codes.Unauthenticated, "api key required")
+}
+
 func TestCountAddQueue_reconnectCountAddClientStream(t *testing.T) {
 	type fields struct {
-		ctx context.Context
+		ctx    context.Context
+		client countv1.CountServiceClient
 	}
 	tests := []struct {
 		name    string
@@ -68,19 +80,24 @@ func TestCountAddQueue_reconnectCountAddClientStream(t *testing.T) {
 	}{
 		{
 			name:    "context error",
-			fields:  fields{R.ErrCTX},
+			fields:  fields{R.ErrCTX, testClient},
+			wantErr: true,
+		},
+		{
+			name:    "permanent error",
+			fields:  fields{R.CTX, unauthenticatedClient{}},
 			wantErr: true,
 		},
 		{
 			name:   "success",
-			fields: fields{R.CTX},
+			fields: fields{R.CTX, testClient},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &CountAddQueue{
 				ctx:    tt.fields.ctx,
-				client: testClient,
+				client: tt.fields.client,
 			}
 			if err := c.reconnectCountAddClientStream(); (err != nil) != tt.wantErr {
 				t.Errorf("CountAddQueue.reconnectCountAddClientStream() error = %v, wantErr %v", err, tt.wantErr)
@@ -0,0 +1,39 @@
+package queue
+
+import (
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"google.golang.org/grpc"
+)
+
+// serviceOption carries the service name of WithService.
+// It does not alter the Add call itself.
+type serviceOption struct {
+	grpc.EmptyCallOption
+	name string
+}
+
+// WithService returns a call option for NewCountAddClient,
+// which sets the service of queued requests that do not have a service yet.
+// Without it, the server counts requests for its default service,
+// or the service an API key is bound to.
+func WithService(name string) grpc.CallOption {
+	return serviceOption{name: name}
+}
+
+// serviceFromOptions returns the service of the last WithService option in opts.
+func serviceFromOptions(opts []grpc.CallOption) (service string) {
+	for _, opt := range opts {
+		if so, ok := opt.(serviceOption); ok {
+			service = so.name
+		}
+	}
+	return service
+}
+
+// setService sets service on req, when it is not set yet.
+func setService(req *countv1.AddRequest, service string) *countv1.AddRequest {
+	if req.GetService() == "" && service != "" {
+		req.Service = service
+	}
+	return req
+}
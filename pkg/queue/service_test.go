@@ -0,0 +1,45 @@
+package queue
+
+import (
+	"context"
+	"testing"
+
+	countv1 "github.com/muhlemmer/count/pkg/api/count/v1"
+	"google.golang.org/grpc"
+)
+
+func Test_serviceFromOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []grpc.CallOption
+		want string
+	}{
+		{"none", nil, ""},
+		{"other options", []grpc.CallOption{WithAPIKey("count_foo", true)}, ""},
+		{"service", []grpc.CallOption{WithAPIKey("count_foo", true), WithService("shop")}, "shop"},
+		{"last service", []grpc.CallOption{WithService("shop"), WithService("blog")}, "blog"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serviceFromOptions(tt.opts); got != tt.want {
+				t.Errorf("serviceFromOptions() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountAddQueue_Queue_service(t *testing.T) {
+	c := &CountAddQueue{
+		queue:   make(chan *request, 2),
+		service: "shop",
+	}
+
+	c.Queue(context.Background(), &countv1.AddRequest{Path: "/foo"})
+	c.QueueOrDrop(context.Background(), &countv1.AddRequest{Path: "/bar", Service: "blog"})
+
+	for _, want := range []string{"shop", "blog"} {
+		if got := (<-c.queue).msg.GetService(); got != want {
+			t.Errorf("queued service = %q, want %q", got, want)
+		}
+	}
+}